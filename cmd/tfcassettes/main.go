@@ -0,0 +1,58 @@
+// tfcassettes lints the cassettes recorded by the acceptance tests.
+//
+// It reports the secrets that were not anonymized, the requests the cassette matcher cannot parse or tell apart
+// from another request getting a different response, and the cassettes whose tests no longer exist.
+// With -fix, secrets are anonymized in place.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/cassettes"
+)
+
+func main() {
+	cassettesDir := flag.String("cassettes", filepath.Join("scaleway", "testdata"), "directory of the cassettes")
+	testsDir := flag.String("tests", "scaleway", "directory of the tests recording the cassettes")
+	fix := flag.Bool("fix", false, "anonymize the secrets found in the cassettes")
+	flag.Parse()
+
+	paths, err := filepath.Glob(filepath.Join(*cassettesDir, "*"+cassettes.Extension))
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	problemsCount := 0
+	for _, path := range paths {
+		problems, err := cassettes.Lint(path, *fix)
+		if err != nil {
+			log.Fatal(err)
+		}
+		for _, problem := range problems {
+			fmt.Printf("%s: %s\n", path, problem)
+		}
+		problemsCount += len(problems)
+	}
+
+	tests, err := cassettes.ParseTests(*testsDir)
+	if err != nil {
+		log.Fatal(err)
+	}
+	for _, path := range tests.Orphaned(paths) {
+		fmt.Printf("%s: cassette is orphaned, no test records it\n", path)
+		problemsCount++
+	}
+
+	if problemsCount > 0 {
+		if *fix {
+			fmt.Printf("%d problem(s) found in %d cassette(s), secrets were anonymized\n", problemsCount, len(paths))
+		} else {
+			fmt.Printf("%d problem(s) found in %d cassette(s)\n", problemsCount, len(paths))
+		}
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,191 @@
+package cassettes
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/dnaeon/go-vcr/cassette"
+)
+
+const (
+	// AnonymizedUUID replaces secrets formatted as UUIDs, like secret keys
+	AnonymizedUUID = "00000000-0000-0000-0000-000000000000"
+	// AnonymizedValue replaces the other secrets
+	AnonymizedValue = "anonymized"
+)
+
+// SensitiveFields is a map with keys listing fields that should be anonymized
+// value will be set in place of its old value
+var SensitiveFields = map[string]interface{}{
+	"secret_key": AnonymizedUUID,
+	"password":   AnonymizedValue,
+	"token":      AnonymizedValue,
+	"secret":     AnonymizedValue,
+}
+
+// SensitiveHeaders lists the request headers holding credentials, they are removed from cassettes
+var SensitiveHeaders = []string{
+	"Authorization",
+	"X-Auth-Token",
+	"x-auth-token",
+}
+
+var (
+	jwtRegexp        = regexp.MustCompile(`eyJ[A-Za-z0-9_-]{10,}\.eyJ[A-Za-z0-9_-]{10,}\.[A-Za-z0-9_-]*`)
+	privateKeyRegexp = regexp.MustCompile(`(?s)(-----BEGIN [A-Z ]*PRIVATE KEY-----).*?(-----END [A-Z ]*PRIVATE KEY-----)`)
+	kubeconfigToken  = regexp.MustCompile(`(?m)^(\s*token:\s*)\S+$`)
+)
+
+// Finding is a secret found in an interaction of a cassette
+type Finding struct {
+	// Location is where the secret is in the interaction, like a JSON path of the response body
+	Location string
+	// Rule is the name of the rule that detected the secret
+	Rule string
+}
+
+func (f Finding) String() string {
+	return fmt.Sprintf("%s (%s)", f.Location, f.Rule)
+}
+
+// anonymizer walks an interaction and anonymizes the secrets it finds, recording a finding for each of them
+type anonymizer struct {
+	findings []Finding
+	// requestBody is the body of the request, values sent by the tests themselves are not secrets
+	requestBody string
+}
+
+// isSentByTest returns true if a value of the response was in the request, like a password set in a test configuration
+func (a *anonymizer) isSentByTest(value string) bool {
+	return strings.Contains(a.requestBody, value)
+}
+
+func (a *anonymizer) report(location, rule string) {
+	a.findings = append(a.findings, Finding{Location: location, Rule: rule})
+}
+
+// anonymizeString anonymizes the secrets embedded in a string, whatever the field holding it
+func (a *anonymizer) anonymizeString(location, value string) string {
+	if jwtRegexp.MatchString(value) {
+		a.report(location, "jwt")
+		value = jwtRegexp.ReplaceAllString(value, AnonymizedValue)
+	}
+	if privateKeyRegexp.MatchString(value) && !strings.Contains(value, "\n"+AnonymizedValue+"\n") {
+		a.report(location, "private_key")
+		value = privateKeyRegexp.ReplaceAllString(value, "$1\n"+AnonymizedValue+"\n$2")
+	}
+
+	return value
+}
+
+// anonymizeKubeconfig anonymizes the tokens of a base64 encoded kubeconfig file, as returned by the k8s API
+func (a *anonymizer) anonymizeKubeconfig(location, value string) string {
+	raw, err := base64.StdEncoding.DecodeString(value)
+	if err != nil || !strings.Contains(string(raw), "kind: Config") {
+		return value
+	}
+
+	anonymized := kubeconfigToken.ReplaceAllStringFunc(string(raw), func(line string) string {
+		match := kubeconfigToken.FindStringSubmatch(line)
+		if strings.TrimSpace(line) == "token: "+AnonymizedValue {
+			return line
+		}
+		a.report(location, "kubeconfig_token")
+		return match[1] + AnonymizedValue
+	})
+
+	return base64.StdEncoding.EncodeToString([]byte(anonymized))
+}
+
+func (a *anonymizer) anonymizeJSON(location string, value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for _, key := range sortedKeys(v) {
+			fieldLocation := location + "." + key
+			if anonymizedValue, isSensitive := SensitiveFields[key]; isSensitive {
+				if fieldValue, isString := v[key].(string); isString && fieldValue != "" && fieldValue != anonymizedValue && !a.isSentByTest(fieldValue) {
+					a.report(fieldLocation, "sensitive_field")
+					v[key] = anonymizedValue
+				}
+				continue
+			}
+			if fieldValue, isString := v[key].(string); isString && key == "content" {
+				v[key] = a.anonymizeKubeconfig(fieldLocation, fieldValue)
+			}
+			v[key] = a.anonymizeJSON(fieldLocation, v[key])
+		}
+		return v
+	case []interface{}:
+		for i := range v {
+			v[i] = a.anonymizeJSON(fmt.Sprintf("%s[%d]", location, i), v[i])
+		}
+		return v
+	case string:
+		return a.anonymizeString(location, v)
+	default:
+		return v
+	}
+}
+
+// anonymizeBody anonymizes a response body, JSON bodies are anonymized field by field
+func (a *anonymizer) anonymizeBody(body string) string {
+	var jsonBody interface{}
+	if err := json.Unmarshal([]byte(body), &jsonBody); err != nil {
+		return a.anonymizeString("response.body", body)
+	}
+
+	found := len(a.findings)
+	jsonBody = a.anonymizeJSON("response.body", jsonBody)
+	if len(a.findings) == found {
+		// Keep the body untouched when it has no secret, marshaling it again would reorder its fields
+		return body
+	}
+
+	anonymizedBody, err := json.Marshal(jsonBody)
+	if err != nil {
+		return body
+	}
+
+	return string(anonymizedBody)
+}
+
+func (a *anonymizer) anonymizeInteraction(i *cassette.Interaction) {
+	for _, header := range SensitiveHeaders {
+		if _, exists := i.Request.Headers[header]; exists {
+			a.report("request.headers."+header, "sensitive_header")
+			i.Request.Headers = i.Request.Headers.Clone()
+			delete(i.Request.Headers, header)
+		}
+	}
+
+	i.Response.Body = a.anonymizeBody(i.Response.Body)
+}
+
+// Anonymize replaces the secrets of an interaction with fixed values, it returns the secrets that were found.
+// Requests bodies are left untouched as they must still match the requests of the tests.
+func Anonymize(i *cassette.Interaction) []Finding {
+	a := &anonymizer{requestBody: i.Request.Body}
+	a.anonymizeInteraction(i)
+
+	return a.findings
+}
+
+// SensitiveFieldsAnonymizer is a save filter of the recorder anonymizing the interactions of a cassette
+func SensitiveFieldsAnonymizer(i *cassette.Interaction) error {
+	Anonymize(i)
+	return nil
+}
+
+func sortedKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
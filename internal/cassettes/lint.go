@@ -0,0 +1,134 @@
+package cassettes
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/dnaeon/go-vcr/cassette"
+)
+
+// Problem is an issue found in an interaction of a cassette
+type Problem struct {
+	// Interaction is the index of the interaction in the cassette
+	Interaction int
+	Message     string
+}
+
+func (p Problem) String() string {
+	return fmt.Sprintf("interaction %d: %s", p.Interaction, p.Message)
+}
+
+// Lint checks the interactions of a cassette file.
+// It reports the secrets that were not anonymized, the requests the matcher cannot parse and the distinct requests
+// it cannot tell apart from an earlier one while they get a different response status.
+// If fix is true, the secrets are anonymized and the cassette is saved.
+func Lint(path string, fix bool) ([]Problem, error) {
+	c, err := cassette.Load(strings.TrimSuffix(path, ".yaml"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to load cassette %s: %w", path, err)
+	}
+
+	problems := []Problem(nil)
+	anonymized := false
+	for index, interaction := range c.Interactions {
+		if err := checkRequest(interaction); err != nil {
+			problems = append(problems, Problem{Interaction: index, Message: err.Error()})
+		} else if previous, found := findIndistinguishable(c.Interactions[:index], interaction); found {
+			problems = append(problems, Problem{Interaction: index, Message: fmt.Sprintf(
+				"%s %s cannot be told apart from interaction %d by the matcher but gets a %s response instead of %s, its replay depends on the order of the requests",
+				interaction.Request.Method, interaction.Request.URL, previous, interaction.Response.Status, c.Interactions[previous].Response.Status,
+			)})
+		}
+
+		findings := Anonymize(interaction)
+		anonymized = anonymized || len(findings) > 0
+		for _, finding := range findings {
+			problems = append(problems, Problem{Interaction: index, Message: "secret is not anonymized: " + finding.String()})
+		}
+	}
+
+	if fix && anonymized {
+		if err := c.Save(); err != nil {
+			return nil, fmt.Errorf("failed to save cassette %s: %w", path, err)
+		}
+	}
+
+	return problems, nil
+}
+
+// checkRequest returns an error if the recorded request of an interaction cannot be parsed by Matcher:
+// its method is unknown, its URL is not absolute or its body is neither a file, JSON, XML nor form values.
+func checkRequest(interaction *cassette.Interaction) error {
+	switch interaction.Request.Method {
+	case http.MethodGet, http.MethodHead, http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete, http.MethodOptions:
+	default:
+		return fmt.Errorf("unknown method %q", interaction.Request.Method)
+	}
+
+	requestURL, err := url.Parse(interaction.Request.URL)
+	if err != nil || !requestURL.IsAbs() {
+		return fmt.Errorf("%s %s: url is not absolute", interaction.Request.Method, interaction.Request.URL)
+	}
+
+	// Uploaded files are not parsed
+	if interaction.Request.Headers.Get("Content-Type") == "application/octet-stream" {
+		return nil
+	}
+
+	// The raw bodies of a request and its own recording are equal, the body is parsed when matched against an empty one
+	if _, err := matches(interaction, cassette.Request{Method: interaction.Request.Method, URL: interaction.Request.URL}); err != nil {
+		return fmt.Errorf("%s %s: %w", interaction.Request.Method, interaction.Request.URL, err)
+	}
+
+	return nil
+}
+
+// findIndistinguishable returns the index of an earlier interaction whose request differs from the request of the
+// interaction but cannot be told apart from it by Matcher, while its response status differs.
+// Only the requests changing a resource are checked, polling requests get different responses by design.
+func findIndistinguishable(previous []*cassette.Interaction, interaction *cassette.Interaction) (int, bool) {
+	if interaction.Request.Method == http.MethodGet || interaction.Request.Method == http.MethodHead {
+		return 0, false
+	}
+
+	for index, other := range previous {
+		if other.Request.Method != interaction.Request.Method || other.Response.Code == interaction.Response.Code {
+			continue
+		}
+		// Identical requests, like a deletion followed by the check of the destroyed resource, are always sent in order
+		if other.Request.URL == interaction.Request.URL && other.Request.Body == interaction.Request.Body {
+			continue
+		}
+		if checkRequest(other) != nil {
+			continue
+		}
+
+		matched, _ := matches(interaction, other.Request)
+		matchedBack, _ := matches(other, interaction.Request)
+		if matched && matchedBack {
+			return index, true
+		}
+	}
+
+	return 0, false
+}
+
+// matches returns true if Matcher matches the request of an interaction against a recording.
+// The body matcher panics on bodies it cannot parse, the panic is returned as an error.
+func matches(interaction *cassette.Interaction, recording cassette.Request) (matched bool, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("body cannot be parsed by the matcher: %v", r)
+		}
+	}()
+
+	request, err := http.NewRequest(interaction.Request.Method, interaction.Request.URL, strings.NewReader(interaction.Request.Body))
+	if err != nil {
+		return false, err
+	}
+	request.Header = interaction.Request.Headers
+
+	return Matcher(request, recording), nil
+}
@@ -0,0 +1,66 @@
+package cassettes
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/dnaeon/go-vcr/cassette"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckRequest(t *testing.T) {
+	i := &cassette.Interaction{
+		Request: cassette.Request{
+			Method: http.MethodPost,
+			URL:    "https://api.scaleway.com/instance/v1/zones/fr-par-1/ips?organization_id=foo",
+			Body:   `{"project":"11111111-1111-1111-1111-111111111111","tags":["foo"]}`,
+		},
+	}
+	require.NoError(t, checkRequest(i))
+
+	i.Request.Body = "name=%zz"
+	assert.ErrorContains(t, checkRequest(i), "body cannot be parsed")
+
+	i.Request.Headers = http.Header{"Content-Type": []string{"application/octet-stream"}}
+	assert.NoError(t, checkRequest(i))
+	i.Request.Headers = nil
+
+	i.Request.Body = ""
+	i.Request.URL = "/instance/v1/zones/fr-par-1/ips"
+	assert.ErrorContains(t, checkRequest(i), "url is not absolute")
+
+	i.Request.URL = "https://api.scaleway.com/instance/v1/zones/fr-par-1/ips"
+	i.Request.Method = "FETCH"
+	assert.ErrorContains(t, checkRequest(i), "unknown method")
+}
+
+func TestFindIndistinguishable(t *testing.T) {
+	interaction := func(method string, url string, body string, code int) *cassette.Interaction {
+		return &cassette.Interaction{
+			Request:  cassette.Request{Method: method, URL: url, Body: body},
+			Response: cassette.Response{Code: code, Status: http.StatusText(code)},
+		}
+	}
+	interactions := []*cassette.Interaction{
+		interaction(http.MethodPost, "https://api.scaleway.com/rdb/v1/regions/fr-par/instances", `{"name":"test-acc-rdb-1111"}`, http.StatusOK),
+		interaction(http.MethodDelete, "https://api.scaleway.com/rdb/v1/regions/fr-par/instances/1", "", http.StatusOK),
+		interaction(http.MethodGet, "https://api.scaleway.com/rdb/v1/regions/fr-par/instances/1", "", http.StatusOK),
+	}
+
+	// Names only differing by their generated suffix are matched by the same recording
+	previous, found := findIndistinguishable(interactions, interaction(http.MethodPost, "https://api.scaleway.com/rdb/v1/regions/fr-par/instances", `{"name":"test-acc-rdb-2222"}`, http.StatusConflict))
+	assert.True(t, found)
+	assert.Equal(t, 0, previous)
+
+	_, found = findIndistinguishable(interactions, interaction(http.MethodPost, "https://api.scaleway.com/rdb/v1/regions/fr-par/instances", `{"name":"test-acc-other-2222"}`, http.StatusConflict))
+	assert.False(t, found)
+
+	// A repeated deletion is always sent after the first one
+	_, found = findIndistinguishable(interactions, interaction(http.MethodDelete, "https://api.scaleway.com/rdb/v1/regions/fr-par/instances/1", "", http.StatusNotFound))
+	assert.False(t, found)
+
+	// Polling requests get different responses by design
+	_, found = findIndistinguishable(interactions, interaction(http.MethodGet, "https://api.scaleway.com/rdb/v1/regions/fr-par/instances/1?project_id=2", "", http.StatusNotFound))
+	assert.False(t, found)
+}
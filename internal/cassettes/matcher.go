@@ -0,0 +1,286 @@
+package cassettes
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/dnaeon/go-vcr/cassette"
+)
+
+// QueryMatcherIgnore contains the list of query value that should be ignored when matching requests with cassettes
+var QueryMatcherIgnore = []string{
+	"organization_id",
+}
+
+// BodyMatcherIgnore contains the list of json body keys that should be ignored when matching requests with cassettes
+var BodyMatcherIgnore = []string{
+	"organization", // like organization_id but deprecated
+	"organization_id",
+	"project_id",
+	"project", // like project_id but should be deprecated
+}
+
+// Test Generated name has format: "{prefix}-{generated_number}
+// example: test-acc-scaleway-project-3723338038624371236
+func extractTestGeneratedNamePrefix(name string) string {
+	// {prefix}-{generated}
+	//         ^
+	dashIndex := strings.LastIndex(name, "-")
+
+	generated := name[dashIndex+1:]
+	_, generatedToIntErr := strconv.ParseInt(generated, 10, 64)
+
+	if dashIndex == -1 || generatedToIntErr != nil {
+		// some are only {name}
+		return name
+	}
+
+	// {prefix}
+	return name[:dashIndex]
+}
+
+// Generated names have format: "tf-{prefix}-{generated1}-{generated2}"
+// example: tf-sg-gifted-yonath
+func extractGeneratedNamePrefix(name string) string {
+	if strings.Count(name, "-") < 3 {
+		return name
+	}
+	// tf-{prefix}-gifted-yonath
+	name = strings.TrimPrefix(name, "tf-")
+
+	// {prefix}-gifted-yonath
+	//                ^
+	dashIndex := strings.LastIndex(name, "-")
+	name = name[:dashIndex]
+	// {prefix}-gifted
+	//         ^
+	dashIndex = strings.LastIndex(name, "-")
+	name = name[:dashIndex]
+	return name
+}
+
+// compareJSONFieldsStrings compare two strings from request JSON bodies
+// has special case when string are terraform generated names
+func compareJSONFieldsStrings(expected, actual string) bool {
+	expectedHandled := expected
+	actualHandled := actual
+
+	// Remove s3 url suffix to allow comparison
+	if strings.HasSuffix(actual, ".s3-website.fr-par.scw.cloud") {
+		actual = strings.TrimSuffix(actual, ".s3-website.fr-par.scw.cloud")
+		expected = strings.TrimSuffix(expected, ".s3-website.fr-par.scw.cloud")
+	}
+
+	// Try to parse test generated name
+	if strings.Contains(actual, "-") {
+		expectedHandled = extractTestGeneratedNamePrefix(expected)
+		actualHandled = extractTestGeneratedNamePrefix(actual)
+	}
+
+	// Try provider generated name
+	if actualHandled == actual && strings.HasPrefix(actual, "tf-") {
+		expectedHandled = extractGeneratedNamePrefix(expected)
+		actualHandled = extractGeneratedNamePrefix(actual)
+	}
+
+	return expectedHandled == actualHandled
+}
+
+func compareJSONFields(expected, actualI interface{}) bool {
+	switch actual := actualI.(type) {
+	case string:
+		if _, isString := expected.(string); !isString {
+			return false
+		}
+		return compareJSONFieldsStrings(expected.(string), actual)
+	default:
+		// Consider equality when not handled
+		return true
+	}
+}
+
+// compareJSONBodies compare two given maps that represent json bodies
+// returns true if both json are equivalent
+func compareJSONBodies(expected, actual map[string]interface{}) bool {
+	// Check for each key in actual requests
+	// Compare its value to cassette content if marshal-able to string
+	for key := range actual {
+		expectedValue, exists := expected[key]
+		if !exists {
+			// Actual request may contain a field that does not exist in cassette
+			// New fields can appear in requests with new api features
+			// We do not want to generate new cassettes for each new features
+			continue
+		}
+		if !compareJSONFields(expectedValue, actual[key]) {
+			return false
+		}
+	}
+
+	for key := range expected {
+		_, exists := actual[key]
+		if !exists && expected[key] != nil {
+			// Fails match if cassettes contains a field not in actual requests
+			// Fields should not disappear from requests unless a sdk breaking change
+			// We ignore if field is nil in cassette as it could be an old deprecated and unused field
+			return false
+		}
+	}
+	return true
+}
+
+// compareFormBodies compare two given url.Values
+// returns true if both url.Values are equivalent
+func compareFormBodies(expected, actual url.Values) bool {
+	// Check for each key in actual requests
+	// Compare its value to cassette content if marshal-able to string
+	for key := range actual {
+		expectedValue, exists := expected[key]
+		if !exists {
+			// Actual request may contain a field that does not exist in cassette
+			// New fields can appear in requests with new api features
+			// We do not want to generate new cassettes for each new features
+			continue
+		}
+		if !compareJSONFields(expectedValue, actual[key]) {
+			return false
+		}
+	}
+
+	for key := range expected {
+		_, exists := actual[key]
+		if !exists && expected[key] != nil {
+			// Fails match if cassettes contains a field not in actual requests
+			// Fields should not disappear from requests unless a sdk breaking change
+			// We ignore if field is nil in cassette as it could be an old deprecated and unused field
+			return false
+		}
+	}
+
+	return true
+}
+
+// bodyMatcher is a custom matcher that will juste check equivalence of request bodies
+func bodyMatcher(actualRequest *http.Request, cassetteRequest cassette.Request) bool {
+	if actualRequest.Body == nil || actualRequest.ContentLength == 0 {
+		if cassetteRequest.Body == "" {
+			return true // Body match if both are empty
+		} else if _, isFile := actualRequest.Body.(*os.File); isFile {
+			return true // Body match if request is sending a file, maybe do more check here
+		}
+		return false
+	}
+
+	actualBody, err := actualRequest.GetBody()
+	if err != nil {
+		panic(fmt.Errorf("cassette body matcher: failed to copy actualRequest body: %w", err)) // lintignore: R009
+	}
+	actualRawBody, err := io.ReadAll(actualBody)
+	if err != nil {
+		panic(fmt.Errorf("cassette body matcher: failed to read actualRequest body: %w", err)) // lintignore: R009
+	}
+
+	// Try to match raw bodies if they are not JSON (ex: cloud-init config)
+	if string(actualRawBody) == cassetteRequest.Body {
+		return true
+	}
+
+	actualJSON := make(map[string]interface{})
+	cassetteJSON := make(map[string]interface{})
+
+	err = xml.Unmarshal(actualRawBody, new(interface{}))
+	if err == nil {
+		// match if content is xml
+		return true
+	}
+
+	if !json.Valid(actualRawBody) {
+		values, err := url.ParseQuery(string(actualRawBody))
+		if err != nil {
+			panic(fmt.Errorf("cassette body matcher: failed to parse body as url values: %w", err)) // lintignore: R009
+		}
+
+		// Remove keys that should be ignored during compare
+		for _, key := range BodyMatcherIgnore {
+			values.Del(key)
+		}
+
+		// Compare url values
+		return compareFormBodies(values, cassetteRequest.Form)
+	}
+
+	err = json.Unmarshal(actualRawBody, &actualJSON)
+	if err != nil {
+		panic(fmt.Errorf("cassette body matcher: failed to parse json body: %w", err)) // lintignore: R009
+	}
+
+	err = json.Unmarshal([]byte(cassetteRequest.Body), &cassetteJSON)
+	if err != nil {
+		// actualRequest contains JSON but cassette may not contain JSON, this doesn't match in this case
+		return false
+	}
+
+	// Remove keys that should be ignored during compare
+	for _, key := range BodyMatcherIgnore {
+		delete(actualJSON, key)
+		delete(cassetteJSON, key)
+	}
+
+	return compareJSONBodies(cassetteJSON, actualJSON)
+}
+
+// Matcher is a custom matcher that check equivalence of a played request against a recorded one
+// It compares method, path and query but will remove unwanted values from query
+func Matcher(actual *http.Request, expected cassette.Request) bool {
+	expectedURL, _ := url.Parse(expected.URL)
+	actualURL := actual.URL
+	actualURLValues := actualURL.Query()
+	expectedURLValues := expectedURL.Query()
+	for _, query := range QueryMatcherIgnore {
+		actualURLValues.Del(query)
+		expectedURLValues.Del(query)
+	}
+	actualURL.RawQuery = actualURLValues.Encode()
+	expectedURL.RawQuery = expectedURLValues.Encode()
+
+	// Specific handling of s3 URLs
+	// Url format is https://test-acc-scaleway-object-bucket-lifecycle-8445817190507446251.s3.fr-par.scw.cloud/?lifecycle=
+	if strings.HasSuffix(actualURL.Host, "scw.cloud") {
+		if !strings.HasSuffix(expectedURL.Host, "scw.cloud") {
+			return false
+		}
+		actualS3Host := strings.Split(actualURL.Host, ".")
+		expectedS3Host := strings.Split(expectedURL.Host, ".")
+
+		if len(actualS3Host) >= 5 && len(expectedS3Host) >= 5 {
+			// Host is bucket.s3.region.scw.cloud
+			// it could be a host without bucket name (ex: function upload)
+			actualBucket := actualS3Host[0]
+			expectedBucket := expectedS3Host[0]
+
+			// Remove random number at the end of the bucket name
+			if strings.Contains(actualBucket, "-") {
+				actualBucket = actualBucket[:strings.LastIndex(actualBucket, "-")]
+			}
+			if strings.Contains(expectedBucket, "-") {
+				expectedBucket = expectedBucket[:strings.LastIndex(expectedBucket, "-")]
+			}
+
+			if actualBucket != expectedBucket {
+				return false
+			}
+		}
+	}
+
+	return actual.Method == expected.Method &&
+		actual.URL.Path == expectedURL.Path &&
+		actualURL.RawQuery == expectedURL.RawQuery &&
+		bodyMatcher(actual, expected)
+}
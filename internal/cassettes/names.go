@@ -0,0 +1,117 @@
+package cassettes
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/scaleway/scaleway-sdk-go/strcase"
+)
+
+// Extension is the extension of cassette files
+const Extension = ".cassette.yaml"
+
+var specialChars = regexp.MustCompile(`[\\?%*:|"<>. ]`)
+
+// FileName returns the name of the cassette of a test, without directory nor extension. (Take care of non fs friendly char)
+func FileName(testName string) string {
+	// Replace nested tests separators.
+	fileName := strings.ReplaceAll(testName, "/", "-")
+
+	fileName = strcase.ToBashArg(fileName)
+
+	// Replace special characters.
+	fileName = specialChars.ReplaceAllLiteralString(fileName, "")
+
+	// Remove prefix to simplify
+	return strings.TrimPrefix(fileName, "test-acc-scaleway-")
+}
+
+// Tests are the tests of a package that may have recorded cassettes
+type Tests struct {
+	// names are the cassette file names of the tests and of their subtests with a constant name
+	names map[string]bool
+	// prefixes are the cassette file names of the tests running subtests whose names are only known at runtime
+	prefixes []string
+}
+
+// ParseTests lists the tests of the go test files of a directory
+func ParseTests(dir string) (*Tests, error) {
+	files, err := filepath.Glob(filepath.Join(dir, "*_test.go"))
+	if err != nil {
+		return nil, err
+	}
+
+	tests := &Tests{names: map[string]bool{}}
+	fset := token.NewFileSet()
+	for _, file := range files {
+		f, err := parser.ParseFile(fset, file, nil, 0)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, decl := range f.Decls {
+			fn, isFunc := decl.(*ast.FuncDecl)
+			if !isFunc || fn.Recv != nil || !strings.HasPrefix(fn.Name.Name, "Test") || fn.Body == nil {
+				continue
+			}
+			tests.addTest(fn)
+		}
+	}
+
+	return tests, nil
+}
+
+func (t *Tests) addTest(fn *ast.FuncDecl) {
+	testName := fn.Name.Name
+	t.names[FileName(testName)] = true
+
+	ast.Inspect(fn.Body, func(node ast.Node) bool {
+		call, isCall := node.(*ast.CallExpr)
+		if !isCall || len(call.Args) != 2 {
+			return true
+		}
+		selector, isSelector := call.Fun.(*ast.SelectorExpr)
+		if !isSelector || selector.Sel.Name != "Run" {
+			return true
+		}
+
+		if literal, isLiteral := call.Args[0].(*ast.BasicLit); isLiteral && literal.Kind == token.STRING {
+			if subtestName, err := strconv.Unquote(literal.Value); err == nil {
+				// The testing package replaces spaces in the names of subtests
+				t.names[FileName(testName+"/"+strings.ReplaceAll(subtestName, " ", "_"))] = true
+				return true
+			}
+		}
+		t.prefixes = append(t.prefixes, FileName(testName)+"-")
+
+		return true
+	})
+}
+
+// Orphaned returns the cassettes that were not recorded by any of the tests
+func (t *Tests) Orphaned(cassettePaths []string) []string {
+	orphaned := []string(nil)
+	for _, path := range cassettePaths {
+		name := strings.TrimSuffix(filepath.Base(path), Extension)
+		if t.names[name] {
+			continue
+		}
+
+		hasPrefix := false
+		for _, prefix := range t.prefixes {
+			hasPrefix = hasPrefix || strings.HasPrefix(name, prefix)
+		}
+		if !hasPrefix {
+			orphaned = append(orphaned, path)
+		}
+	}
+	sort.Strings(orphaned)
+
+	return orphaned
+}
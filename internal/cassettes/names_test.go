@@ -0,0 +1,45 @@
+package cassettes
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFileName(t *testing.T) {
+	assert.Equal(t, "instance-server-basic", FileName("TestAccScalewayInstanceServer_Basic"))
+	assert.Equal(t, "provider-ssh-keys", FileName("TestAccScalewayProvider_SSHKeys"))
+	assert.Equal(t, "k8s-cluster-auto-upgrade-minor-version", FileName("TestAccScalewayK8SCluster_AutoUpgrade/minor_version"))
+}
+
+func TestOrphaned(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "foo_test.go"), []byte(`package foo
+
+import "testing"
+
+func TestAccScalewayFoo_Basic(t *testing.T) {
+	t.Run("with bar", func(t *testing.T) {})
+}
+
+func TestAccScalewayFoo_Table(t *testing.T) {
+	for _, name := range []string{"a", "b"} {
+		t.Run(name, func(t *testing.T) {})
+	}
+}
+`), 0o600))
+
+	tests, err := ParseTests(dir)
+	require.NoError(t, err)
+
+	orphaned := tests.Orphaned([]string{
+		"testdata/foo-basic.cassette.yaml",
+		"testdata/foo-basic-with-bar.cassette.yaml",
+		"testdata/foo-table-a.cassette.yaml",
+		"testdata/foo-removed.cassette.yaml",
+	})
+	assert.Equal(t, []string{"testdata/foo-removed.cassette.yaml"}, orphaned)
+}
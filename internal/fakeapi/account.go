@@ -0,0 +1,112 @@
+package fakeapi
+
+import (
+	"net/http"
+
+	accountV3 "github.com/scaleway/scaleway-sdk-go/api/account/v3"
+)
+
+const accountPrefix = "/account/v3"
+
+type accountStore struct {
+	projects *collection[accountV3.Project]
+}
+
+func (s *Server) registerAccount() {
+	s.account = &accountStore{
+		projects: newCollection[accountV3.Project](),
+	}
+
+	// The default project always exists, like on a real organization
+	s.account.projects.add(DefaultProjectID, &accountV3.Project{
+		ID:             DefaultProjectID,
+		Name:           "default",
+		OrganizationID: DefaultOrganizationID,
+		CreatedAt:      s.timePtr(),
+		UpdatedAt:      s.timePtr(),
+	})
+
+	s.handle("GET "+accountPrefix+"/projects", s.accountListProjects)
+	s.handle("POST "+accountPrefix+"/projects", s.accountCreateProject)
+	s.handle("GET "+accountPrefix+"/projects/{project_id}", s.accountGetProject)
+	s.handle("PATCH "+accountPrefix+"/projects/{project_id}", s.accountUpdateProject)
+	s.handle("DELETE "+accountPrefix+"/projects/{project_id}", s.accountDeleteProject)
+}
+
+func (s *Server) accountProject(r *http.Request) (*accountV3.Project, error) {
+	id := r.PathValue("project_id")
+	project, exists := s.account.projects.get(id)
+	if !exists {
+		return nil, notFoundError("project", id)
+	}
+
+	return project, nil
+}
+
+func (s *Server) accountListProjects(r *http.Request) (interface{}, error) {
+	f := newFilter(r)
+	projectIDs := f.query["project_ids"]
+	projects := s.account.projects.list(func(project *accountV3.Project) bool {
+		return f.matchName(project.Name) &&
+			f.matchString(project.OrganizationID, "organization_id") &&
+			(len(projectIDs) == 0 || containsString(projectIDs, project.ID))
+	})
+
+	return paginate(r, "projects", projects), nil
+}
+
+func (s *Server) accountCreateProject(r *http.Request) (interface{}, error) {
+	req := &accountV3.ProjectAPICreateProjectRequest{}
+	if err := decode(r, req); err != nil {
+		return nil, err
+	}
+
+	project := &accountV3.Project{
+		ID:             newID(),
+		Name:           req.Name,
+		OrganizationID: organizationOrDefault(req.OrganizationID),
+		CreatedAt:      s.timePtr(),
+		UpdatedAt:      s.timePtr(),
+		Description:    req.Description,
+	}
+	s.account.projects.add(project.ID, project)
+
+	return project, nil
+}
+
+func (s *Server) accountGetProject(r *http.Request) (interface{}, error) {
+	return s.accountProject(r)
+}
+
+func (s *Server) accountUpdateProject(r *http.Request) (interface{}, error) {
+	project, err := s.accountProject(r)
+	if err != nil {
+		return nil, err
+	}
+
+	req := &accountV3.ProjectAPIUpdateProjectRequest{}
+	if err := decode(r, req); err != nil {
+		return nil, err
+	}
+
+	if req.Name != nil {
+		project.Name = *req.Name
+	}
+	if req.Description != nil {
+		project.Description = *req.Description
+	}
+	project.UpdatedAt = s.timePtr()
+
+	return project, nil
+}
+
+func (s *Server) accountDeleteProject(r *http.Request) (interface{}, error) {
+	project, err := s.accountProject(r)
+	if err != nil {
+		return nil, err
+	}
+
+	s.account.projects.delete(project.ID)
+
+	return nil, nil
+}
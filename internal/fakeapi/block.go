@@ -0,0 +1,169 @@
+package fakeapi
+
+import (
+	"net/http"
+
+	block "github.com/scaleway/scaleway-sdk-go/api/block/v1alpha1"
+	instance "github.com/scaleway/scaleway-sdk-go/api/instance/v1"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+)
+
+const blockPrefix = "/block/v1alpha1/zones/{zone}"
+
+// blockStore only holds the volumes migrated from the instance API, volumes cannot be created directly
+type blockStore struct {
+	volumes *collection[block.Volume]
+}
+
+func (s *Server) registerBlock() {
+	s.block = &blockStore{
+		volumes: newCollection[block.Volume](),
+	}
+
+	s.handle("GET "+blockPrefix+"/volumes", s.blockListVolumes)
+	s.handle("GET "+blockPrefix+"/volumes/{volume_id}", s.blockGetVolume)
+	s.handle("PATCH "+blockPrefix+"/volumes/{volume_id}", s.blockUpdateVolume)
+	s.handle("DELETE "+blockPrefix+"/volumes/{volume_id}", s.blockDeleteVolume)
+}
+
+func (s *Server) blockVolume(r *http.Request) (*block.Volume, error) {
+	id := r.PathValue("volume_id")
+	volume, exists := s.block.volumes.get(id)
+	if !exists || volume.Zone != zone(r) {
+		return nil, notFoundError("volume", id)
+	}
+
+	return volume, nil
+}
+
+// blockVolumeStatus returns the status of an idle volume, depending on whether it is attached
+func blockVolumeStatus(volume *block.Volume) block.VolumeStatus {
+	if len(volume.References) > 0 {
+		return block.VolumeStatusInUse
+	}
+
+	return block.VolumeStatusAvailable
+}
+
+func (s *Server) blockListVolumes(r *http.Request) (interface{}, error) {
+	f := newFilter(r)
+	volumes := s.block.volumes.list(func(volume *block.Volume) bool {
+		return volume.Zone == zone(r) &&
+			f.matchName(volume.Name) &&
+			f.matchString(volume.ProjectID, "project_id") &&
+			f.matchTags(volume.Tags)
+	})
+	for _, volume := range volumes {
+		s.settle(volume.ID)
+	}
+
+	return paginate(r, "volumes", volumes), nil
+}
+
+func (s *Server) blockGetVolume(r *http.Request) (interface{}, error) {
+	volume, err := s.blockVolume(r)
+	if err != nil {
+		return nil, err
+	}
+	s.settle(volume.ID)
+
+	return volume, nil
+}
+
+func (s *Server) blockUpdateVolume(r *http.Request) (interface{}, error) {
+	volume, err := s.blockVolume(r)
+	if err != nil {
+		return nil, err
+	}
+	if s.isTransient(volume.ID) {
+		return nil, transientStateError("volume", volume.ID, string(volume.Status))
+	}
+
+	req := &block.UpdateVolumeRequest{}
+	if err := decode(r, req); err != nil {
+		return nil, err
+	}
+
+	if req.Name != nil {
+		volume.Name = *req.Name
+	}
+	if req.Tags != nil {
+		volume.Tags = *req.Tags
+	}
+	if req.Size != nil && *req.Size != volume.Size {
+		if *req.Size < volume.Size {
+			return nil, invalidArgumentError("size", "constraint", "volumes cannot be shrunk")
+		}
+		volume.Size = *req.Size
+		volume.Status = block.VolumeStatusResizing
+		s.schedule(volume.ID, func() {
+			volume.Status = blockVolumeStatus(volume)
+		})
+		s.instanceSyncServerVolumeSize(volume.ID, volume.Size)
+	}
+	volume.UpdatedAt = s.timePtr()
+
+	return volume, nil
+}
+
+func (s *Server) blockDeleteVolume(r *http.Request) (interface{}, error) {
+	volume, err := s.blockVolume(r)
+	if err != nil {
+		return nil, err
+	}
+	if len(volume.References) > 0 {
+		return nil, preconditionFailedError("volume_not_attached", "volume is attached to a resource")
+	}
+	if s.isTransient(volume.ID) {
+		return nil, transientStateError("volume", volume.ID, string(volume.Status))
+	}
+
+	s.block.volumes.delete(volume.ID)
+
+	return nil, nil
+}
+
+// blockNewVolumeFromInstance creates the block volume an instance volume is migrated to, it keeps the same ID
+func (s *Server) blockNewVolumeFromInstance(volume *instance.Volume) *block.Volume {
+	blockVolume := &block.Volume{
+		ID:        volume.ID,
+		Name:      volume.Name,
+		Type:      "sbs_5k",
+		Size:      volume.Size,
+		ProjectID: volume.Project,
+		CreatedAt: volume.CreationDate,
+		UpdatedAt: s.timePtr(),
+		Tags:      volume.Tags,
+		Zone:      volume.Zone,
+		Specs: &block.VolumeSpecifications{
+			PerfIops: scw.Uint32Ptr(5000),
+			Class:    block.StorageClassSbs,
+		},
+		References: []*block.Reference{},
+	}
+	blockVolume.Status = blockVolumeStatus(blockVolume)
+	s.block.volumes.add(blockVolume.ID, blockVolume)
+	if volume.Server != nil {
+		s.blockAttachVolume(blockVolume.ID, volume.Server.ID)
+	}
+
+	return blockVolume
+}
+
+// blockAttachVolume references a volume as attached to an instance server
+func (s *Server) blockAttachVolume(volumeID string, serverID string) {
+	volume, exists := s.block.volumes.get(volumeID)
+	if !exists {
+		return
+	}
+
+	volume.References = []*block.Reference{{
+		ID:                  newID(),
+		ProductResourceType: "instance_server",
+		ProductResourceID:   serverID,
+		CreatedAt:           s.timePtr(),
+		Type:                block.ReferenceTypeExclusive,
+		Status:              block.ReferenceStatusAttached,
+	}}
+	volume.Status = blockVolumeStatus(volume)
+}
@@ -0,0 +1,265 @@
+package fakeapi
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	domain "github.com/scaleway/scaleway-sdk-go/api/domain/v2beta1"
+)
+
+const domainPrefix = "/domain/v2beta1"
+
+// domainNameservers are the nameservers of every DNS zone
+var domainNameservers = []string{"ns0.dom.scw.cloud", "ns1.dom.scw.cloud"}
+
+type domainStore struct {
+	// zones are keyed by their full name, the subdomain followed by the domain
+	zones   *collection[domain.DNSZone]
+	records map[string][]*domain.Record
+}
+
+func (s *Server) registerDomain() {
+	s.domain = &domainStore{
+		zones:   newCollection[domain.DNSZone](),
+		records: map[string][]*domain.Record{},
+	}
+
+	s.handle("GET "+domainPrefix+"/dns-zones", s.domainListDNSZones)
+	s.handle("POST "+domainPrefix+"/dns-zones", s.domainCreateDNSZone)
+	s.handle("PATCH "+domainPrefix+"/dns-zones/{dns_zone}", s.domainUpdateDNSZone)
+	s.handle("DELETE "+domainPrefix+"/dns-zones/{dns_zone}", s.domainDeleteDNSZone)
+	s.handle("GET "+domainPrefix+"/dns-zones/{dns_zone}/records", s.domainListDNSZoneRecords)
+	s.handle("PATCH "+domainPrefix+"/dns-zones/{dns_zone}/records", s.domainUpdateDNSZoneRecords)
+}
+
+func domainZoneName(zone *domain.DNSZone) string {
+	if zone.Subdomain == "" {
+		return zone.Domain
+	}
+
+	return zone.Subdomain + "." + zone.Domain
+}
+
+func (s *Server) domainNewDNSZone(domainName, subdomain, projectID string) *domain.DNSZone {
+	zone := &domain.DNSZone{
+		Domain:         domainName,
+		Subdomain:      subdomain,
+		Ns:             domainNameservers,
+		NsDefault:      domainNameservers,
+		NsMaster:       []string{},
+		Status:         domain.DNSZoneStatusPending,
+		UpdatedAt:      s.timePtr(),
+		ProjectID:      projectID,
+		LinkedProducts: []domain.LinkedProduct{},
+	}
+	name := domainZoneName(zone)
+	s.domain.zones.add(name, zone)
+	s.domain.records[name] = []*domain.Record{}
+	s.domainSetPending(zone)
+
+	return zone
+}
+
+// domainSetPending sets a DNS zone as pending until it is read, like the real API does when it is changed
+func (s *Server) domainSetPending(zone *domain.DNSZone) {
+	zone.Status = domain.DNSZoneStatusPending
+	zone.UpdatedAt = s.timePtr()
+	s.schedule(domainZoneName(zone), func() {
+		zone.Status = domain.DNSZoneStatusActive
+	})
+}
+
+func (s *Server) domainDNSZone(r *http.Request) (*domain.DNSZone, error) {
+	name := r.PathValue("dns_zone")
+	zone, exists := s.domain.zones.get(name)
+	if !exists {
+		return nil, notFoundError("dns_zone", name)
+	}
+
+	return zone, nil
+}
+
+func (s *Server) domainListDNSZones(r *http.Request) (interface{}, error) {
+	f := newFilter(r)
+	zones := s.domain.zones.list(func(zone *domain.DNSZone) bool {
+		return f.matchString(zone.ProjectID, "project_id") &&
+			f.matchString(zone.Domain, "domain") &&
+			f.matchString(domainZoneName(zone), "dns_zone")
+	})
+	for _, zone := range zones {
+		s.settle(domainZoneName(zone))
+	}
+
+	return paginate(r, "dns_zones", zones), nil
+}
+
+func (s *Server) domainCreateDNSZone(r *http.Request) (interface{}, error) {
+	req := &domain.CreateDNSZoneRequest{}
+	if err := decode(r, req); err != nil {
+		return nil, err
+	}
+
+	if req.Domain == "" {
+		return nil, invalidArgumentError("domain", "required", "domain is required")
+	}
+	name := req.Domain
+	if req.Subdomain != "" {
+		name = req.Subdomain + "." + req.Domain
+	}
+	if _, exists := s.domain.zones.get(name); exists {
+		return nil, &Error{StatusCode: http.StatusConflict, Type: "conflict", Message: fmt.Sprintf("dns zone %s already exists", name)}
+	}
+
+	return s.domainNewDNSZone(req.Domain, req.Subdomain, projectOrDefault(&req.ProjectID)), nil
+}
+
+func (s *Server) domainUpdateDNSZone(r *http.Request) (interface{}, error) {
+	zone, err := s.domainDNSZone(r)
+	if err != nil {
+		return nil, err
+	}
+
+	req := &domain.UpdateDNSZoneRequest{}
+	if err := decode(r, req); err != nil {
+		return nil, err
+	}
+
+	if req.NewDNSZone != nil {
+		oldName := domainZoneName(zone)
+		newName := *req.NewDNSZone
+		if !strings.HasSuffix(newName, "."+zone.Domain) {
+			return nil, invalidArgumentError("new_dns_zone", "constraint", "new dns zone must be a subdomain of "+zone.Domain)
+		}
+		zone.Subdomain = strings.TrimSuffix(newName, "."+zone.Domain)
+		s.domain.zones.delete(oldName)
+		s.domain.zones.add(newName, zone)
+		s.domain.records[newName] = s.domain.records[oldName]
+		delete(s.domain.records, oldName)
+		delete(s.transitions, oldName)
+	}
+	if req.ProjectID != "" {
+		zone.ProjectID = req.ProjectID
+	}
+	s.domainSetPending(zone)
+
+	return zone, nil
+}
+
+func (s *Server) domainDeleteDNSZone(r *http.Request) (interface{}, error) {
+	zone, err := s.domainDNSZone(r)
+	if err != nil {
+		return nil, err
+	}
+
+	name := domainZoneName(zone)
+	s.domain.zones.delete(name)
+	delete(s.domain.records, name)
+	delete(s.transitions, name)
+
+	return &domain.DeleteDNSZoneResponse{}, nil
+}
+
+func (s *Server) domainListDNSZoneRecords(r *http.Request) (interface{}, error) {
+	zone, err := s.domainDNSZone(r)
+	if err != nil {
+		return nil, err
+	}
+
+	f := newFilter(r)
+	records := []*domain.Record{}
+	for _, record := range s.domain.records[domainZoneName(zone)] {
+		if f.matchString(record.Name, "name") && f.matchString(string(record.Type), "type") && f.matchString(record.ID, "id") {
+			records = append(records, record)
+		}
+	}
+
+	return paginate(r, "records", records), nil
+}
+
+func (s *Server) domainUpdateDNSZoneRecords(r *http.Request) (interface{}, error) {
+	req := &domain.UpdateDNSZoneRecordsRequest{}
+	if err := decode(r, req); err != nil {
+		return nil, err
+	}
+
+	zone, err := s.domainDNSZone(r)
+	if err != nil {
+		// Changing the records of a missing subdomain zone creates it, unless disallowed
+		name := r.PathValue("dns_zone")
+		parent := s.domainParentZone(name)
+		if req.DisallowNewZoneCreation || parent == nil {
+			return nil, err
+		}
+		zone = s.domainNewDNSZone(parent.Domain, strings.TrimSuffix(name, "."+parent.Domain), parent.ProjectID)
+	}
+
+	name := domainZoneName(zone)
+	records := s.domain.records[name]
+	changed := []*domain.Record{}
+	for _, change := range req.Changes {
+		switch {
+		case change.Add != nil:
+			for _, record := range change.Add.Records {
+				record.ID = newID()
+				records = append(records, record)
+				changed = append(changed, record)
+			}
+		case change.Set != nil:
+			records = domainRemoveRecords(records, change.Set.ID, change.Set.IDFields)
+			for _, record := range change.Set.Records {
+				if change.Set.ID != nil {
+					record.ID = *change.Set.ID
+				} else {
+					record.ID = newID()
+				}
+				records = append(records, record)
+				changed = append(changed, record)
+			}
+		case change.Delete != nil:
+			records = domainRemoveRecords(records, change.Delete.ID, change.Delete.IDFields)
+		case change.Clear != nil:
+			records = []*domain.Record{}
+		}
+	}
+	s.domain.records[name] = records
+	s.domainSetPending(zone)
+
+	if req.ReturnAllRecords == nil || *req.ReturnAllRecords {
+		changed = records
+	}
+
+	return &domain.UpdateDNSZoneRecordsResponse{Records: changed}, nil
+}
+
+// domainParentZone returns the existing zone of the root domain of name, if any
+func (s *Server) domainParentZone(name string) *domain.DNSZone {
+	for _, zone := range s.domain.zones.list(nil) {
+		if zone.Subdomain == "" && strings.HasSuffix(name, "."+zone.Domain) {
+			return zone
+		}
+	}
+
+	return nil
+}
+
+// domainRemoveRecords returns the records not matching the ID nor the identifier
+func domainRemoveRecords(records []*domain.Record, id *string, identifier *domain.RecordIdentifier) []*domain.Record {
+	kept := []*domain.Record{}
+	for _, record := range records {
+		matches := false
+		switch {
+		case id != nil:
+			matches = record.ID == *id
+		case identifier != nil:
+			matches = record.Name == identifier.Name && record.Type == identifier.Type &&
+				(identifier.Data == nil || record.Data == *identifier.Data) &&
+				(identifier.TTL == nil || record.TTL == *identifier.TTL)
+		}
+		if !matches {
+			kept = append(kept, record)
+		}
+	}
+
+	return kept
+}
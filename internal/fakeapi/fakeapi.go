@@ -0,0 +1,519 @@
+// Package fakeapi is an in-process fake of the Scaleway API.
+//
+// It keeps the state of the resources in memory and mimics the asynchronous state transitions of the real API,
+// so acceptance tests can be written and run without a Scaleway account nor recorded cassettes.
+// Resources are represented with the types of the Scaleway SDK, which guarantees the SDK can decode the responses.
+package fakeapi
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/scaleway/scaleway-sdk-go/scw"
+)
+
+const (
+	// DefaultOrganizationID is the organization owning the resources created without an explicit project
+	DefaultOrganizationID = "11111111-1111-1111-1111-111111111111"
+	// DefaultProjectID is the project of the resources created without an explicit project
+	DefaultProjectID = "11111111-1111-1111-1111-111111111111"
+
+	defaultPageSize = 50
+
+	// transientReads is the number of reads during which a resource stays in a transient state
+	transientReads = 1
+)
+
+// Server is an in-process fake of the Scaleway API. It implements http.Handler.
+type Server struct {
+	mu  sync.Mutex
+	mux *http.ServeMux
+
+	// now returns the current time, it is used for the creation and update dates of resources
+	now func() time.Time
+	// transitions are the pending changes of state of resources, by resource ID
+	transitions map[string]*transition
+
+	account     *accountStore
+	block       *blockStore
+	domain      *domainStore
+	iam         *iamStore
+	instance    *instanceStore
+	ipam        *ipamStore
+	k8s         *k8sStore
+	lb          *lbStore
+	marketplace *marketplaceStore
+	rdb         *rdbStore
+	secret      *secretStore
+	vpc         *vpcStore
+}
+
+// New returns a fake API without any resource but the public ones, like marketplace images.
+func New() *Server {
+	s := &Server{
+		mux:         http.NewServeMux(),
+		now:         func() time.Time { return time.Now().UTC().Truncate(time.Second) },
+		transitions: map[string]*transition{},
+	}
+
+	s.registerAccount()
+	s.registerBlock()
+	s.registerDomain()
+	s.registerIam()
+	s.registerInstance()
+	s.registerIpam()
+	s.registerK8S()
+	s.registerLB()
+	s.registerMarketplace()
+	s.registerRdb()
+	s.registerSecret()
+	s.registerVPC()
+
+	s.mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		writeError(w, &Error{
+			StatusCode: http.StatusNotImplemented,
+			Type:       "not_implemented",
+			Message:    fmt.Sprintf("%s %s is not implemented by the fake API", r.Method, r.URL.Path),
+		})
+	})
+
+	return s
+}
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mux.ServeHTTP(w, r)
+}
+
+// Client returns an HTTP client sending every request to the fake API, without any network access.
+func (s *Server) Client() *http.Client {
+	return &http.Client{Transport: s}
+}
+
+// RoundTrip serves the request in process, whatever its host.
+func (s *Server) RoundTrip(r *http.Request) (*http.Response, error) {
+	recorder := httptest.NewRecorder()
+	s.ServeHTTP(recorder, r)
+	response := recorder.Result()
+	response.Request = r
+
+	return response, nil
+}
+
+// Error is an error of the Scaleway API, encoded like the errors of the real API so the SDK can parse it.
+type Error struct {
+	StatusCode   int                      `json:"-"`
+	Type         string                   `json:"type"`
+	Message      string                   `json:"message"`
+	Resource     string                   `json:"resource,omitempty"`
+	ResourceID   string                   `json:"resource_id,omitempty"`
+	CurrentState string                   `json:"current_state,omitempty"`
+	Precondition string                   `json:"precondition,omitempty"`
+	HelpMessage  string                   `json:"help_message,omitempty"`
+	Details      []map[string]interface{} `json:"details,omitempty"`
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("%d %s: %s", e.StatusCode, e.Type, e.Message)
+}
+
+func notFoundError(resource, id string) error {
+	return &Error{
+		StatusCode: http.StatusNotFound,
+		Type:       "not_found",
+		Message:    "resource is not found",
+		Resource:   resource,
+		ResourceID: id,
+	}
+}
+
+func transientStateError(resource, id, state string) error {
+	return &Error{
+		StatusCode:   http.StatusConflict,
+		Type:         "transient_state",
+		Message:      fmt.Sprintf("resource %s with ID %s is in a transient state: %s", resource, id, state),
+		Resource:     resource,
+		ResourceID:   id,
+		CurrentState: state,
+	}
+}
+
+func preconditionFailedError(precondition, helpMessage string) error {
+	return &Error{
+		StatusCode:   http.StatusPreconditionFailed,
+		Type:         "precondition_failed",
+		Message:      "precondition is not respected",
+		Precondition: precondition,
+		HelpMessage:  helpMessage,
+	}
+}
+
+func invalidArgumentError(argumentName, reason, helpMessage string) error {
+	return &Error{
+		StatusCode: http.StatusBadRequest,
+		Type:       "invalid_arguments",
+		Message:    "invalid argument(s)",
+		Details: []map[string]interface{}{{
+			"argument_name": argumentName,
+			"reason":        reason,
+			"help_message":  helpMessage,
+		}},
+	}
+}
+
+func writeError(w http.ResponseWriter, err error) {
+	apiErr, ok := err.(*Error)
+	if !ok {
+		apiErr = &Error{
+			StatusCode: http.StatusInternalServerError,
+			Type:       "internal_error",
+			Message:    err.Error(),
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(apiErr.StatusCode)
+	_ = json.NewEncoder(w).Encode(apiErr)
+}
+
+// handlerFunc handles a request of the fake API. It returns the response to encode in JSON,
+// or nil for an empty response, and an error that is encoded like the errors of the real API.
+type handlerFunc func(r *http.Request) (interface{}, error)
+
+// handle registers the handler of a pattern, handlers are run one at a time on the state of the server.
+func (s *Server) handle(pattern string, handler handlerFunc) {
+	s.mux.HandleFunc(pattern, func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		res, err := handler(r)
+		s.mu.Unlock()
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+
+		if res == nil {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+
+		if page, isPage := res.(listPage); isPage {
+			w.Header().Set("X-Total-Count", strconv.Itoa(page.totalCount()))
+		}
+
+		body, err := json.Marshal(res)
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write(body)
+	})
+}
+
+// decode decodes the JSON body of a request in req, a request type of the SDK
+func decode(r *http.Request, req interface{}) error {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		return err
+	}
+	if len(bytes.TrimSpace(body)) == 0 {
+		return nil
+	}
+
+	if err := json.Unmarshal(body, req); err != nil {
+		return &Error{
+			StatusCode: http.StatusBadRequest,
+			Type:       "invalid_request_error",
+			Message:    fmt.Sprintf("cannot decode request body: %s", err),
+		}
+	}
+
+	return nil
+}
+
+// newID returns a random UUID v4, the format of the IDs of the Scaleway API
+func newID() string {
+	b := make([]byte, 16)
+	_, _ = rand.Read(b)
+	b[6] = (b[6] & 0x0f) | 0x40
+	b[8] = (b[8] & 0x3f) | 0x80
+
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:])
+}
+
+// transition is a pending change of state of a resource, applied once the resource has been read enough times
+type transition struct {
+	reads int
+	apply func()
+}
+
+// schedule sets the change of state applied after the resource is read, replacing the pending one if any.
+// The resource stays in its transient state until then, like resources of the real API being provisioned.
+func (s *Server) schedule(id string, apply func()) {
+	s.transitions[id] = &transition{
+		reads: transientReads,
+		apply: apply,
+	}
+}
+
+// settle is called when a resource is read, it applies its pending change of state once it has been read enough times
+func (s *Server) settle(id string) {
+	t, exists := s.transitions[id]
+	if !exists {
+		return
+	}
+
+	if t.reads > 0 {
+		t.reads--
+		return
+	}
+
+	delete(s.transitions, id)
+	t.apply()
+}
+
+// isTransient returns true if the resource has a pending change of state
+func (s *Server) isTransient(id string) bool {
+	_, exists := s.transitions[id]
+	return exists
+}
+
+// timePtr returns the current time of the server
+func (s *Server) timePtr() *time.Time {
+	now := s.now()
+	return &now
+}
+
+// projectOrDefault returns the project ID of a request, the default project if not set
+func projectOrDefault(projectID ...*string) string {
+	for _, id := range projectID {
+		if id != nil && *id != "" {
+			return *id
+		}
+	}
+
+	return DefaultProjectID
+}
+
+func stringOrDefault(value *string, defaultValue string) string {
+	if value == nil || *value == "" {
+		return defaultValue
+	}
+
+	return *value
+}
+
+func stringsOrEmpty(value *[]string) []string {
+	if value == nil || *value == nil {
+		return []string{}
+	}
+
+	return *value
+}
+
+// collection is a set of resources of the fake API, listed in the order they were created
+type collection[T any] struct {
+	ids   []string
+	items map[string]*T
+}
+
+func newCollection[T any]() *collection[T] {
+	return &collection[T]{
+		items: map[string]*T{},
+	}
+}
+
+func (c *collection[T]) add(id string, item *T) {
+	if _, exists := c.items[id]; !exists {
+		c.ids = append(c.ids, id)
+	}
+	c.items[id] = item
+}
+
+func (c *collection[T]) get(id string) (*T, bool) {
+	item, ok := c.items[id]
+	return item, ok
+}
+
+func (c *collection[T]) delete(id string) {
+	if _, exists := c.items[id]; !exists {
+		return
+	}
+
+	delete(c.items, id)
+	for i, existingID := range c.ids {
+		if existingID == id {
+			c.ids = append(c.ids[:i], c.ids[i+1:]...)
+			break
+		}
+	}
+}
+
+// list returns the resources matching the filter, every resource if the filter is nil
+func (c *collection[T]) list(filter func(*T) bool) []*T {
+	items := []*T{}
+	for _, id := range c.ids {
+		item := c.items[id]
+		if filter == nil || filter(item) {
+			items = append(items, item)
+		}
+	}
+
+	return items
+}
+
+// listPage is a response of a list endpoint, its total count is also set in the X-Total-Count header like the instance API does
+type listPage interface {
+	totalCount() int
+}
+
+// page is a page of a list response, encoded as {"<key>": [...], "total_count": n}
+type page[T any] struct {
+	key   string
+	items []*T
+	total int
+}
+
+func (p page[T]) totalCount() int {
+	return p.total
+}
+
+func (p page[T]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(map[string]interface{}{
+		p.key:         p.items,
+		"total_count": p.total,
+	})
+}
+
+// paginate returns the page of the items requested with the page and per_page query parameters
+func paginate[T any](r *http.Request, key string, items []*T) page[T] {
+	pageNumber := queryInt(r, "page", 1)
+	pageSize := queryInt(r, "per_page", defaultPageSize)
+	if pageNumber < 1 {
+		pageNumber = 1
+	}
+	if pageSize < 1 {
+		pageSize = defaultPageSize
+	}
+
+	start := (pageNumber - 1) * pageSize
+	end := start + pageSize
+	if start > len(items) {
+		start = len(items)
+	}
+	if end > len(items) {
+		end = len(items)
+	}
+
+	return page[T]{
+		key:   key,
+		items: items[start:end],
+		total: len(items),
+	}
+}
+
+func queryInt(r *http.Request, key string, defaultValue int) int {
+	value, err := strconv.Atoi(r.URL.Query().Get(key))
+	if err != nil {
+		return defaultValue
+	}
+
+	return value
+}
+
+// filter is the set of common filters of list endpoints
+type filter struct {
+	query map[string][]string
+}
+
+func newFilter(r *http.Request) filter {
+	return filter{query: r.URL.Query()}
+}
+
+func (f filter) get(key string) string {
+	values := f.query[key]
+	if len(values) == 0 {
+		return ""
+	}
+
+	return values[0]
+}
+
+// matchString returns true if the value equals the one of the first set query parameter of keys
+func (f filter) matchString(value string, keys ...string) bool {
+	for _, key := range keys {
+		if expected := f.get(key); expected != "" {
+			return expected == value
+		}
+	}
+
+	return true
+}
+
+// matchName returns true if the name contains the name query parameter, like the API does
+func (f filter) matchName(name string) bool {
+	return strings.Contains(name, f.get("name"))
+}
+
+// matchProject returns true if the resource belongs to the project and organization query parameters
+func (f filter) matchProject(projectID, organizationID string) bool {
+	return f.matchString(projectID, "project_id", "project") &&
+		f.matchString(organizationID, "organization_id", "organization")
+}
+
+// matchTags returns true if the resource has every tag of the tags query parameter
+func (f filter) matchTags(tags []string) bool {
+	expectedTags := f.query["tags"]
+	if len(expectedTags) == 1 {
+		expectedTags = strings.Split(expectedTags[0], ",")
+	}
+
+	for _, expected := range expectedTags {
+		if expected == "" {
+			continue
+		}
+		found := false
+		for _, tag := range tags {
+			if tag == expected {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+
+	return true
+}
+
+// zone returns the zone of a request, from its zone path parameter
+func zone(r *http.Request) scw.Zone {
+	return scw.Zone(r.PathValue("zone"))
+}
+
+// region returns the region of a request, from its region path parameter
+func region(r *http.Request) scw.Region {
+	return scw.Region(r.PathValue("region"))
+}
+
+// sortedKeys returns the keys of a map in a stable order
+func sortedKeys[T any](m map[string]T) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
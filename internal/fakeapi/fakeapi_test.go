@@ -0,0 +1,209 @@
+package fakeapi
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	instance "github.com/scaleway/scaleway-sdk-go/api/instance/v1"
+	"github.com/scaleway/scaleway-sdk-go/api/marketplace/v2"
+	rdb "github.com/scaleway/scaleway-sdk-go/api/rdb/v1"
+	secret "github.com/scaleway/scaleway-sdk-go/api/secret/v1alpha1"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestClient(t *testing.T) *scw.Client {
+	t.Helper()
+	client, err := scw.NewClient(
+		scw.WithHTTPClient(New().Client()),
+		scw.WithAuth("SCWXXXXXXXXXXXXXXXXX", "11111111-1111-1111-1111-111111111111"),
+		scw.WithDefaultProjectID(DefaultProjectID),
+		scw.WithDefaultOrganizationID(DefaultOrganizationID),
+		scw.WithDefaultZone(scw.ZoneFrPar1),
+		scw.WithDefaultRegion(scw.RegionFrPar),
+	)
+	require.NoError(t, err)
+
+	return client
+}
+
+func TestInstanceServerLifecycle(t *testing.T) {
+	client := newTestClient(t)
+	instanceAPI := instance.NewAPI(client)
+	marketplaceAPI := marketplace.NewAPI(client)
+	retryInterval := time.Duration(0)
+
+	image, err := marketplaceAPI.GetLocalImageByLabel(&marketplace.GetLocalImageByLabelRequest{
+		ImageLabel:     "ubuntu_jammy",
+		Zone:           scw.ZoneFrPar1,
+		CommercialType: "DEV1-S",
+	})
+	require.NoError(t, err)
+
+	created, err := instanceAPI.CreateServer(&instance.CreateServerRequest{
+		Name:           "test-server",
+		CommercialType: "DEV1-S",
+		Image:          image.ID,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, instance.ServerStateStopped, created.Server.State)
+	assert.Len(t, created.Server.Volumes, 1)
+
+	_, err = instanceAPI.ServerAction(&instance.ServerActionRequest{
+		ServerID: created.Server.ID,
+		Action:   instance.ServerActionPoweron,
+	})
+	require.NoError(t, err)
+
+	server, err := instanceAPI.GetServer(&instance.GetServerRequest{ServerID: created.Server.ID})
+	require.NoError(t, err)
+	assert.Equal(t, instance.ServerStateStarting, server.Server.State)
+
+	// Actions are refused while the server is in a transient state
+	_, err = instanceAPI.ServerAction(&instance.ServerActionRequest{
+		ServerID: created.Server.ID,
+		Action:   instance.ServerActionPoweroff,
+	})
+	transientErr := &scw.TransientStateError{}
+	require.ErrorAs(t, err, &transientErr)
+
+	running, err := instanceAPI.WaitForServer(&instance.WaitForServerRequest{
+		ServerID:      created.Server.ID,
+		RetryInterval: &retryInterval,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, instance.ServerStateRunning, running.State)
+
+	_, err = instanceAPI.ServerAction(&instance.ServerActionRequest{
+		ServerID: created.Server.ID,
+		Action:   instance.ServerActionTerminate,
+	})
+	require.NoError(t, err)
+
+	_, err = instanceAPI.WaitForServer(&instance.WaitForServerRequest{
+		ServerID:      created.Server.ID,
+		RetryInterval: &retryInterval,
+	})
+	notFoundErr := &scw.ResourceNotFoundError{}
+	require.ErrorAs(t, err, &notFoundErr)
+
+	volumes, err := instanceAPI.ListVolumes(&instance.ListVolumesRequest{})
+	require.NoError(t, err)
+	assert.Empty(t, volumes.Volumes)
+}
+
+func TestNotFound(t *testing.T) {
+	instanceAPI := instance.NewAPI(newTestClient(t))
+
+	_, err := instanceAPI.GetIP(&instance.GetIPRequest{IP: "11111111-2222-3333-4444-555555555555"})
+	notFoundErr := &scw.ResourceNotFoundError{}
+	require.True(t, errors.As(err, &notFoundErr))
+	assert.Equal(t, "11111111-2222-3333-4444-555555555555", notFoundErr.ResourceID)
+}
+
+func TestPagination(t *testing.T) {
+	instanceAPI := instance.NewAPI(newTestClient(t))
+
+	for i := 0; i < 60; i++ {
+		_, err := instanceAPI.CreateIP(&instance.CreateIPRequest{})
+		require.NoError(t, err)
+	}
+
+	page, err := instanceAPI.ListIPs(&instance.ListIPsRequest{PerPage: scw.Uint32Ptr(25)})
+	require.NoError(t, err)
+	assert.Len(t, page.IPs, 25)
+	assert.Equal(t, uint32(60), page.TotalCount)
+
+	all, err := instanceAPI.ListIPs(&instance.ListIPsRequest{}, scw.WithAllPages())
+	require.NoError(t, err)
+	assert.Len(t, all.IPs, 60)
+}
+
+func TestRdbInstanceUsers(t *testing.T) {
+	fake := New()
+	client, err := scw.NewClient(
+		scw.WithHTTPClient(fake.Client()),
+		scw.WithAuth("SCWXXXXXXXXXXXXXXXXX", "11111111-1111-1111-1111-111111111111"),
+		scw.WithDefaultProjectID(DefaultProjectID),
+		scw.WithDefaultRegion(scw.RegionFrPar),
+	)
+	require.NoError(t, err)
+	rdbAPI := rdb.NewAPI(client)
+	retryInterval := time.Duration(0)
+
+	created, err := rdbAPI.CreateInstance(&rdb.CreateInstanceRequest{
+		Name:     "test-rdb",
+		Engine:   "PostgreSQL-15",
+		NodeType: "DB-DEV-S",
+		UserName: "admin",
+		Password: "Passw0rd!",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, rdb.InstanceStatusProvisioning, created.Status)
+
+	ready, err := rdbAPI.WaitForInstance(&rdb.WaitForInstanceRequest{
+		InstanceID:    created.ID,
+		RetryInterval: &retryInterval,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, rdb.InstanceStatusReady, ready.Status)
+
+	password, exists := fake.Password(created.ID, "admin")
+	assert.True(t, exists)
+	assert.Equal(t, "Passw0rd!", password)
+
+	_, err = rdbAPI.CreateUser(&rdb.CreateUserRequest{
+		InstanceID: created.ID,
+		Name:       "admin",
+		Password:   "Other0ne!",
+	})
+	assert.Error(t, err)
+
+	_, err = rdbAPI.UpdateUser(&rdb.UpdateUserRequest{
+		InstanceID: created.ID,
+		Name:       "admin",
+		Password:   scw.StringPtr(""),
+	})
+	assert.Error(t, err)
+	password, _ = fake.Password(created.ID, "admin")
+	assert.Equal(t, "Passw0rd!", password)
+}
+
+func TestSecretVersions(t *testing.T) {
+	secretAPI := secret.NewAPI(newTestClient(t))
+
+	created, err := secretAPI.CreateSecret(&secret.CreateSecretRequest{Name: "test-secret"})
+	require.NoError(t, err)
+	assert.Equal(t, "/", created.Path)
+
+	for _, data := range []string{"first", "second"} {
+		_, err = secretAPI.CreateSecretVersion(&secret.CreateSecretVersionRequest{
+			SecretID: created.ID,
+			Data:     []byte(data),
+		})
+		require.NoError(t, err)
+	}
+
+	latest, err := secretAPI.AccessSecretVersionByName(&secret.AccessSecretVersionByNameRequest{
+		SecretName: "test-secret",
+		Revision:   "latest",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, uint32(2), latest.Revision)
+	assert.Equal(t, []byte("second"), latest.Data)
+
+	_, err = secretAPI.DisableSecretVersion(&secret.DisableSecretVersionRequest{
+		SecretID: created.ID,
+		Revision: "2",
+	})
+	require.NoError(t, err)
+
+	enabled, err := secretAPI.AccessSecretVersion(&secret.AccessSecretVersionRequest{
+		SecretID: created.ID,
+		Revision: "latest_enabled",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []byte("first"), enabled.Data)
+}
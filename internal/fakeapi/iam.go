@@ -0,0 +1,826 @@
+package fakeapi
+
+import (
+	"crypto/md5" //nolint:gosec
+	"fmt"
+	"net/http"
+	"strings"
+
+	iam "github.com/scaleway/scaleway-sdk-go/api/iam/v1alpha1"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+)
+
+const iamPrefix = "/iam/v1alpha1"
+
+type iamStore struct {
+	applications *collection[iam.Application]
+	apiKeys      *collection[iam.APIKey]
+	policies     *collection[iam.Policy]
+	rules        map[string][]*iam.Rule
+	sshKeys      *collection[iam.SSHKey]
+	users        *collection[iam.User]
+	groups       *collection[iam.Group]
+}
+
+func (s *Server) registerIam() {
+	s.iam = &iamStore{
+		applications: newCollection[iam.Application](),
+		apiKeys:      newCollection[iam.APIKey](),
+		policies:     newCollection[iam.Policy](),
+		rules:        map[string][]*iam.Rule{},
+		sshKeys:      newCollection[iam.SSHKey](),
+		users:        newCollection[iam.User](),
+		groups:       newCollection[iam.Group](),
+	}
+
+	s.handle("GET "+iamPrefix+"/applications", s.iamListApplications)
+	s.handle("POST "+iamPrefix+"/applications", s.iamCreateApplication)
+	s.handle("GET "+iamPrefix+"/applications/{application_id}", s.iamGetApplication)
+	s.handle("PATCH "+iamPrefix+"/applications/{application_id}", s.iamUpdateApplication)
+	s.handle("DELETE "+iamPrefix+"/applications/{application_id}", s.iamDeleteApplication)
+
+	s.handle("GET "+iamPrefix+"/api-keys", s.iamListAPIKeys)
+	s.handle("POST "+iamPrefix+"/api-keys", s.iamCreateAPIKey)
+	s.handle("GET "+iamPrefix+"/api-keys/{access_key}", s.iamGetAPIKey)
+	s.handle("PATCH "+iamPrefix+"/api-keys/{access_key}", s.iamUpdateAPIKey)
+	s.handle("DELETE "+iamPrefix+"/api-keys/{access_key}", s.iamDeleteAPIKey)
+
+	s.handle("GET "+iamPrefix+"/policies", s.iamListPolicies)
+	s.handle("POST "+iamPrefix+"/policies", s.iamCreatePolicy)
+	s.handle("GET "+iamPrefix+"/policies/{policy_id}", s.iamGetPolicy)
+	s.handle("PATCH "+iamPrefix+"/policies/{policy_id}", s.iamUpdatePolicy)
+	s.handle("DELETE "+iamPrefix+"/policies/{policy_id}", s.iamDeletePolicy)
+	s.handle("GET "+iamPrefix+"/rules", s.iamListRules)
+	s.handle("PUT "+iamPrefix+"/rules", s.iamSetRules)
+
+	s.handle("GET "+iamPrefix+"/ssh-keys", s.iamListSSHKeys)
+	s.handle("POST "+iamPrefix+"/ssh-keys", s.iamCreateSSHKey)
+	s.handle("GET "+iamPrefix+"/ssh-keys/{ssh_key_id}", s.iamGetSSHKey)
+	s.handle("PATCH "+iamPrefix+"/ssh-keys/{ssh_key_id}", s.iamUpdateSSHKey)
+	s.handle("DELETE "+iamPrefix+"/ssh-keys/{ssh_key_id}", s.iamDeleteSSHKey)
+
+	s.handle("GET "+iamPrefix+"/users", s.iamListUsers)
+	s.handle("POST "+iamPrefix+"/users", s.iamCreateUser)
+	s.handle("GET "+iamPrefix+"/users/{user_id}", s.iamGetUser)
+	s.handle("DELETE "+iamPrefix+"/users/{user_id}", s.iamDeleteUser)
+
+	s.handle("GET "+iamPrefix+"/groups", s.iamListGroups)
+	s.handle("POST "+iamPrefix+"/groups", s.iamCreateGroup)
+	s.handle("GET "+iamPrefix+"/groups/{group_id}", s.iamGetGroup)
+	s.handle("PATCH "+iamPrefix+"/groups/{group_id}", s.iamUpdateGroup)
+	s.handle("DELETE "+iamPrefix+"/groups/{group_id}", s.iamDeleteGroup)
+	s.handle("PUT "+iamPrefix+"/groups/{group_id}/members", s.iamSetGroupMembers)
+	s.handle("POST "+iamPrefix+"/groups/{group_id}/add-member", s.iamAddGroupMember)
+	s.handle("POST "+iamPrefix+"/groups/{group_id}/remove-member", s.iamRemoveGroupMember)
+}
+
+func organizationOrDefault(organizationID string) string {
+	if organizationID == "" {
+		return DefaultOrganizationID
+	}
+
+	return organizationID
+}
+
+func tagsOrEmpty(tags []string) []string {
+	if tags == nil {
+		return []string{}
+	}
+
+	return tags
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
+}
+
+func removeString(values []string, value string) []string {
+	kept := []string{}
+	for _, v := range values {
+		if v != value {
+			kept = append(kept, v)
+		}
+	}
+
+	return kept
+}
+
+////
+// Applications
+////
+
+func (s *Server) iamApplication(r *http.Request) (*iam.Application, error) {
+	id := r.PathValue("application_id")
+	application, exists := s.iam.applications.get(id)
+	if !exists {
+		return nil, notFoundError("application", id)
+	}
+
+	return application, nil
+}
+
+func (s *Server) iamListApplications(r *http.Request) (interface{}, error) {
+	f := newFilter(r)
+	applications := s.iam.applications.list(func(application *iam.Application) bool {
+		return f.matchName(application.Name) &&
+			f.matchString(application.OrganizationID, "organization_id") &&
+			(f.get("tag") == "" || containsString(application.Tags, f.get("tag")))
+	})
+
+	return paginate(r, "applications", applications), nil
+}
+
+func (s *Server) iamCreateApplication(r *http.Request) (interface{}, error) {
+	req := &iam.CreateApplicationRequest{}
+	if err := decode(r, req); err != nil {
+		return nil, err
+	}
+
+	application := &iam.Application{
+		ID:             newID(),
+		Name:           req.Name,
+		Description:    req.Description,
+		CreatedAt:      s.timePtr(),
+		UpdatedAt:      s.timePtr(),
+		OrganizationID: organizationOrDefault(req.OrganizationID),
+		Editable:       true,
+		Tags:           tagsOrEmpty(req.Tags),
+	}
+	s.iam.applications.add(application.ID, application)
+
+	return application, nil
+}
+
+func (s *Server) iamGetApplication(r *http.Request) (interface{}, error) {
+	return s.iamApplication(r)
+}
+
+func (s *Server) iamUpdateApplication(r *http.Request) (interface{}, error) {
+	application, err := s.iamApplication(r)
+	if err != nil {
+		return nil, err
+	}
+
+	req := &iam.UpdateApplicationRequest{}
+	if err := decode(r, req); err != nil {
+		return nil, err
+	}
+
+	if req.Name != nil {
+		application.Name = *req.Name
+	}
+	if req.Description != nil {
+		application.Description = *req.Description
+	}
+	if req.Tags != nil {
+		application.Tags = *req.Tags
+	}
+	application.UpdatedAt = s.timePtr()
+
+	return application, nil
+}
+
+func (s *Server) iamDeleteApplication(r *http.Request) (interface{}, error) {
+	application, err := s.iamApplication(r)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, apiKey := range s.iam.apiKeys.list(nil) {
+		if apiKey.ApplicationID != nil && *apiKey.ApplicationID == application.ID {
+			s.iam.apiKeys.delete(apiKey.AccessKey)
+		}
+	}
+	for _, group := range s.iam.groups.list(nil) {
+		group.ApplicationIDs = removeString(group.ApplicationIDs, application.ID)
+	}
+	s.iam.applications.delete(application.ID)
+
+	return nil, nil
+}
+
+////
+// API keys
+////
+
+// newAccessKey returns a random access key, in the format of the real ones
+func newAccessKey() string {
+	const alphabet = "ABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789"
+	id := strings.ReplaceAll(newID(), "-", "")
+
+	key := make([]byte, 17)
+	for i := range key {
+		key[i] = alphabet[int(id[i])%len(alphabet)]
+	}
+
+	return "SCW" + string(key)
+}
+
+func (s *Server) iamAPIKey(r *http.Request) (*iam.APIKey, error) {
+	accessKey := r.PathValue("access_key")
+	apiKey, exists := s.iam.apiKeys.get(accessKey)
+	if !exists {
+		return nil, notFoundError("api_key", accessKey)
+	}
+
+	return apiKey, nil
+}
+
+// iamAPIKeyWithoutSecret returns a copy of an API key without its secret key, it is only returned on creation
+func iamAPIKeyWithoutSecret(apiKey *iam.APIKey) *iam.APIKey {
+	withoutSecret := *apiKey
+	withoutSecret.SecretKey = nil
+
+	return &withoutSecret
+}
+
+func (s *Server) iamListAPIKeys(r *http.Request) (interface{}, error) {
+	f := newFilter(r)
+	apiKeys := []*iam.APIKey{}
+	for _, apiKey := range s.iam.apiKeys.list(nil) {
+		applicationID, userID := "", ""
+		if apiKey.ApplicationID != nil {
+			applicationID = *apiKey.ApplicationID
+		}
+		if apiKey.UserID != nil {
+			userID = *apiKey.UserID
+		}
+		if f.matchString(applicationID, "application_id") &&
+			f.matchString(userID, "user_id") &&
+			f.matchString(applicationID+userID, "bearer_id") &&
+			strings.Contains(apiKey.Description, f.get("description")) {
+			apiKeys = append(apiKeys, iamAPIKeyWithoutSecret(apiKey))
+		}
+	}
+
+	return paginate(r, "api_keys", apiKeys), nil
+}
+
+func (s *Server) iamCreateAPIKey(r *http.Request) (interface{}, error) {
+	req := &iam.CreateAPIKeyRequest{}
+	if err := decode(r, req); err != nil {
+		return nil, err
+	}
+
+	switch {
+	case req.ApplicationID != nil:
+		application, exists := s.iam.applications.get(*req.ApplicationID)
+		if !exists {
+			return nil, notFoundError("application", *req.ApplicationID)
+		}
+		application.NbAPIKeys++
+	case req.UserID != nil:
+		if _, exists := s.iam.users.get(*req.UserID); !exists {
+			return nil, notFoundError("user", *req.UserID)
+		}
+	default:
+		return nil, invalidArgumentError("bearer", "required", "application_id or user_id is required")
+	}
+
+	apiKey := &iam.APIKey{
+		AccessKey:        newAccessKey(),
+		SecretKey:        scw.StringPtr(newID()),
+		ApplicationID:    req.ApplicationID,
+		UserID:           req.UserID,
+		Description:      req.Description,
+		CreatedAt:        s.timePtr(),
+		UpdatedAt:        s.timePtr(),
+		ExpiresAt:        req.ExpiresAt,
+		DefaultProjectID: projectOrDefault(req.DefaultProjectID),
+		Editable:         true,
+		CreationIP:       "127.0.0.1",
+	}
+	s.iam.apiKeys.add(apiKey.AccessKey, apiKey)
+
+	return apiKey, nil
+}
+
+func (s *Server) iamGetAPIKey(r *http.Request) (interface{}, error) {
+	apiKey, err := s.iamAPIKey(r)
+	if err != nil {
+		return nil, err
+	}
+
+	return iamAPIKeyWithoutSecret(apiKey), nil
+}
+
+func (s *Server) iamUpdateAPIKey(r *http.Request) (interface{}, error) {
+	apiKey, err := s.iamAPIKey(r)
+	if err != nil {
+		return nil, err
+	}
+
+	req := &iam.UpdateAPIKeyRequest{}
+	if err := decode(r, req); err != nil {
+		return nil, err
+	}
+
+	if req.DefaultProjectID != nil {
+		apiKey.DefaultProjectID = *req.DefaultProjectID
+	}
+	if req.Description != nil {
+		apiKey.Description = *req.Description
+	}
+	apiKey.UpdatedAt = s.timePtr()
+
+	return iamAPIKeyWithoutSecret(apiKey), nil
+}
+
+func (s *Server) iamDeleteAPIKey(r *http.Request) (interface{}, error) {
+	apiKey, err := s.iamAPIKey(r)
+	if err != nil {
+		return nil, err
+	}
+
+	if apiKey.ApplicationID != nil {
+		if application, exists := s.iam.applications.get(*apiKey.ApplicationID); exists {
+			application.NbAPIKeys--
+		}
+	}
+	s.iam.apiKeys.delete(apiKey.AccessKey)
+
+	return nil, nil
+}
+
+////
+// Policies
+////
+
+func (s *Server) iamPolicy(r *http.Request) (*iam.Policy, error) {
+	id := r.PathValue("policy_id")
+	policy, exists := s.iam.policies.get(id)
+	if !exists {
+		return nil, notFoundError("policy", id)
+	}
+
+	return policy, nil
+}
+
+// iamSetPolicyRules replaces the rules of a policy and updates its counters
+func (s *Server) iamSetPolicyRules(policy *iam.Policy, specs []*iam.RuleSpecs) []*iam.Rule {
+	rules := make([]*iam.Rule, 0, len(specs))
+	policy.NbPermissionSets = 0
+	policy.NbScopes = 0
+	for _, spec := range specs {
+		rule := &iam.Rule{
+			ID:                 newID(),
+			PermissionSetNames: spec.PermissionSetNames,
+			ProjectIDs:         spec.ProjectIDs,
+			OrganizationID:     spec.OrganizationID,
+		}
+		if spec.ProjectIDs != nil {
+			rule.PermissionSetsScopeType = iam.PermissionSetScopeTypeProjects
+			policy.NbScopes += uint32(len(*spec.ProjectIDs))
+		} else {
+			rule.PermissionSetsScopeType = iam.PermissionSetScopeTypeOrganization
+			policy.NbScopes++
+		}
+		if spec.PermissionSetNames != nil {
+			policy.NbPermissionSets += uint32(len(*spec.PermissionSetNames))
+		}
+		rules = append(rules, rule)
+	}
+	policy.NbRules = uint32(len(rules))
+	s.iam.rules[policy.ID] = rules
+
+	return rules
+}
+
+// iamSetPolicyPrincipal sets the principal of a policy, a policy has only one
+func iamSetPolicyPrincipal(policy *iam.Policy, userID, groupID, applicationID *string, noPrincipal *bool) {
+	if userID == nil && groupID == nil && applicationID == nil && noPrincipal == nil {
+		return
+	}
+
+	policy.UserID, policy.GroupID, policy.ApplicationID, policy.NoPrincipal = userID, groupID, applicationID, nil
+	if noPrincipal != nil && *noPrincipal {
+		policy.NoPrincipal = noPrincipal
+	}
+}
+
+func (s *Server) iamListPolicies(r *http.Request) (interface{}, error) {
+	f := newFilter(r)
+	policies := s.iam.policies.list(func(policy *iam.Policy) bool {
+		return f.matchString(policy.OrganizationID, "organization_id") &&
+			strings.Contains(policy.Name, f.get("policy_name")) &&
+			(f.get("tag") == "" || containsString(policy.Tags, f.get("tag")))
+	})
+
+	return paginate(r, "policies", policies), nil
+}
+
+func (s *Server) iamCreatePolicy(r *http.Request) (interface{}, error) {
+	req := &iam.CreatePolicyRequest{}
+	if err := decode(r, req); err != nil {
+		return nil, err
+	}
+
+	policy := &iam.Policy{
+		ID:             newID(),
+		Name:           req.Name,
+		Description:    req.Description,
+		OrganizationID: organizationOrDefault(req.OrganizationID),
+		CreatedAt:      s.timePtr(),
+		UpdatedAt:      s.timePtr(),
+		Editable:       true,
+		Tags:           tagsOrEmpty(req.Tags),
+	}
+	iamSetPolicyPrincipal(policy, req.UserID, req.GroupID, req.ApplicationID, req.NoPrincipal)
+	s.iamSetPolicyRules(policy, req.Rules)
+	s.iam.policies.add(policy.ID, policy)
+
+	return policy, nil
+}
+
+func (s *Server) iamGetPolicy(r *http.Request) (interface{}, error) {
+	return s.iamPolicy(r)
+}
+
+func (s *Server) iamUpdatePolicy(r *http.Request) (interface{}, error) {
+	policy, err := s.iamPolicy(r)
+	if err != nil {
+		return nil, err
+	}
+
+	req := &iam.UpdatePolicyRequest{}
+	if err := decode(r, req); err != nil {
+		return nil, err
+	}
+
+	if req.Name != nil {
+		policy.Name = *req.Name
+	}
+	if req.Description != nil {
+		policy.Description = *req.Description
+	}
+	if req.Tags != nil {
+		policy.Tags = *req.Tags
+	}
+	iamSetPolicyPrincipal(policy, req.UserID, req.GroupID, req.ApplicationID, req.NoPrincipal)
+	policy.UpdatedAt = s.timePtr()
+
+	return policy, nil
+}
+
+func (s *Server) iamDeletePolicy(r *http.Request) (interface{}, error) {
+	policy, err := s.iamPolicy(r)
+	if err != nil {
+		return nil, err
+	}
+
+	s.iam.policies.delete(policy.ID)
+	delete(s.iam.rules, policy.ID)
+
+	return nil, nil
+}
+
+func (s *Server) iamListRules(r *http.Request) (interface{}, error) {
+	policyID := r.URL.Query().Get("policy_id")
+	if _, exists := s.iam.policies.get(policyID); !exists {
+		return nil, notFoundError("policy", policyID)
+	}
+
+	return paginate(r, "rules", s.iam.rules[policyID]), nil
+}
+
+func (s *Server) iamSetRules(r *http.Request) (interface{}, error) {
+	req := &iam.SetRulesRequest{}
+	if err := decode(r, req); err != nil {
+		return nil, err
+	}
+
+	policy, exists := s.iam.policies.get(req.PolicyID)
+	if !exists {
+		return nil, notFoundError("policy", req.PolicyID)
+	}
+
+	return &iam.SetRulesResponse{Rules: s.iamSetPolicyRules(policy, req.Rules)}, nil
+}
+
+////
+// SSH keys
+////
+
+// sshKeyFingerprint returns the MD5 fingerprint of a public key, in the format of the API
+func sshKeyFingerprint(publicKey string) string {
+	sum := md5.Sum([]byte(publicKey)) //nolint:gosec
+	parts := make([]string, 0, len(sum))
+	for _, b := range sum {
+		parts = append(parts, fmt.Sprintf("%02x", b))
+	}
+
+	return "256 MD5:" + strings.Join(parts, ":")
+}
+
+func (s *Server) iamSSHKey(r *http.Request) (*iam.SSHKey, error) {
+	id := r.PathValue("ssh_key_id")
+	sshKey, exists := s.iam.sshKeys.get(id)
+	if !exists {
+		return nil, notFoundError("ssh_key", id)
+	}
+
+	return sshKey, nil
+}
+
+func (s *Server) iamListSSHKeys(r *http.Request) (interface{}, error) {
+	f := newFilter(r)
+	sshKeys := s.iam.sshKeys.list(func(sshKey *iam.SSHKey) bool {
+		return f.matchName(sshKey.Name) &&
+			f.matchProject(sshKey.ProjectID, sshKey.OrganizationID)
+	})
+
+	return paginate(r, "ssh_keys", sshKeys), nil
+}
+
+func (s *Server) iamCreateSSHKey(r *http.Request) (interface{}, error) {
+	req := &iam.CreateSSHKeyRequest{}
+	if err := decode(r, req); err != nil {
+		return nil, err
+	}
+
+	if !strings.HasPrefix(req.PublicKey, "ssh-") && !strings.HasPrefix(req.PublicKey, "ecdsa-") {
+		return nil, invalidArgumentError("public_key", "constraint", "public key is not a valid SSH key")
+	}
+
+	sshKey := &iam.SSHKey{
+		ID:             newID(),
+		Name:           req.Name,
+		PublicKey:      strings.TrimSpace(req.PublicKey),
+		Fingerprint:    sshKeyFingerprint(req.PublicKey),
+		CreatedAt:      s.timePtr(),
+		UpdatedAt:      s.timePtr(),
+		OrganizationID: DefaultOrganizationID,
+		ProjectID:      projectOrDefault(&req.ProjectID),
+	}
+	s.iam.sshKeys.add(sshKey.ID, sshKey)
+
+	return sshKey, nil
+}
+
+func (s *Server) iamGetSSHKey(r *http.Request) (interface{}, error) {
+	return s.iamSSHKey(r)
+}
+
+func (s *Server) iamUpdateSSHKey(r *http.Request) (interface{}, error) {
+	sshKey, err := s.iamSSHKey(r)
+	if err != nil {
+		return nil, err
+	}
+
+	req := &iam.UpdateSSHKeyRequest{}
+	if err := decode(r, req); err != nil {
+		return nil, err
+	}
+
+	if req.Name != nil {
+		sshKey.Name = *req.Name
+	}
+	if req.Disabled != nil {
+		sshKey.Disabled = *req.Disabled
+	}
+	sshKey.UpdatedAt = s.timePtr()
+
+	return sshKey, nil
+}
+
+func (s *Server) iamDeleteSSHKey(r *http.Request) (interface{}, error) {
+	sshKey, err := s.iamSSHKey(r)
+	if err != nil {
+		return nil, err
+	}
+
+	s.iam.sshKeys.delete(sshKey.ID)
+
+	return nil, nil
+}
+
+////
+// Users
+////
+
+func (s *Server) iamUser(r *http.Request) (*iam.User, error) {
+	id := r.PathValue("user_id")
+	user, exists := s.iam.users.get(id)
+	if !exists {
+		return nil, notFoundError("user", id)
+	}
+
+	return user, nil
+}
+
+func (s *Server) iamListUsers(r *http.Request) (interface{}, error) {
+	f := newFilter(r)
+	userIDs := f.query["user_ids"]
+	users := s.iam.users.list(func(user *iam.User) bool {
+		return f.matchString(user.OrganizationID, "organization_id") &&
+			(len(userIDs) == 0 || containsString(userIDs, user.ID)) &&
+			(f.get("tag") == "" || containsString(user.Tags, f.get("tag")))
+	})
+
+	return paginate(r, "users", users), nil
+}
+
+func (s *Server) iamCreateUser(r *http.Request) (interface{}, error) {
+	req := &iam.CreateUserRequest{}
+	if err := decode(r, req); err != nil {
+		return nil, err
+	}
+
+	if !strings.Contains(req.Email, "@") {
+		return nil, invalidArgumentError("email", "constraint", "email is not valid")
+	}
+
+	user := &iam.User{
+		ID:                newID(),
+		Email:             req.Email,
+		CreatedAt:         s.timePtr(),
+		UpdatedAt:         s.timePtr(),
+		OrganizationID:    organizationOrDefault(req.OrganizationID),
+		Deletable:         true,
+		Type:              iam.UserTypeGuest,
+		Status:            iam.UserStatusInvitationPending,
+		AccountRootUserID: DefaultOrganizationID,
+		Tags:              tagsOrEmpty(req.Tags),
+	}
+	s.iam.users.add(user.ID, user)
+
+	return user, nil
+}
+
+func (s *Server) iamGetUser(r *http.Request) (interface{}, error) {
+	return s.iamUser(r)
+}
+
+func (s *Server) iamDeleteUser(r *http.Request) (interface{}, error) {
+	user, err := s.iamUser(r)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, apiKey := range s.iam.apiKeys.list(nil) {
+		if apiKey.UserID != nil && *apiKey.UserID == user.ID {
+			s.iam.apiKeys.delete(apiKey.AccessKey)
+		}
+	}
+	for _, group := range s.iam.groups.list(nil) {
+		group.UserIDs = removeString(group.UserIDs, user.ID)
+	}
+	s.iam.users.delete(user.ID)
+
+	return nil, nil
+}
+
+////
+// Groups
+////
+
+func (s *Server) iamGroup(r *http.Request) (*iam.Group, error) {
+	id := r.PathValue("group_id")
+	group, exists := s.iam.groups.get(id)
+	if !exists {
+		return nil, notFoundError("group", id)
+	}
+
+	return group, nil
+}
+
+func (s *Server) iamListGroups(r *http.Request) (interface{}, error) {
+	f := newFilter(r)
+	groups := s.iam.groups.list(func(group *iam.Group) bool {
+		return f.matchName(group.Name) &&
+			f.matchString(group.OrganizationID, "organization_id") &&
+			(f.get("tag") == "" || containsString(group.Tags, f.get("tag")))
+	})
+
+	return paginate(r, "groups", groups), nil
+}
+
+func (s *Server) iamCreateGroup(r *http.Request) (interface{}, error) {
+	req := &iam.CreateGroupRequest{}
+	if err := decode(r, req); err != nil {
+		return nil, err
+	}
+
+	group := &iam.Group{
+		ID:             newID(),
+		CreatedAt:      s.timePtr(),
+		UpdatedAt:      s.timePtr(),
+		OrganizationID: organizationOrDefault(req.OrganizationID),
+		Name:           req.Name,
+		Description:    req.Description,
+		UserIDs:        []string{},
+		ApplicationIDs: []string{},
+		Tags:           tagsOrEmpty(req.Tags),
+	}
+	s.iam.groups.add(group.ID, group)
+
+	return group, nil
+}
+
+func (s *Server) iamGetGroup(r *http.Request) (interface{}, error) {
+	return s.iamGroup(r)
+}
+
+func (s *Server) iamUpdateGroup(r *http.Request) (interface{}, error) {
+	group, err := s.iamGroup(r)
+	if err != nil {
+		return nil, err
+	}
+
+	req := &iam.UpdateGroupRequest{}
+	if err := decode(r, req); err != nil {
+		return nil, err
+	}
+
+	if req.Name != nil {
+		group.Name = *req.Name
+	}
+	if req.Description != nil {
+		group.Description = *req.Description
+	}
+	if req.Tags != nil {
+		group.Tags = *req.Tags
+	}
+	group.UpdatedAt = s.timePtr()
+
+	return group, nil
+}
+
+func (s *Server) iamDeleteGroup(r *http.Request) (interface{}, error) {
+	group, err := s.iamGroup(r)
+	if err != nil {
+		return nil, err
+	}
+
+	s.iam.groups.delete(group.ID)
+
+	return nil, nil
+}
+
+func (s *Server) iamSetGroupMembers(r *http.Request) (interface{}, error) {
+	group, err := s.iamGroup(r)
+	if err != nil {
+		return nil, err
+	}
+
+	req := &iam.SetGroupMembersRequest{}
+	if err := decode(r, req); err != nil {
+		return nil, err
+	}
+
+	group.UserIDs = tagsOrEmpty(req.UserIDs)
+	group.ApplicationIDs = tagsOrEmpty(req.ApplicationIDs)
+	group.UpdatedAt = s.timePtr()
+
+	return group, nil
+}
+
+func (s *Server) iamAddGroupMember(r *http.Request) (interface{}, error) {
+	group, err := s.iamGroup(r)
+	if err != nil {
+		return nil, err
+	}
+
+	req := &iam.AddGroupMemberRequest{}
+	if err := decode(r, req); err != nil {
+		return nil, err
+	}
+
+	if req.UserID != nil && !containsString(group.UserIDs, *req.UserID) {
+		group.UserIDs = append(group.UserIDs, *req.UserID)
+	}
+	if req.ApplicationID != nil && !containsString(group.ApplicationIDs, *req.ApplicationID) {
+		group.ApplicationIDs = append(group.ApplicationIDs, *req.ApplicationID)
+	}
+	group.UpdatedAt = s.timePtr()
+
+	return group, nil
+}
+
+func (s *Server) iamRemoveGroupMember(r *http.Request) (interface{}, error) {
+	group, err := s.iamGroup(r)
+	if err != nil {
+		return nil, err
+	}
+
+	req := &iam.RemoveGroupMemberRequest{}
+	if err := decode(r, req); err != nil {
+		return nil, err
+	}
+
+	if req.UserID != nil {
+		group.UserIDs = removeString(group.UserIDs, *req.UserID)
+	}
+	if req.ApplicationID != nil {
+		group.ApplicationIDs = removeString(group.ApplicationIDs, *req.ApplicationID)
+	}
+	group.UpdatedAt = s.timePtr()
+
+	return group, nil
+}
@@ -0,0 +1,2220 @@
+package fakeapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	block "github.com/scaleway/scaleway-sdk-go/api/block/v1alpha1"
+	instance "github.com/scaleway/scaleway-sdk-go/api/instance/v1"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+)
+
+const (
+	instancePrefix = "/instance/v1/zones/{zone}"
+
+	// instanceDefaultVolumeSize is the size of the root volume of servers created without an explicit size
+	instanceDefaultVolumeSize = 10 * scw.GB
+	// instanceImportedSnapshotSize is the size of the snapshots imported from object storage, whose objects are not read
+	instanceImportedSnapshotSize = 20 * scw.GB
+)
+
+type instanceStore struct {
+	servers            *collection[instance.Server]
+	ips                *collection[instance.IP]
+	volumes            *collection[instance.Volume]
+	snapshots          *collection[instance.Snapshot]
+	images             *collection[instance.Image]
+	securityGroups     *collection[instance.SecurityGroup]
+	securityGroupRules map[string][]*instance.SecurityGroupRule
+	privateNICs        *collection[instance.PrivateNIC]
+	userData           map[string]map[string][]byte
+	snapshotExports    map[string][]string
+	serverTypes        map[string]*instance.ServerType
+	availabilities     map[string]instance.ServerTypesAvailability
+
+	// lastIP is the index of the last allocated public IP, IPs are allocated sequentially
+	lastIP int
+}
+
+func (s *Server) registerInstance() {
+	s.instance = &instanceStore{
+		servers:            newCollection[instance.Server](),
+		ips:                newCollection[instance.IP](),
+		volumes:            newCollection[instance.Volume](),
+		snapshots:          newCollection[instance.Snapshot](),
+		images:             newCollection[instance.Image](),
+		securityGroups:     newCollection[instance.SecurityGroup](),
+		securityGroupRules: map[string][]*instance.SecurityGroupRule{},
+		privateNICs:        newCollection[instance.PrivateNIC](),
+		userData:           map[string]map[string][]byte{},
+		snapshotExports:    map[string][]string{},
+		serverTypes:        instanceServerTypes(),
+		availabilities:     map[string]instance.ServerTypesAvailability{},
+	}
+
+	s.handle("GET "+instancePrefix+"/products/servers", s.instanceListServerTypes)
+	s.handle("GET "+instancePrefix+"/products/servers/availability", s.instanceListServerTypesAvailability)
+
+	s.handle("GET "+instancePrefix+"/servers", s.instanceListServers)
+	s.handle("POST "+instancePrefix+"/servers", s.instanceCreateServer)
+	s.handle("GET "+instancePrefix+"/servers/{server_id}", s.instanceGetServer)
+	s.handle("PATCH "+instancePrefix+"/servers/{server_id}", s.instanceUpdateServer)
+	s.handle("DELETE "+instancePrefix+"/servers/{server_id}", s.instanceDeleteServer)
+	s.handle("GET "+instancePrefix+"/servers/{server_id}/action", s.instanceListServerActions)
+	s.handle("POST "+instancePrefix+"/servers/{server_id}/action", s.instanceServerAction)
+	s.handle("POST "+instancePrefix+"/servers/{server_id}/attach-volume", s.instanceAttachVolume)
+	s.handle("POST "+instancePrefix+"/servers/{server_id}/detach-volume", s.instanceDetachVolume)
+
+	s.handle("GET "+instancePrefix+"/servers/{server_id}/user_data", s.instanceListUserData)
+	s.mux.HandleFunc("GET "+instancePrefix+"/servers/{server_id}/user_data/{key}", s.instanceGetUserData)
+	s.handle("PATCH "+instancePrefix+"/servers/{server_id}/user_data/{key}", s.instanceSetUserData)
+	s.handle("DELETE "+instancePrefix+"/servers/{server_id}/user_data/{key}", s.instanceDeleteUserData)
+
+	s.handle("GET "+instancePrefix+"/servers/{server_id}/private_nics", s.instanceListPrivateNICs)
+	s.handle("POST "+instancePrefix+"/servers/{server_id}/private_nics", s.instanceCreatePrivateNIC)
+	s.handle("GET "+instancePrefix+"/servers/{server_id}/private_nics/{private_nic_id}", s.instanceGetPrivateNIC)
+	s.handle("PATCH "+instancePrefix+"/servers/{server_id}/private_nics/{private_nic_id}", s.instanceUpdatePrivateNIC)
+	s.handle("DELETE "+instancePrefix+"/servers/{server_id}/private_nics/{private_nic_id}", s.instanceDeletePrivateNIC)
+
+	s.handle("GET "+instancePrefix+"/ips", s.instanceListIPs)
+	s.handle("POST "+instancePrefix+"/ips", s.instanceCreateIP)
+	s.handle("GET "+instancePrefix+"/ips/{ip}", s.instanceGetIP)
+	s.handle("PATCH "+instancePrefix+"/ips/{ip}", s.instanceUpdateIP)
+	s.handle("DELETE "+instancePrefix+"/ips/{ip}", s.instanceDeleteIP)
+
+	s.handle("GET "+instancePrefix+"/volumes", s.instanceListVolumes)
+	s.handle("POST "+instancePrefix+"/volumes", s.instanceCreateVolume)
+	s.handle("GET "+instancePrefix+"/volumes/{volume_id}", s.instanceGetVolume)
+	s.handle("PATCH "+instancePrefix+"/volumes/{volume_id}", s.instanceUpdateVolume)
+	s.handle("DELETE "+instancePrefix+"/volumes/{volume_id}", s.instanceDeleteVolume)
+	s.handle("POST "+instancePrefix+"/block-migration/plan", s.instancePlanBlockMigration)
+	s.handle("POST "+instancePrefix+"/block-migration/apply", s.instanceApplyBlockMigration)
+
+	s.handle("GET "+instancePrefix+"/snapshots", s.instanceListSnapshots)
+	s.handle("POST "+instancePrefix+"/snapshots", s.instanceCreateSnapshot)
+	s.handle("GET "+instancePrefix+"/snapshots/{snapshot_id}", s.instanceGetSnapshot)
+	s.handle("PATCH "+instancePrefix+"/snapshots/{snapshot_id}", s.instanceUpdateSnapshot)
+	s.handle("DELETE "+instancePrefix+"/snapshots/{snapshot_id}", s.instanceDeleteSnapshot)
+	s.handle("POST "+instancePrefix+"/snapshots/{snapshot_id}/export", s.instanceExportSnapshot)
+
+	s.handle("GET "+instancePrefix+"/images", s.instanceListImages)
+	s.handle("POST "+instancePrefix+"/images", s.instanceCreateImage)
+	s.handle("GET "+instancePrefix+"/images/{image_id}", s.instanceGetImage)
+	s.handle("DELETE "+instancePrefix+"/images/{image_id}", s.instanceDeleteImage)
+
+	s.handle("GET "+instancePrefix+"/security_groups", s.instanceListSecurityGroups)
+	s.handle("POST "+instancePrefix+"/security_groups", s.instanceCreateSecurityGroup)
+	s.handle("GET "+instancePrefix+"/security_groups/{security_group_id}", s.instanceGetSecurityGroup)
+	s.handle("PATCH "+instancePrefix+"/security_groups/{security_group_id}", s.instanceUpdateSecurityGroup)
+	s.handle("DELETE "+instancePrefix+"/security_groups/{security_group_id}", s.instanceDeleteSecurityGroup)
+	s.handle("GET "+instancePrefix+"/security_groups/{security_group_id}/rules", s.instanceListSecurityGroupRules)
+	s.handle("POST "+instancePrefix+"/security_groups/{security_group_id}/rules", s.instanceCreateSecurityGroupRule)
+	s.handle("PUT "+instancePrefix+"/security_groups/{security_group_id}/rules", s.instanceSetSecurityGroupRules)
+	s.handle("GET "+instancePrefix+"/security_groups/{security_group_id}/rules/{rule_id}", s.instanceGetSecurityGroupRule)
+	s.handle("PATCH "+instancePrefix+"/security_groups/{security_group_id}/rules/{rule_id}", s.instanceUpdateSecurityGroupRule)
+	s.handle("DELETE "+instancePrefix+"/security_groups/{security_group_id}/rules/{rule_id}", s.instanceDeleteSecurityGroupRule)
+}
+
+// instanceServerTypes returns the catalog of server types, a subset of the real one
+func instanceServerTypes() map[string]*instance.ServerType {
+	serverType := func(ncpus uint32, ram uint64, hourlyPrice float32, localVolumeMaxSize scw.Size) *instance.ServerType {
+		return &instance.ServerType{
+			HourlyPrice:  hourlyPrice,
+			MonthlyPrice: scw.Float32Ptr(hourlyPrice * 730),
+			Ncpus:        ncpus,
+			RAM:          ram * uint64(scw.GB),
+			Arch:         instance.ArchX86_64,
+			AltNames:     []string{},
+			VolumesConstraint: &instance.ServerTypeVolumeConstraintSizes{
+				MinSize: 0,
+				MaxSize: localVolumeMaxSize,
+			},
+			PerVolumeConstraint: &instance.ServerTypeVolumeConstraintsByType{
+				LSSD: &instance.ServerTypeVolumeConstraintSizes{
+					MinSize: scw.GB,
+					MaxSize: localVolumeMaxSize,
+				},
+			},
+			Network: &instance.ServerTypeNetwork{
+				Interfaces:           []*instance.ServerTypeNetworkInterface{},
+				SumInternalBandwidth: scw.Uint64Ptr(uint64(ncpus) * 200_000_000),
+				SumInternetBandwidth: scw.Uint64Ptr(uint64(ncpus) * 200_000_000),
+			},
+			Capabilities: &instance.ServerTypeCapabilities{
+				BlockStorage: scw.BoolPtr(true),
+				BootTypes:    []instance.BootType{instance.BootTypeLocal, instance.BootTypeRescue},
+			},
+		}
+	}
+
+	return map[string]*instance.ServerType{
+		"DEV1-S":     serverType(2, 2, 0.0088, 20*scw.GB),
+		"DEV1-M":     serverType(3, 4, 0.0198, 40*scw.GB),
+		"DEV1-L":     serverType(4, 8, 0.042, 80*scw.GB),
+		"GP1-XS":     serverType(4, 16, 0.091, 150*scw.GB),
+		"PLAY2-PICO": serverType(1, 2, 0.014, 0),
+		"PLAY2-NANO": serverType(2, 4, 0.027, 0),
+		"PRO2-XXS":   serverType(2, 8, 0.055, 0),
+		"PRO2-S":     serverType(8, 32, 0.219, 0),
+	}
+}
+
+func (s *Server) instanceListServerTypes(r *http.Request) (interface{}, error) {
+	servers := map[string]*instance.ServerType{}
+	if queryInt(r, "page", 1) == 1 {
+		servers = s.instance.serverTypes
+	}
+
+	return &instance.ListServersTypesResponse{
+		Servers:    servers,
+		TotalCount: uint32(len(s.instance.serverTypes)),
+	}, nil
+}
+
+func (s *Server) instanceListServerTypesAvailability(r *http.Request) (interface{}, error) {
+	servers := map[string]*instance.GetServerTypesAvailabilityResponseAvailability{}
+	if queryInt(r, "page", 1) == 1 {
+		for name := range s.instance.serverTypes {
+			availability, exists := s.instance.availabilities[name]
+			if !exists {
+				availability = instance.ServerTypesAvailabilityAvailable
+			}
+			servers[name] = &instance.GetServerTypesAvailabilityResponseAvailability{
+				Availability: availability,
+			}
+		}
+	}
+
+	return &instance.GetServerTypesAvailabilityResponse{
+		Servers:    servers,
+		TotalCount: uint32(len(s.instance.serverTypes)),
+	}, nil
+}
+
+// SetServerTypeAvailability sets the stock of a server type, they are all available by default
+func (s *Server) SetServerTypeAvailability(commercialType string, availability instance.ServerTypesAvailability) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.instance.availabilities[commercialType] = availability
+}
+
+////
+// Servers
+////
+
+func (s *Server) instanceServer(r *http.Request) (*instance.Server, error) {
+	id := r.PathValue("server_id")
+	server, exists := s.instance.servers.get(id)
+	if !exists || server.Zone != zone(r) {
+		return nil, notFoundError("instance_server", id)
+	}
+
+	return server, nil
+}
+
+// instanceServerIdle returns the server of the request, or an error if it is in a transient state
+func (s *Server) instanceServerIdle(r *http.Request) (*instance.Server, error) {
+	server, err := s.instanceServer(r)
+	if err != nil {
+		return nil, err
+	}
+	if s.isTransient(server.ID) {
+		return nil, transientStateError("instance_server", server.ID, string(server.State))
+	}
+
+	return server, nil
+}
+
+func (s *Server) instanceListServers(r *http.Request) (interface{}, error) {
+	f := newFilter(r)
+	servers := s.instance.servers.list(func(server *instance.Server) bool {
+		return server.Zone == zone(r) &&
+			f.matchName(server.Name) &&
+			f.matchProject(server.Project, server.Organization) &&
+			f.matchTags(server.Tags) &&
+			f.matchString(string(server.State), "state") &&
+			f.matchString(server.CommercialType, "commercial_type") &&
+			instanceServerInPrivateNetwork(server, f.get("private_network"))
+	})
+	for _, server := range servers {
+		s.settle(server.ID)
+	}
+
+	return paginate(r, "servers", servers), nil
+}
+
+// instanceServerInPrivateNetwork returns whether the server has a private NIC in the private network, if any
+func instanceServerInPrivateNetwork(server *instance.Server, privateNetworkID string) bool {
+	if privateNetworkID == "" {
+		return true
+	}
+	for _, nic := range server.PrivateNics {
+		if nic.PrivateNetworkID == privateNetworkID {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (s *Server) instanceCreateServer(r *http.Request) (interface{}, error) {
+	req := &instance.CreateServerRequest{}
+	if err := decode(r, req); err != nil {
+		return nil, err
+	}
+
+	if _, exists := s.instance.serverTypes[req.CommercialType]; !exists {
+		return nil, invalidArgumentError("commercial_type", "constraint", fmt.Sprintf("%s is not a valid commercial type", req.CommercialType))
+	}
+
+	server := &instance.Server{
+		ID:                newID(),
+		Name:              req.Name,
+		Organization:      DefaultOrganizationID,
+		Project:           projectOrDefault(req.Project, req.Organization),
+		Tags:              req.Tags,
+		CommercialType:    req.CommercialType,
+		CreationDate:      s.timePtr(),
+		ModificationDate:  s.timePtr(),
+		DynamicIPRequired: req.DynamicIPRequired == nil || *req.DynamicIPRequired,
+		RoutedIPEnabled:   req.RoutedIPEnabled == nil || *req.RoutedIPEnabled,
+		EnableIPv6:        req.EnableIPv6,
+		Hostname:          req.Name,
+		PublicIPs:         []*instance.ServerIP{},
+		BootType:          instance.BootTypeLocal,
+		Volumes:           map[string]*instance.VolumeServer{},
+		Arch:              instance.ArchX86_64,
+		PrivateNics:       []*instance.PrivateNIC{},
+		Maintenances:      []*instance.ServerMaintenance{},
+		Zone:              zone(r),
+	}
+	server.Bootscript = instanceDefaultBootscript(server)
+	if server.Tags == nil {
+		server.Tags = []string{}
+	}
+	if req.BootType != nil {
+		server.BootType = *req.BootType
+	}
+
+	rootVolumeSize := instanceDefaultVolumeSize
+	if req.Image != "" {
+		image, exists := s.instance.images.get(req.Image)
+		if !exists {
+			return nil, notFoundError("instance_image", req.Image)
+		}
+		server.Image = image
+		if image.RootVolume != nil {
+			rootVolumeSize = image.RootVolume.Size
+		}
+	}
+
+	securityGroup, err := s.instanceServerSecurityGroup(server, req.SecurityGroup)
+	if err != nil {
+		return nil, err
+	}
+	server.SecurityGroup = &instance.SecurityGroupSummary{ID: securityGroup.ID, Name: securityGroup.Name}
+	securityGroup.Servers = append(securityGroup.Servers, &instance.ServerSummary{ID: server.ID, Name: server.Name})
+
+	// The root volume is created from the image when not set, like the real API
+	if len(req.Volumes) == 0 && server.Image != nil {
+		req.Volumes = map[string]*instance.VolumeServerTemplate{"0": {}}
+	}
+	for _, index := range sortedKeys(req.Volumes) {
+		template := req.Volumes[index]
+		size := rootVolumeSize
+		if template.Size != nil {
+			size = *template.Size
+		}
+		if template.VolumeType == "sbs_volume" {
+			blockVolume, exists := s.block.volumes.get(stringOrDefault(template.ID, ""))
+			if !exists {
+				blockVolume = s.blockNewVolumeFromInstance(&instance.Volume{
+					ID:           newID(),
+					Name:         stringOrDefault(template.Name, server.Name),
+					Size:         size,
+					CreationDate: s.timePtr(),
+					Project:      server.Project,
+					Tags:         []string{},
+					Zone:         server.Zone,
+				})
+			} else if len(blockVolume.References) > 0 {
+				return nil, preconditionFailedError("volume_not_attached", fmt.Sprintf("volume %s is already attached to a server", blockVolume.ID))
+			}
+			s.blockAttachVolume(blockVolume.ID, server.ID)
+			server.Volumes[index] = instanceBlockVolumeServer(blockVolume, index == "0")
+			continue
+		}
+
+		var volume *instance.Volume
+		if template.ID != nil && *template.ID != "" {
+			existingVolume, exists := s.instance.volumes.get(*template.ID)
+			if !exists {
+				return nil, notFoundError("instance_volume", *template.ID)
+			}
+			if existingVolume.Server != nil {
+				return nil, preconditionFailedError("volume_not_attached", fmt.Sprintf("volume %s is already attached to a server", existingVolume.ID))
+			}
+			volume = existingVolume
+		} else {
+			volumeType := template.VolumeType
+			if volumeType == "" {
+				volumeType = instance.VolumeVolumeTypeLSSD
+			}
+			volume = s.instanceNewVolume(server.Zone, server.Project, stringOrDefault(template.Name, server.Name), volumeType, size)
+		}
+		volume.Server = &instance.ServerSummary{ID: server.ID, Name: server.Name}
+		server.Volumes[index] = instanceVolumeServer(volume, index == "0")
+	}
+
+	publicIPs := []string{}
+	if req.PublicIP != nil {
+		publicIPs = append(publicIPs, *req.PublicIP)
+	}
+	if req.PublicIPs != nil {
+		publicIPs = append(publicIPs, *req.PublicIPs...)
+	}
+	for _, ipID := range publicIPs {
+		ip, exists := s.instance.ips.get(ipID)
+		if !exists {
+			return nil, notFoundError("instance_ip", ipID)
+		}
+		s.instanceAttachIP(ip, server)
+	}
+
+	server.State = instance.ServerStateStopped
+	server.AllowedActions = instanceServerAllowedActions(server.State)
+	s.instance.servers.add(server.ID, server)
+
+	return &instance.CreateServerResponse{Server: server}, nil
+}
+
+// instanceDefaultBootscript returns the bootscript the API reports for servers booting locally
+func instanceDefaultBootscript(server *instance.Server) *instance.Bootscript {
+	return &instance.Bootscript{
+		ID:           "fdfe150f-a870-4ce4-b432-9f56b5b995c1",
+		Title:        "x86_64 mainline 4.4.230 rev1",
+		Arch:         instance.ArchX86_64,
+		Bootcmdargs:  "LINUX_COMMON scaleway boot=local nbd.max_part=16",
+		Default:      true,
+		Public:       true,
+		Organization: server.Organization,
+		Project:      server.Project,
+		Zone:         server.Zone,
+	}
+}
+
+// instanceServerSecurityGroup returns the security group of a new server, the default one of its project if not set
+func (s *Server) instanceServerSecurityGroup(server *instance.Server, securityGroupID *string) (*instance.SecurityGroup, error) {
+	if securityGroupID != nil && *securityGroupID != "" {
+		securityGroup, exists := s.instance.securityGroups.get(*securityGroupID)
+		if !exists {
+			return nil, notFoundError("instance_security_group", *securityGroupID)
+		}
+		return securityGroup, nil
+	}
+
+	for _, securityGroup := range s.instance.securityGroups.list(nil) {
+		if securityGroup.Zone == server.Zone && securityGroup.Project == server.Project && securityGroup.ProjectDefault {
+			return securityGroup, nil
+		}
+	}
+
+	securityGroup := s.instanceNewSecurityGroup(server.Zone, server.Project, "Default security group")
+	securityGroup.ProjectDefault = true
+
+	return securityGroup, nil
+}
+
+func (s *Server) instanceGetServer(r *http.Request) (interface{}, error) {
+	server, err := s.instanceServer(r)
+	if err != nil {
+		return nil, err
+	}
+	s.settle(server.ID)
+
+	// The server may have been deleted by a terminate action
+	if _, exists := s.instance.servers.get(server.ID); !exists {
+		return nil, notFoundError("instance_server", server.ID)
+	}
+
+	return &instance.GetServerResponse{Server: server}, nil
+}
+
+func (s *Server) instanceUpdateServer(r *http.Request) (interface{}, error) {
+	server, err := s.instanceServerIdle(r)
+	if err != nil {
+		return nil, err
+	}
+
+	req := &instance.UpdateServerRequest{}
+	if err := decode(r, req); err != nil {
+		return nil, err
+	}
+
+	if req.Name != nil {
+		server.Name = *req.Name
+	}
+	if req.Tags != nil {
+		server.Tags = *req.Tags
+	}
+	if req.BootType != nil {
+		server.BootType = *req.BootType
+	}
+	if req.DynamicIPRequired != nil {
+		server.DynamicIPRequired = *req.DynamicIPRequired
+	}
+	if req.RoutedIPEnabled != nil {
+		server.RoutedIPEnabled = *req.RoutedIPEnabled
+	}
+	if req.EnableIPv6 != nil {
+		server.EnableIPv6 = *req.EnableIPv6
+	}
+	if req.Protected != nil {
+		server.Protected = *req.Protected
+	}
+	if req.CommercialType != nil {
+		if server.State != instance.ServerStateStopped {
+			return nil, preconditionFailedError("server_stopped", "server must be stopped to change its commercial type")
+		}
+		if _, exists := s.instance.serverTypes[*req.CommercialType]; !exists {
+			return nil, invalidArgumentError("commercial_type", "constraint", fmt.Sprintf("%s is not a valid commercial type", *req.CommercialType))
+		}
+		server.CommercialType = *req.CommercialType
+	}
+	if req.SecurityGroup != nil {
+		securityGroup, exists := s.instance.securityGroups.get(req.SecurityGroup.ID)
+		if !exists {
+			return nil, notFoundError("instance_security_group", req.SecurityGroup.ID)
+		}
+		s.instanceDetachSecurityGroup(server)
+		server.SecurityGroup = &instance.SecurityGroupSummary{ID: securityGroup.ID, Name: securityGroup.Name}
+		securityGroup.Servers = append(securityGroup.Servers, &instance.ServerSummary{ID: server.ID, Name: server.Name})
+	}
+	if req.PublicIPs != nil {
+		for _, serverIP := range server.PublicIPs {
+			if ip, exists := s.instance.ips.get(serverIP.ID); exists {
+				s.instanceDetachIP(ip)
+			}
+		}
+		for _, ipID := range *req.PublicIPs {
+			ip, exists := s.instance.ips.get(ipID)
+			if !exists {
+				return nil, notFoundError("instance_ip", ipID)
+			}
+			s.instanceAttachIP(ip, server)
+		}
+	}
+	if req.Volumes != nil {
+		if err := s.instanceSetServerVolumes(server, *req.Volumes); err != nil {
+			return nil, err
+		}
+	}
+	server.ModificationDate = s.timePtr()
+
+	return &instance.UpdateServerResponse{Server: server}, nil
+}
+
+// instanceSetServerVolumes replaces the volumes attached to a server
+func (s *Server) instanceSetServerVolumes(server *instance.Server, templates map[string]*instance.VolumeServerTemplate) error {
+	volumes := map[string]*instance.VolumeServer{}
+	for _, index := range sortedKeys(templates) {
+		template := templates[index]
+		if template.ID == nil {
+			return invalidArgumentError("volumes."+index+".id", "required", "volumes of a server can only be updated by ID")
+		}
+		if blockVolume, exists := s.block.volumes.get(*template.ID); exists {
+			if len(blockVolume.References) > 0 && blockVolume.References[0].ProductResourceID != server.ID {
+				return preconditionFailedError("volume_not_attached", fmt.Sprintf("volume %s is already attached to a server", blockVolume.ID))
+			}
+			volumes[index] = instanceBlockVolumeServer(blockVolume, index == "0")
+			continue
+		}
+		volume, exists := s.instance.volumes.get(*template.ID)
+		if !exists {
+			return notFoundError("instance_volume", *template.ID)
+		}
+		if volume.Server != nil && volume.Server.ID != server.ID {
+			return preconditionFailedError("volume_not_attached", fmt.Sprintf("volume %s is already attached to a server", volume.ID))
+		}
+		volumes[index] = instanceVolumeServer(volume, index == "0")
+	}
+
+	for _, volume := range server.Volumes {
+		if _, kept := instanceServerVolumeIndex(volumes, volume.ID); !kept &&
+			volume.VolumeType == instance.VolumeServerVolumeTypeLSSD && server.State != instance.ServerStateStopped {
+			return preconditionFailedError("server_stopped", "server must be stopped to detach a local volume")
+		}
+	}
+	for _, volume := range server.Volumes {
+		s.instanceDetachServerVolume(volume.ID, false)
+	}
+	for _, volume := range volumes {
+		if existingVolume, exists := s.instance.volumes.get(volume.ID); exists {
+			existingVolume.Server = &instance.ServerSummary{ID: server.ID, Name: server.Name}
+		} else {
+			s.blockAttachVolume(volume.ID, server.ID)
+		}
+	}
+	server.Volumes = volumes
+
+	return nil
+}
+
+// instanceServerVolumeIndex returns the index of a volume among the volumes of a server
+func instanceServerVolumeIndex(volumes map[string]*instance.VolumeServer, volumeID string) (string, bool) {
+	for index, volume := range volumes {
+		if volume.ID == volumeID {
+			return index, true
+		}
+	}
+
+	return "", false
+}
+
+// instanceDetachServerVolume detaches a volume from its server, instance and block volumes alike, and deletes it if asked to
+func (s *Server) instanceDetachServerVolume(volumeID string, deleteVolume bool) {
+	if volume, exists := s.instance.volumes.get(volumeID); exists {
+		volume.Server = nil
+		if deleteVolume {
+			s.instance.volumes.delete(volumeID)
+		}
+	}
+	if volume, exists := s.block.volumes.get(volumeID); exists {
+		volume.References = []*block.Reference{}
+		volume.Status = blockVolumeStatus(volume)
+		if deleteVolume {
+			s.block.volumes.delete(volumeID)
+		}
+	}
+}
+
+func (s *Server) instanceDeleteServer(r *http.Request) (interface{}, error) {
+	server, err := s.instanceServerIdle(r)
+	if err != nil {
+		return nil, err
+	}
+	if server.State != instance.ServerStateStopped && server.State != instance.ServerStateStoppedInPlace {
+		return nil, preconditionFailedError("server_stopped", "instance must be stopped to be deleted")
+	}
+
+	s.instanceRemoveServer(server, false)
+
+	return nil, nil
+}
+
+// instanceRemoveServer deletes a server, its volumes are detached or deleted with it
+func (s *Server) instanceRemoveServer(server *instance.Server, deleteVolumes bool) {
+	for _, volume := range server.Volumes {
+		s.instanceDetachServerVolume(volume.ID, deleteVolumes)
+	}
+	for _, serverIP := range server.PublicIPs {
+		if ip, exists := s.instance.ips.get(serverIP.ID); exists {
+			s.instanceDetachIP(ip)
+		}
+	}
+	for _, nic := range server.PrivateNics {
+		s.instanceRemovePrivateNIC(nic)
+	}
+	s.instanceDetachSecurityGroup(server)
+	delete(s.instance.userData, server.ID)
+	delete(s.transitions, server.ID)
+	s.instance.servers.delete(server.ID)
+}
+
+func instanceServerAllowedActions(state instance.ServerState) []instance.ServerAction {
+	switch state {
+	case instance.ServerStateRunning:
+		return []instance.ServerAction{instance.ServerActionPoweroff, instance.ServerActionStopInPlace, instance.ServerActionReboot, instance.ServerActionTerminate, instance.ServerActionBackup}
+	case instance.ServerStateStopped, instance.ServerStateStoppedInPlace:
+		return []instance.ServerAction{instance.ServerActionPoweron, instance.ServerActionBackup}
+	default:
+		return []instance.ServerAction{}
+	}
+}
+
+func (s *Server) instanceListServerActions(r *http.Request) (interface{}, error) {
+	server, err := s.instanceServer(r)
+	if err != nil {
+		return nil, err
+	}
+
+	return &instance.ListServerActionsResponse{Actions: instanceServerAllowedActions(server.State)}, nil
+}
+
+func (s *Server) instanceServerAction(r *http.Request) (interface{}, error) {
+	server, err := s.instanceServerIdle(r)
+	if err != nil {
+		return nil, err
+	}
+
+	req := &instance.ServerActionRequest{}
+	if err := decode(r, req); err != nil {
+		return nil, err
+	}
+	if req.Action == "" {
+		req.Action = instance.ServerActionPoweron
+	}
+
+	allowed := false
+	for _, action := range instanceServerAllowedActions(server.State) {
+		allowed = allowed || action == req.Action
+	}
+	if !allowed {
+		return nil, preconditionFailedError("action_allowed", fmt.Sprintf("action %s is not allowed when the server is %s", req.Action, server.State))
+	}
+
+	switch req.Action {
+	case instance.ServerActionPoweron:
+		s.instanceSetServerState(server, instance.ServerStateStarting, "provisioning node", instance.ServerStateRunning, "booted")
+		if server.DynamicIPRequired && len(server.PublicIPs) == 0 {
+			ip := s.instanceNewIP(server.Zone, server.Project, instance.IPTypeRoutedIPv4)
+			ip.Tags = []string{"dynamic"}
+			s.instanceAttachIP(ip, server)
+			server.PublicIP.Dynamic = true
+			server.PublicIPs[0].Dynamic = true
+		}
+	case instance.ServerActionPoweroff:
+		s.instanceSetServerState(server, instance.ServerStateStopping, "stopping", instance.ServerStateStopped, "")
+		s.instanceReleaseDynamicIP(server)
+	case instance.ServerActionStopInPlace:
+		s.instanceSetServerState(server, instance.ServerStateStopping, "stopping", instance.ServerStateStoppedInPlace, "")
+	case instance.ServerActionReboot:
+		s.instanceSetServerState(server, instance.ServerStateRunning, "rebooting", instance.ServerStateRunning, "booted")
+	case instance.ServerActionTerminate:
+		server.State = instance.ServerStateStopping
+		server.StateDetail = "terminating"
+		server.AllowedActions = []instance.ServerAction{}
+		s.schedule(server.ID, func() {
+			s.instanceRemoveServer(server, true)
+		})
+	case instance.ServerActionBackup:
+		image, err := s.instanceBackupServer(server, stringOrDefault(req.Name, server.Name+"-backup"))
+		if err != nil {
+			return nil, err
+		}
+		return &instance.ServerActionResponse{Task: s.instanceTask(server, "backup", "/images/"+image.ID)}, nil
+	default:
+		return nil, invalidArgumentError("action", "constraint", fmt.Sprintf("action %s is not supported by the fake API", req.Action))
+	}
+	server.ModificationDate = s.timePtr()
+
+	return &instance.ServerActionResponse{Task: s.instanceTask(server, string(req.Action), "")}, nil
+}
+
+// instanceSetServerState sets the transient state of a server, and its final state once it has been read
+func (s *Server) instanceSetServerState(server *instance.Server, transientState instance.ServerState, transientDetail string, finalState instance.ServerState, finalDetail string) {
+	server.State = transientState
+	server.StateDetail = transientDetail
+	server.AllowedActions = instanceServerAllowedActions(transientState)
+	s.schedule(server.ID, func() {
+		server.State = finalState
+		server.StateDetail = finalDetail
+		server.AllowedActions = instanceServerAllowedActions(finalState)
+		if finalState == instance.ServerStateRunning {
+			server.Location = &instance.ServerLocation{ZoneID: string(server.Zone), PlatformID: "14", ClusterID: "1", HypervisorID: "101", NodeID: "1"}
+		} else {
+			server.Location = nil
+		}
+	})
+}
+
+func (s *Server) instanceTask(server *instance.Server, description string, hrefResult string) *instance.Task {
+	return &instance.Task{
+		ID:          newID(),
+		Description: description,
+		Progress:    0,
+		StartedAt:   s.timePtr(),
+		Status:      instance.TaskStatusPending,
+		HrefFrom:    "/servers/" + server.ID + "/action",
+		HrefResult:  hrefResult,
+		Zone:        server.Zone,
+	}
+}
+
+// instanceBackupServer creates an image with a snapshot of each volume of a server
+func (s *Server) instanceBackupServer(server *instance.Server, name string) (*instance.Image, error) {
+	image := &instance.Image{
+		ID:               newID(),
+		Name:             name,
+		Arch:             server.Arch,
+		CreationDate:     s.timePtr(),
+		ModificationDate: s.timePtr(),
+		ExtraVolumes:     map[string]*instance.Volume{},
+		FromServer:       server.ID,
+		Organization:     server.Organization,
+		Project:          server.Project,
+		Tags:             []string{},
+		State:            instance.ImageStateCreating,
+		Zone:             server.Zone,
+	}
+
+	for _, index := range sortedKeys(server.Volumes) {
+		volume := server.Volumes[index]
+		snapshot := s.instanceNewSnapshot(server.Zone, server.Project, name+"-"+index, instance.VolumeVolumeType(volume.VolumeType), volume.Size, volume.ID)
+		if index == "0" {
+			image.RootVolume = &instance.VolumeSummary{ID: snapshot.ID, Name: snapshot.Name, Size: snapshot.Size, VolumeType: instance.VolumeVolumeType(snapshot.VolumeType)}
+		} else {
+			image.ExtraVolumes[index] = &instance.Volume{ID: snapshot.ID, Name: snapshot.Name, Size: snapshot.Size, VolumeType: instance.VolumeVolumeType(snapshot.VolumeType), Zone: server.Zone}
+		}
+	}
+
+	s.instance.images.add(image.ID, image)
+	s.schedule(image.ID, func() {
+		image.State = instance.ImageStateAvailable
+	})
+
+	return image, nil
+}
+
+func (s *Server) instanceAttachVolume(r *http.Request) (interface{}, error) {
+	server, err := s.instanceServerIdle(r)
+	if err != nil {
+		return nil, err
+	}
+
+	req := &instance.AttachServerVolumeRequest{}
+	if err := decode(r, req); err != nil {
+		return nil, err
+	}
+
+	volume, exists := s.instance.volumes.get(req.VolumeID)
+	if !exists {
+		return nil, notFoundError("instance_volume", req.VolumeID)
+	}
+	if volume.Server != nil {
+		return nil, preconditionFailedError("volume_not_attached", fmt.Sprintf("volume %s is already attached to a server", volume.ID))
+	}
+
+	index := 0
+	for {
+		if _, used := server.Volumes[strconv.Itoa(index)]; !used {
+			break
+		}
+		index++
+	}
+	volume.Server = &instance.ServerSummary{ID: server.ID, Name: server.Name}
+	server.Volumes[strconv.Itoa(index)] = instanceVolumeServer(volume, req.Boot != nil && *req.Boot)
+
+	return &instance.AttachServerVolumeResponse{Server: server}, nil
+}
+
+func (s *Server) instanceDetachVolume(r *http.Request) (interface{}, error) {
+	server, err := s.instanceServerIdle(r)
+	if err != nil {
+		return nil, err
+	}
+
+	req := &instance.DetachServerVolumeRequest{}
+	if err := decode(r, req); err != nil {
+		return nil, err
+	}
+
+	for index, volume := range server.Volumes {
+		if volume.ID == req.VolumeID {
+			if volume.VolumeType == instance.VolumeServerVolumeTypeLSSD && server.State != instance.ServerStateStopped {
+				return nil, preconditionFailedError("server_stopped", "server must be stopped to detach a local volume")
+			}
+			delete(server.Volumes, index)
+			if existingVolume, exists := s.instance.volumes.get(volume.ID); exists {
+				existingVolume.Server = nil
+			}
+			return &instance.DetachServerVolumeResponse{Server: server}, nil
+		}
+	}
+
+	return nil, notFoundError("instance_volume", req.VolumeID)
+}
+
+////
+// User data
+////
+
+func (s *Server) instanceListUserData(r *http.Request) (interface{}, error) {
+	server, err := s.instanceServer(r)
+	if err != nil {
+		return nil, err
+	}
+
+	return &instance.ListServerUserDataResponse{UserData: sortedKeys(s.instance.userData[server.ID])}, nil
+}
+
+// instanceGetUserData returns the raw content of a user data, it is not encoded in JSON
+func (s *Server) instanceGetUserData(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	server, err := s.instanceServer(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	content, exists := s.instance.userData[server.ID][r.PathValue("key")]
+	if !exists {
+		writeError(w, notFoundError("instance_user_data", r.PathValue("key")))
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/plain")
+	_, _ = w.Write(content)
+}
+
+func (s *Server) instanceSetUserData(r *http.Request) (interface{}, error) {
+	server, err := s.instanceServer(r)
+	if err != nil {
+		return nil, err
+	}
+
+	content, err := io.ReadAll(r.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if s.instance.userData[server.ID] == nil {
+		s.instance.userData[server.ID] = map[string][]byte{}
+	}
+	s.instance.userData[server.ID][r.PathValue("key")] = content
+
+	return nil, nil
+}
+
+func (s *Server) instanceDeleteUserData(r *http.Request) (interface{}, error) {
+	server, err := s.instanceServer(r)
+	if err != nil {
+		return nil, err
+	}
+
+	delete(s.instance.userData[server.ID], r.PathValue("key"))
+
+	return nil, nil
+}
+
+////
+// Private NICs
+////
+
+func (s *Server) instanceListPrivateNICs(r *http.Request) (interface{}, error) {
+	server, err := s.instanceServer(r)
+	if err != nil {
+		return nil, err
+	}
+
+	f := newFilter(r)
+	nics := s.instance.privateNICs.list(func(nic *instance.PrivateNIC) bool {
+		return nic.ServerID == server.ID && f.matchTags(nic.Tags)
+	})
+	for _, nic := range nics {
+		s.settle(nic.ID)
+	}
+
+	return paginate(r, "private_nics", nics), nil
+}
+
+func (s *Server) instanceCreatePrivateNIC(r *http.Request) (interface{}, error) {
+	server, err := s.instanceServer(r)
+	if err != nil {
+		return nil, err
+	}
+
+	req := &instance.CreatePrivateNICRequest{}
+	if err := decode(r, req); err != nil {
+		return nil, err
+	}
+
+	region, _ := server.Zone.Region()
+	privateNetwork, exists := s.vpc.privateNetworks.get(req.PrivateNetworkID)
+	if !exists || privateNetwork.Region != region {
+		return nil, notFoundError("private_network", req.PrivateNetworkID)
+	}
+	for _, nic := range server.PrivateNics {
+		if nic.PrivateNetworkID == req.PrivateNetworkID {
+			return nil, &Error{
+				StatusCode: http.StatusConflict,
+				Type:       "conflict",
+				Message:    fmt.Sprintf("server %s is already attached to private network %s", server.ID, req.PrivateNetworkID),
+			}
+		}
+	}
+
+	nic := &instance.PrivateNIC{
+		ID:               newID(),
+		ServerID:         server.ID,
+		PrivateNetworkID: req.PrivateNetworkID,
+		MacAddress:       newMACAddress(),
+		State:            instance.PrivateNICStateSyncing,
+		Tags:             req.Tags,
+	}
+	if nic.Tags == nil {
+		nic.Tags = []string{}
+	}
+
+	resource := &ipamResource{
+		Type:       "instance_private_nic",
+		ID:         nic.ID,
+		MacAddress: nic.MacAddress,
+		Name:       server.Name,
+	}
+	if len(req.IPIDs) > 0 {
+		for _, ipID := range req.IPIDs {
+			if err := s.ipamAttachIP(ipID, resource); err != nil {
+				return nil, err
+			}
+		}
+	} else if _, err := s.ipamBookIP(privateNetwork, server.Project, resource, false); err != nil {
+		return nil, err
+	}
+
+	s.instance.privateNICs.add(nic.ID, nic)
+	server.PrivateNics = append(server.PrivateNics, nic)
+	s.schedule(nic.ID, func() {
+		nic.State = instance.PrivateNICStateAvailable
+	})
+
+	return &instance.CreatePrivateNICResponse{PrivateNic: nic}, nil
+}
+
+func (s *Server) instancePrivateNIC(r *http.Request) (*instance.PrivateNIC, error) {
+	server, err := s.instanceServer(r)
+	if err != nil {
+		return nil, err
+	}
+
+	id := r.PathValue("private_nic_id")
+	nic, exists := s.instance.privateNICs.get(id)
+	if !exists || nic.ServerID != server.ID {
+		return nil, notFoundError("instance_private_nic", id)
+	}
+
+	return nic, nil
+}
+
+func (s *Server) instanceGetPrivateNIC(r *http.Request) (interface{}, error) {
+	nic, err := s.instancePrivateNIC(r)
+	if err != nil {
+		return nil, err
+	}
+	s.settle(nic.ID)
+
+	return &instance.GetPrivateNICResponse{PrivateNic: nic}, nil
+}
+
+func (s *Server) instanceUpdatePrivateNIC(r *http.Request) (interface{}, error) {
+	nic, err := s.instancePrivateNIC(r)
+	if err != nil {
+		return nil, err
+	}
+
+	req := &instance.UpdatePrivateNICRequest{}
+	if err := decode(r, req); err != nil {
+		return nil, err
+	}
+	if req.Tags != nil {
+		nic.Tags = *req.Tags
+	}
+
+	return nic, nil
+}
+
+func (s *Server) instanceDeletePrivateNIC(r *http.Request) (interface{}, error) {
+	nic, err := s.instancePrivateNIC(r)
+	if err != nil {
+		return nil, err
+	}
+	if s.isTransient(nic.ID) {
+		return nil, transientStateError("instance_private_nic", nic.ID, string(nic.State))
+	}
+
+	s.instanceRemovePrivateNIC(nic)
+
+	return nil, nil
+}
+
+// instanceRemovePrivateNIC deletes a private NIC and releases its IPAM IPs
+func (s *Server) instanceRemovePrivateNIC(nic *instance.PrivateNIC) {
+	s.ipamReleaseResourceIPs(nic.ID)
+	s.instance.privateNICs.delete(nic.ID)
+	delete(s.transitions, nic.ID)
+
+	if server, exists := s.instance.servers.get(nic.ServerID); exists {
+		for i, serverNIC := range server.PrivateNics {
+			if serverNIC.ID == nic.ID {
+				server.PrivateNics = append(server.PrivateNics[:i], server.PrivateNics[i+1:]...)
+				break
+			}
+		}
+	}
+}
+
+// newMACAddress returns a random MAC address in the range of Scaleway private NICs
+func newMACAddress() string {
+	id := newID()
+	return fmt.Sprintf("02:00:00:%s:%s:%s", id[0:2], id[2:4], id[4:6])
+}
+
+////
+// IPs
+////
+
+func (s *Server) instanceListIPs(r *http.Request) (interface{}, error) {
+	f := newFilter(r)
+	ips := s.instance.ips.list(func(ip *instance.IP) bool {
+		return ip.Zone == zone(r) &&
+			f.matchProject(ip.Project, ip.Organization) &&
+			f.matchTags(ip.Tags) &&
+			f.matchString(string(ip.Type), "type")
+	})
+
+	return paginate(r, "ips", ips), nil
+}
+
+// instanceNewIP allocates a public IP, addresses are allocated sequentially
+func (s *Server) instanceNewIP(zone scw.Zone, projectID string, ipType instance.IPType) *instance.IP {
+	s.instance.lastIP++
+	ip := &instance.IP{
+		ID:           newID(),
+		Organization: DefaultOrganizationID,
+		Project:      projectID,
+		Tags:         []string{},
+		Type:         ipType,
+		State:        instance.IPStateDetached,
+		Zone:         zone,
+	}
+
+	if ipType == instance.IPTypeRoutedIPv6 {
+		_, prefix, _ := net.ParseCIDR(fmt.Sprintf("2001:bc8:1640:%x::/64", s.instance.lastIP))
+		ip.Prefix = scw.IPNet{IPNet: *prefix}
+		ip.Address = prefix.IP
+	} else {
+		ip.Address = net.IPv4(51, 15, byte(s.instance.lastIP/250), byte(s.instance.lastIP%250+1)).To4()
+		ip.Prefix = scw.IPNet{IPNet: net.IPNet{IP: ip.Address, Mask: net.CIDRMask(32, 32)}}
+	}
+	s.instance.ips.add(ip.ID, ip)
+
+	return ip
+}
+
+func (s *Server) instanceCreateIP(r *http.Request) (interface{}, error) {
+	req := &instance.CreateIPRequest{}
+	if err := decode(r, req); err != nil {
+		return nil, err
+	}
+
+	ipType := req.Type
+	if ipType == "" || ipType == instance.IPTypeUnknownIptype {
+		ipType = instance.IPTypeRoutedIPv4
+	}
+
+	ip := s.instanceNewIP(zone(r), projectOrDefault(req.Project, req.Organization), ipType)
+	if req.Tags != nil {
+		ip.Tags = req.Tags
+	}
+
+	if req.Server != nil {
+		server, exists := s.instance.servers.get(*req.Server)
+		if !exists {
+			return nil, notFoundError("instance_server", *req.Server)
+		}
+		s.instanceAttachIP(ip, server)
+	}
+
+	return &instance.CreateIPResponse{IP: ip}, nil
+}
+
+// instanceIP returns the IP of a request, identified by its ID or its address
+func (s *Server) instanceIP(r *http.Request) (*instance.IP, error) {
+	id := r.PathValue("ip")
+	if ip, exists := s.instance.ips.get(id); exists && ip.Zone == zone(r) {
+		return ip, nil
+	}
+
+	for _, ip := range s.instance.ips.list(nil) {
+		if ip.Zone == zone(r) && ip.Address.String() == id {
+			return ip, nil
+		}
+	}
+
+	return nil, notFoundError("instance_ip", id)
+}
+
+func (s *Server) instanceGetIP(r *http.Request) (interface{}, error) {
+	ip, err := s.instanceIP(r)
+	if err != nil {
+		return nil, err
+	}
+
+	return &instance.GetIPResponse{IP: ip}, nil
+}
+
+func (s *Server) instanceUpdateIP(r *http.Request) (interface{}, error) {
+	ip, err := s.instanceIP(r)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		return nil, err
+	}
+	req := &instance.UpdateIPRequest{}
+	fields := map[string]json.RawMessage{}
+	if err := json.Unmarshal(body, req); err != nil {
+		return nil, invalidArgumentError("body", "constraint", err.Error())
+	}
+	_ = json.Unmarshal(body, &fields)
+
+	if req.Tags != nil {
+		ip.Tags = *req.Tags
+	}
+	if req.Type != "" {
+		ip.Type = req.Type
+	}
+	if _, exists := fields["reverse"]; exists {
+		if req.Reverse == nil || req.Reverse.Null {
+			ip.Reverse = nil
+		} else {
+			ip.Reverse = scw.StringPtr(req.Reverse.Value)
+		}
+	}
+	if _, exists := fields["server"]; exists {
+		s.instanceDetachIP(ip)
+		if req.Server != nil && !req.Server.Null {
+			server, exists := s.instance.servers.get(req.Server.Value)
+			if !exists {
+				return nil, notFoundError("instance_server", req.Server.Value)
+			}
+			s.instanceAttachIP(ip, server)
+		}
+	}
+
+	return &instance.UpdateIPResponse{IP: ip}, nil
+}
+
+func (s *Server) instanceDeleteIP(r *http.Request) (interface{}, error) {
+	ip, err := s.instanceIP(r)
+	if err != nil {
+		return nil, err
+	}
+
+	s.instanceDetachIP(ip)
+	s.instance.ips.delete(ip.ID)
+
+	return nil, nil
+}
+
+// instanceAttachIP attaches an IP to a server, the first IPv4 of a server is its public IP
+func (s *Server) instanceAttachIP(ip *instance.IP, server *instance.Server) {
+	s.instanceDetachIP(ip)
+
+	family := instance.ServerIPIPFamilyInet
+	if ip.Type == instance.IPTypeRoutedIPv6 {
+		family = instance.ServerIPIPFamilyInet6
+	}
+	serverIP := &instance.ServerIP{
+		ID:               ip.ID,
+		Address:          ip.Address,
+		Gateway:          net.IPv4(62, 210, 0, 1).To4(),
+		Netmask:          "32",
+		Family:           family,
+		ProvisioningMode: instance.ServerIPProvisioningModeDHCP,
+		Tags:             ip.Tags,
+		State:            instance.ServerIPStateAttached,
+	}
+	if family == instance.ServerIPIPFamilyInet6 {
+		serverIP.Netmask = "64"
+		serverIP.Gateway = nil
+		serverIP.ProvisioningMode = instance.ServerIPProvisioningModeSlaac
+	}
+
+	server.PublicIPs = append(server.PublicIPs, serverIP)
+	if server.PublicIP == nil && family == instance.ServerIPIPFamilyInet {
+		server.PublicIP = serverIP
+	}
+	ip.Server = &instance.ServerSummary{ID: server.ID, Name: server.Name}
+	ip.State = instance.IPStateAttached
+}
+
+// instanceDetachIP detaches an IP from its server, if any
+func (s *Server) instanceDetachIP(ip *instance.IP) {
+	if ip.Server == nil {
+		return
+	}
+
+	if server, exists := s.instance.servers.get(ip.Server.ID); exists {
+		for i, serverIP := range server.PublicIPs {
+			if serverIP.ID == ip.ID {
+				server.PublicIPs = append(server.PublicIPs[:i], server.PublicIPs[i+1:]...)
+				break
+			}
+		}
+		if server.PublicIP != nil && server.PublicIP.ID == ip.ID {
+			server.PublicIP = nil
+			for _, serverIP := range server.PublicIPs {
+				if serverIP.Family == instance.ServerIPIPFamilyInet {
+					server.PublicIP = serverIP
+					break
+				}
+			}
+		}
+	}
+	ip.Server = nil
+	ip.State = instance.IPStateDetached
+}
+
+// instanceReleaseDynamicIP deletes the dynamic IP of a stopped server
+func (s *Server) instanceReleaseDynamicIP(server *instance.Server) {
+	for _, serverIP := range server.PublicIPs {
+		if !serverIP.Dynamic {
+			continue
+		}
+		if ip, exists := s.instance.ips.get(serverIP.ID); exists {
+			s.instanceDetachIP(ip)
+			s.instance.ips.delete(ip.ID)
+		}
+		return
+	}
+}
+
+////
+// Volumes
+////
+
+func instanceVolumeServer(volume *instance.Volume, boot bool) *instance.VolumeServer {
+	return &instance.VolumeServer{
+		ID:               volume.ID,
+		Name:             volume.Name,
+		Organization:     volume.Organization,
+		Server:           volume.Server,
+		Size:             volume.Size,
+		VolumeType:       instance.VolumeServerVolumeType(volume.VolumeType),
+		CreationDate:     volume.CreationDate,
+		ModificationDate: volume.ModificationDate,
+		State:            instance.VolumeServerState(volume.State),
+		Project:          volume.Project,
+		Boot:             boot,
+		Zone:             volume.Zone,
+	}
+}
+
+func (s *Server) instanceNewVolume(zone scw.Zone, projectID, name string, volumeType instance.VolumeVolumeType, size scw.Size) *instance.Volume {
+	volume := &instance.Volume{
+		ID:               newID(),
+		Name:             name,
+		Size:             size,
+		VolumeType:       volumeType,
+		CreationDate:     s.timePtr(),
+		ModificationDate: s.timePtr(),
+		Organization:     DefaultOrganizationID,
+		Project:          projectID,
+		Tags:             []string{},
+		State:            instance.VolumeStateAvailable,
+		Zone:             zone,
+	}
+	s.instance.volumes.add(volume.ID, volume)
+
+	return volume
+}
+
+func (s *Server) instanceVolume(r *http.Request) (*instance.Volume, error) {
+	id := r.PathValue("volume_id")
+	volume, exists := s.instance.volumes.get(id)
+	if !exists || volume.Zone != zone(r) {
+		return nil, notFoundError("instance_volume", id)
+	}
+
+	return volume, nil
+}
+
+func (s *Server) instanceListVolumes(r *http.Request) (interface{}, error) {
+	f := newFilter(r)
+	volumes := s.instance.volumes.list(func(volume *instance.Volume) bool {
+		return volume.Zone == zone(r) &&
+			f.matchName(volume.Name) &&
+			f.matchProject(volume.Project, volume.Organization) &&
+			f.matchTags(volume.Tags) &&
+			f.matchString(string(volume.VolumeType), "volume_type")
+	})
+	for _, volume := range volumes {
+		s.settle(volume.ID)
+	}
+
+	return paginate(r, "volumes", volumes), nil
+}
+
+func (s *Server) instanceCreateVolume(r *http.Request) (interface{}, error) {
+	req := &instance.CreateVolumeRequest{}
+	if err := decode(r, req); err != nil {
+		return nil, err
+	}
+
+	size := scw.Size(0)
+	if req.BaseSnapshot != nil {
+		snapshot, exists := s.instance.snapshots.get(*req.BaseSnapshot)
+		if !exists {
+			return nil, notFoundError("instance_snapshot", *req.BaseSnapshot)
+		}
+		size = snapshot.Size
+	} else if req.Size == nil {
+		return nil, invalidArgumentError("size", "required", "size is required when creating a volume without base snapshot")
+	}
+	if req.Size != nil {
+		size = *req.Size
+	}
+
+	volumeType := req.VolumeType
+	if volumeType == "" {
+		volumeType = instance.VolumeVolumeTypeLSSD
+	}
+
+	volume := s.instanceNewVolume(zone(r), projectOrDefault(req.Project, req.Organization), req.Name, volumeType, size)
+	if req.Tags != nil {
+		volume.Tags = req.Tags
+	}
+	if volumeType == instance.VolumeVolumeTypeBSSD {
+		volume.State = instance.VolumeStateFetching
+		s.schedule(volume.ID, func() {
+			volume.State = instance.VolumeStateAvailable
+		})
+	}
+
+	return &instance.CreateVolumeResponse{Volume: volume}, nil
+}
+
+func (s *Server) instanceGetVolume(r *http.Request) (interface{}, error) {
+	volume, err := s.instanceVolume(r)
+	if err != nil {
+		return nil, err
+	}
+	s.settle(volume.ID)
+
+	return &instance.GetVolumeResponse{Volume: volume}, nil
+}
+
+func (s *Server) instanceUpdateVolume(r *http.Request) (interface{}, error) {
+	volume, err := s.instanceVolume(r)
+	if err != nil {
+		return nil, err
+	}
+	if s.isTransient(volume.ID) {
+		return nil, transientStateError("instance_volume", volume.ID, string(volume.State))
+	}
+
+	req := &instance.UpdateVolumeRequest{}
+	if err := decode(r, req); err != nil {
+		return nil, err
+	}
+
+	if req.Name != nil {
+		volume.Name = *req.Name
+	}
+	if req.Tags != nil {
+		volume.Tags = *req.Tags
+	}
+	if req.Size != nil && *req.Size != volume.Size {
+		if volume.VolumeType != instance.VolumeVolumeTypeBSSD {
+			return nil, preconditionFailedError("volume_type", "only b_ssd volumes can be resized")
+		}
+		if *req.Size < volume.Size {
+			return nil, invalidArgumentError("size", "constraint", "volumes cannot be shrunk")
+		}
+		volume.Size = *req.Size
+		volume.State = instance.VolumeStateResizing
+		s.schedule(volume.ID, func() {
+			volume.State = instance.VolumeStateAvailable
+		})
+		s.instanceSyncServerVolumeSize(volume.ID, volume.Size)
+	}
+	volume.ModificationDate = s.timePtr()
+
+	return &instance.UpdateVolumeResponse{Volume: volume}, nil
+}
+
+func (s *Server) instanceDeleteVolume(r *http.Request) (interface{}, error) {
+	volume, err := s.instanceVolume(r)
+	if err != nil {
+		return nil, err
+	}
+	if volume.Server != nil {
+		return nil, preconditionFailedError("volume_not_attached", fmt.Sprintf("volume is attached to server %s", volume.Server.ID))
+	}
+	if s.isTransient(volume.ID) {
+		return nil, transientStateError("instance_volume", volume.ID, string(volume.State))
+	}
+
+	s.instance.volumes.delete(volume.ID)
+
+	return nil, nil
+}
+
+// instanceSyncServerVolumeSize reports the new size of a volume in the server it is attached to
+func (s *Server) instanceSyncServerVolumeSize(volumeID string, size scw.Size) {
+	for _, server := range s.instance.servers.list(nil) {
+		for _, volume := range server.Volumes {
+			if volume.ID == volumeID {
+				volume.Size = size
+			}
+		}
+	}
+}
+
+func instanceBlockVolumeServer(volume *block.Volume, boot bool) *instance.VolumeServer {
+	return &instance.VolumeServer{
+		ID:               volume.ID,
+		Name:             volume.Name,
+		Size:             volume.Size,
+		VolumeType:       instance.VolumeServerVolumeType("sbs_volume"),
+		CreationDate:     volume.CreatedAt,
+		ModificationDate: volume.UpdatedAt,
+		State:            instance.VolumeServerStateAvailable,
+		Project:          volume.ProjectID,
+		Boot:             boot,
+		Zone:             volume.Zone,
+	}
+}
+
+// instanceBlockMigrationKey returns the validation key of the migration plan of a volume
+func instanceBlockMigrationKey(volume *instance.Volume) string {
+	return fmt.Sprintf("%s-%d", volume.ID, volume.ModificationDate.Unix())
+}
+
+func (s *Server) instanceBlockMigrationVolume(req *instance.PlanBlockMigrationRequest) (*instance.Volume, error) {
+	if req.VolumeID == nil {
+		return nil, invalidArgumentError("volume_id", "required", "only volumes can be migrated by the fake API")
+	}
+	volume, exists := s.instance.volumes.get(*req.VolumeID)
+	if !exists {
+		return nil, notFoundError("instance_volume", *req.VolumeID)
+	}
+	if volume.VolumeType != instance.VolumeVolumeTypeBSSD {
+		return nil, preconditionFailedError("volume_type", "only b_ssd volumes can be migrated to block storage")
+	}
+	if s.isTransient(volume.ID) {
+		return nil, transientStateError("instance_volume", volume.ID, string(volume.State))
+	}
+
+	return volume, nil
+}
+
+func (s *Server) instancePlanBlockMigration(r *http.Request) (interface{}, error) {
+	req := &instance.PlanBlockMigrationRequest{}
+	if err := decode(r, req); err != nil {
+		return nil, err
+	}
+
+	volume, err := s.instanceBlockMigrationVolume(req)
+	if err != nil {
+		return nil, err
+	}
+
+	return &instance.MigrationPlan{
+		Volume:        volume,
+		Snapshots:     []*instance.Snapshot{},
+		ValidationKey: instanceBlockMigrationKey(volume),
+	}, nil
+}
+
+func (s *Server) instanceApplyBlockMigration(r *http.Request) (interface{}, error) {
+	req := &instance.ApplyBlockMigrationRequest{}
+	if err := decode(r, req); err != nil {
+		return nil, err
+	}
+
+	volume, err := s.instanceBlockMigrationVolume(&instance.PlanBlockMigrationRequest{VolumeID: req.VolumeID, SnapshotID: req.SnapshotID})
+	if err != nil {
+		return nil, err
+	}
+	if req.ValidationKey != instanceBlockMigrationKey(volume) {
+		return nil, invalidArgumentError("validation_key", "constraint", "the validation key does not match the current migration plan")
+	}
+
+	// The volume keeps its ID once migrated, it is only known by the block API
+	s.instance.volumes.delete(volume.ID)
+	blockVolume := s.blockNewVolumeFromInstance(volume)
+	if volume.Server != nil {
+		if server, exists := s.instance.servers.get(volume.Server.ID); exists {
+			for index, serverVolume := range server.Volumes {
+				if serverVolume.ID == volume.ID {
+					server.Volumes[index] = instanceBlockVolumeServer(blockVolume, serverVolume.Boot)
+				}
+			}
+		}
+	}
+
+	return nil, nil
+}
+
+////
+// Snapshots
+////
+
+func (s *Server) instanceNewSnapshot(zone scw.Zone, projectID, name string, volumeType instance.VolumeVolumeType, size scw.Size, volumeID string) *instance.Snapshot {
+	snapshot := &instance.Snapshot{
+		ID:               newID(),
+		Name:             name,
+		Organization:     DefaultOrganizationID,
+		Project:          projectID,
+		Tags:             []string{},
+		VolumeType:       volumeType,
+		Size:             size,
+		State:            instance.SnapshotStateSnapshotting,
+		CreationDate:     s.timePtr(),
+		ModificationDate: s.timePtr(),
+		Zone:             zone,
+	}
+	if volumeID != "" {
+		snapshot.BaseVolume = &instance.SnapshotBaseVolume{ID: volumeID, Name: name}
+	}
+	s.instance.snapshots.add(snapshot.ID, snapshot)
+	s.schedule(snapshot.ID, func() {
+		snapshot.State = instance.SnapshotStateAvailable
+	})
+
+	return snapshot
+}
+
+func (s *Server) instanceSnapshot(r *http.Request) (*instance.Snapshot, error) {
+	id := r.PathValue("snapshot_id")
+	snapshot, exists := s.instance.snapshots.get(id)
+	if !exists || snapshot.Zone != zone(r) {
+		return nil, notFoundError("instance_snapshot", id)
+	}
+
+	return snapshot, nil
+}
+
+func (s *Server) instanceListSnapshots(r *http.Request) (interface{}, error) {
+	f := newFilter(r)
+	snapshots := s.instance.snapshots.list(func(snapshot *instance.Snapshot) bool {
+		return snapshot.Zone == zone(r) &&
+			f.matchName(snapshot.Name) &&
+			f.matchProject(snapshot.Project, snapshot.Organization) &&
+			f.matchTags(snapshot.Tags)
+	})
+	for _, snapshot := range snapshots {
+		s.settle(snapshot.ID)
+	}
+
+	return paginate(r, "snapshots", snapshots), nil
+}
+
+func (s *Server) instanceCreateSnapshot(r *http.Request) (interface{}, error) {
+	req := &instance.CreateSnapshotRequest{}
+	if err := decode(r, req); err != nil {
+		return nil, err
+	}
+
+	// The imported object is not read, the snapshot is created with a fixed size
+	if req.Bucket != nil && req.Key != nil {
+		volumeType := instance.VolumeVolumeType(req.VolumeType)
+		if volumeType == "" {
+			volumeType = instance.VolumeVolumeType(instance.SnapshotVolumeTypeUnified)
+		}
+		snapshot := s.instanceNewSnapshot(zone(r), projectOrDefault(req.Project, req.Organization), req.Name, volumeType, instanceImportedSnapshotSize, "")
+		snapshot.State = instance.SnapshotStateImporting
+		if req.Tags != nil {
+			snapshot.Tags = *req.Tags
+		}
+		return &instance.CreateSnapshotResponse{Snapshot: snapshot}, nil
+	}
+	if req.VolumeID == nil {
+		return nil, invalidArgumentError("volume_id", "required", "snapshots can only be created from a volume or imported from an object")
+	}
+	volume, exists := s.instance.volumes.get(*req.VolumeID)
+	if !exists {
+		return nil, notFoundError("instance_volume", *req.VolumeID)
+	}
+
+	snapshot := s.instanceNewSnapshot(zone(r), projectOrDefault(req.Project, req.Organization), req.Name, volume.VolumeType, volume.Size, volume.ID)
+	if req.Tags != nil {
+		snapshot.Tags = *req.Tags
+	}
+
+	return &instance.CreateSnapshotResponse{Snapshot: snapshot}, nil
+}
+
+func (s *Server) instanceGetSnapshot(r *http.Request) (interface{}, error) {
+	snapshot, err := s.instanceSnapshot(r)
+	if err != nil {
+		return nil, err
+	}
+	s.settle(snapshot.ID)
+
+	return &instance.GetSnapshotResponse{Snapshot: snapshot}, nil
+}
+
+func (s *Server) instanceUpdateSnapshot(r *http.Request) (interface{}, error) {
+	snapshot, err := s.instanceSnapshot(r)
+	if err != nil {
+		return nil, err
+	}
+
+	req := &instance.UpdateSnapshotRequest{}
+	if err := decode(r, req); err != nil {
+		return nil, err
+	}
+	if req.Name != nil {
+		snapshot.Name = *req.Name
+	}
+	if req.Tags != nil {
+		snapshot.Tags = *req.Tags
+	}
+	snapshot.ModificationDate = s.timePtr()
+
+	return &instance.UpdateSnapshotResponse{Snapshot: snapshot}, nil
+}
+
+func (s *Server) instanceDeleteSnapshot(r *http.Request) (interface{}, error) {
+	snapshot, err := s.instanceSnapshot(r)
+	if err != nil {
+		return nil, err
+	}
+	if s.isTransient(snapshot.ID) {
+		return nil, transientStateError("instance_snapshot", snapshot.ID, string(snapshot.State))
+	}
+
+	s.instance.snapshots.delete(snapshot.ID)
+
+	return nil, nil
+}
+
+func (s *Server) instanceExportSnapshot(r *http.Request) (interface{}, error) {
+	snapshot, err := s.instanceSnapshot(r)
+	if err != nil {
+		return nil, err
+	}
+	if s.isTransient(snapshot.ID) {
+		return nil, transientStateError("instance_snapshot", snapshot.ID, string(snapshot.State))
+	}
+
+	req := &instance.ExportSnapshotRequest{}
+	if err := decode(r, req); err != nil {
+		return nil, err
+	}
+	if req.Bucket == "" || req.Key == "" {
+		return nil, invalidArgumentError("bucket", "required", "bucket and key are required to export a snapshot")
+	}
+
+	s.instance.snapshotExports[snapshot.ID] = append(s.instance.snapshotExports[snapshot.ID], req.Bucket+"/"+req.Key)
+	snapshot.State = instance.SnapshotStateExporting
+	s.schedule(snapshot.ID, func() {
+		snapshot.State = instance.SnapshotStateAvailable
+	})
+
+	return &instance.ExportSnapshotResponse{Task: &instance.Task{
+		ID:          newID(),
+		Description: "export_snapshot",
+		StartedAt:   s.timePtr(),
+		Status:      instance.TaskStatusPending,
+		HrefFrom:    "/snapshots/" + snapshot.ID + "/export",
+		HrefResult:  "/snapshots/" + snapshot.ID,
+		Zone:        snapshot.Zone,
+	}}, nil
+}
+
+// SnapshotExports returns the objects a snapshot has been exported to, as bucket/key, in the order of the exports
+func (s *Server) SnapshotExports(snapshotID string) []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]string{}, s.instance.snapshotExports[snapshotID]...)
+}
+
+////
+// Images
+////
+
+func (s *Server) instanceImage(r *http.Request) (*instance.Image, error) {
+	id := r.PathValue("image_id")
+	image, exists := s.instance.images.get(id)
+	if !exists || image.Zone != zone(r) {
+		return nil, notFoundError("instance_image", id)
+	}
+
+	return image, nil
+}
+
+func (s *Server) instanceListImages(r *http.Request) (interface{}, error) {
+	f := newFilter(r)
+	images := s.instance.images.list(func(image *instance.Image) bool {
+		return image.Zone == zone(r) &&
+			f.matchName(image.Name) &&
+			f.matchProject(image.Project, image.Organization) &&
+			f.matchTags(image.Tags) &&
+			f.matchString(string(image.Arch), "arch") &&
+			f.matchString(strconv.FormatBool(image.Public), "public")
+	})
+	for _, image := range images {
+		s.settle(image.ID)
+	}
+
+	return paginate(r, "images", images), nil
+}
+
+func (s *Server) instanceGetImage(r *http.Request) (interface{}, error) {
+	image, err := s.instanceImage(r)
+	if err != nil {
+		return nil, err
+	}
+	s.settle(image.ID)
+
+	return &instance.GetImageResponse{Image: image}, nil
+}
+
+func (s *Server) instanceCreateImage(r *http.Request) (interface{}, error) {
+	req := &instance.CreateImageRequest{}
+	if err := decode(r, req); err != nil {
+		return nil, err
+	}
+
+	rootSnapshot, exists := s.instance.snapshots.get(req.RootVolume)
+	if !exists {
+		return nil, notFoundError("instance_snapshot", req.RootVolume)
+	}
+	if s.isTransient(rootSnapshot.ID) {
+		return nil, transientStateError("instance_snapshot", rootSnapshot.ID, string(rootSnapshot.State))
+	}
+
+	arch := req.Arch
+	if arch == "" {
+		arch = instance.ArchX86_64
+	}
+	image := &instance.Image{
+		ID:               newID(),
+		Name:             req.Name,
+		Arch:             arch,
+		CreationDate:     s.timePtr(),
+		ModificationDate: s.timePtr(),
+		ExtraVolumes:     map[string]*instance.Volume{},
+		Organization:     DefaultOrganizationID,
+		Project:          projectOrDefault(req.Project, req.Organization),
+		Public:           req.Public != nil && *req.Public,
+		RootVolume:       &instance.VolumeSummary{ID: rootSnapshot.ID, Name: rootSnapshot.Name, Size: rootSnapshot.Size, VolumeType: rootSnapshot.VolumeType},
+		Tags:             req.Tags,
+		State:            instance.ImageStateCreating,
+		Zone:             zone(r),
+	}
+	if image.Tags == nil {
+		image.Tags = []string{}
+	}
+	for index, template := range req.ExtraVolumes {
+		snapshot, exists := s.instance.snapshots.get(template.ID)
+		if !exists {
+			return nil, notFoundError("instance_snapshot", template.ID)
+		}
+		image.ExtraVolumes[index] = &instance.Volume{ID: snapshot.ID, Name: snapshot.Name, Size: snapshot.Size, VolumeType: snapshot.VolumeType, Zone: snapshot.Zone}
+	}
+
+	s.instance.images.add(image.ID, image)
+	s.schedule(image.ID, func() {
+		image.State = instance.ImageStateAvailable
+	})
+
+	return &instance.CreateImageResponse{Image: image}, nil
+}
+
+func (s *Server) instanceDeleteImage(r *http.Request) (interface{}, error) {
+	image, err := s.instanceImage(r)
+	if err != nil {
+		return nil, err
+	}
+	if image.Public {
+		return nil, &Error{StatusCode: http.StatusForbidden, Type: "permissions_denied", Message: "public images cannot be deleted"}
+	}
+
+	s.instance.images.delete(image.ID)
+
+	return nil, nil
+}
+
+////
+// Security groups
+////
+
+func (s *Server) instanceNewSecurityGroup(zone scw.Zone, projectID, name string) *instance.SecurityGroup {
+	securityGroup := &instance.SecurityGroup{
+		ID:                    newID(),
+		Name:                  name,
+		Description:           "",
+		EnableDefaultSecurity: true,
+		InboundDefaultPolicy:  instance.SecurityGroupPolicyAccept,
+		OutboundDefaultPolicy: instance.SecurityGroupPolicyAccept,
+		Organization:          DefaultOrganizationID,
+		Project:               projectID,
+		Tags:                  []string{},
+		OrganizationDefault:   scw.BoolPtr(false),
+		CreationDate:          s.timePtr(),
+		ModificationDate:      s.timePtr(),
+		Servers:               []*instance.ServerSummary{},
+		Stateful:              true,
+		State:                 instance.SecurityGroupStateAvailable,
+		Zone:                  zone,
+	}
+	s.instance.securityGroups.add(securityGroup.ID, securityGroup)
+	s.instance.securityGroupRules[securityGroup.ID] = []*instance.SecurityGroupRule{}
+
+	return securityGroup
+}
+
+// instanceDefaultSecurityGroupRules returns the rules blocking SMTP, added to security groups with default security enabled
+func instanceDefaultSecurityGroupRules(zone scw.Zone) []*instance.SecurityGroupRule {
+	rules := []*instance.SecurityGroupRule{}
+	for _, ipRange := range []string{"0.0.0.0/0", "::/0"} {
+		for _, port := range []uint32{25, 465, 587} {
+			_, ipNet, _ := net.ParseCIDR(ipRange)
+			rules = append(rules, &instance.SecurityGroupRule{
+				ID:           newID(),
+				Protocol:     instance.SecurityGroupRuleProtocolTCP,
+				Direction:    instance.SecurityGroupRuleDirectionOutbound,
+				Action:       instance.SecurityGroupRuleActionDrop,
+				IPRange:      scw.IPNet{IPNet: *ipNet},
+				DestPortFrom: scw.Uint32Ptr(port),
+				Position:     uint32(len(rules) + 1),
+				Editable:     false,
+				Zone:         zone,
+			})
+		}
+	}
+
+	return rules
+}
+
+func (s *Server) instanceSecurityGroup(r *http.Request) (*instance.SecurityGroup, error) {
+	id := r.PathValue("security_group_id")
+	securityGroup, exists := s.instance.securityGroups.get(id)
+	if !exists || securityGroup.Zone != zone(r) {
+		return nil, notFoundError("instance_security_group", id)
+	}
+
+	return securityGroup, nil
+}
+
+func (s *Server) instanceListSecurityGroups(r *http.Request) (interface{}, error) {
+	f := newFilter(r)
+	securityGroups := s.instance.securityGroups.list(func(securityGroup *instance.SecurityGroup) bool {
+		return securityGroup.Zone == zone(r) &&
+			f.matchName(securityGroup.Name) &&
+			f.matchProject(securityGroup.Project, securityGroup.Organization) &&
+			f.matchTags(securityGroup.Tags) &&
+			f.matchString(strconv.FormatBool(securityGroup.ProjectDefault), "project_default")
+	})
+
+	return paginate(r, "security_groups", securityGroups), nil
+}
+
+func (s *Server) instanceCreateSecurityGroup(r *http.Request) (interface{}, error) {
+	req := &instance.CreateSecurityGroupRequest{}
+	if err := decode(r, req); err != nil {
+		return nil, err
+	}
+
+	securityGroup := s.instanceNewSecurityGroup(zone(r), projectOrDefault(req.Project, req.Organization), req.Name)
+	securityGroup.Description = req.Description
+	securityGroup.Stateful = req.Stateful
+	if req.Tags != nil {
+		securityGroup.Tags = req.Tags
+	}
+	if req.InboundDefaultPolicy != "" {
+		securityGroup.InboundDefaultPolicy = req.InboundDefaultPolicy
+	}
+	if req.OutboundDefaultPolicy != "" {
+		securityGroup.OutboundDefaultPolicy = req.OutboundDefaultPolicy
+	}
+	if req.EnableDefaultSecurity != nil {
+		securityGroup.EnableDefaultSecurity = *req.EnableDefaultSecurity
+	}
+	if req.ProjectDefault != nil {
+		securityGroup.ProjectDefault = *req.ProjectDefault
+	}
+
+	return &instance.CreateSecurityGroupResponse{SecurityGroup: securityGroup}, nil
+}
+
+func (s *Server) instanceGetSecurityGroup(r *http.Request) (interface{}, error) {
+	securityGroup, err := s.instanceSecurityGroup(r)
+	if err != nil {
+		return nil, err
+	}
+
+	return &instance.GetSecurityGroupResponse{SecurityGroup: securityGroup}, nil
+}
+
+func (s *Server) instanceUpdateSecurityGroup(r *http.Request) (interface{}, error) {
+	securityGroup, err := s.instanceSecurityGroup(r)
+	if err != nil {
+		return nil, err
+	}
+
+	req := &instance.UpdateSecurityGroupRequest{}
+	if err := decode(r, req); err != nil {
+		return nil, err
+	}
+
+	if req.Name != nil {
+		securityGroup.Name = *req.Name
+	}
+	if req.Description != nil {
+		securityGroup.Description = *req.Description
+	}
+	if req.EnableDefaultSecurity != nil {
+		securityGroup.EnableDefaultSecurity = *req.EnableDefaultSecurity
+	}
+	if req.InboundDefaultPolicy != "" {
+		securityGroup.InboundDefaultPolicy = req.InboundDefaultPolicy
+	}
+	if req.OutboundDefaultPolicy != "" {
+		securityGroup.OutboundDefaultPolicy = req.OutboundDefaultPolicy
+	}
+	if req.Tags != nil {
+		securityGroup.Tags = *req.Tags
+	}
+	if req.ProjectDefault != nil {
+		securityGroup.ProjectDefault = *req.ProjectDefault
+	}
+	if req.Stateful != nil {
+		securityGroup.Stateful = *req.Stateful
+	}
+	securityGroup.ModificationDate = s.timePtr()
+
+	return &instance.UpdateSecurityGroupResponse{SecurityGroup: securityGroup}, nil
+}
+
+func (s *Server) instanceDeleteSecurityGroup(r *http.Request) (interface{}, error) {
+	securityGroup, err := s.instanceSecurityGroup(r)
+	if err != nil {
+		return nil, err
+	}
+	if len(securityGroup.Servers) > 0 {
+		return nil, preconditionFailedError("security_group_unused", "group is in use, you cannot delete it")
+	}
+
+	s.instance.securityGroups.delete(securityGroup.ID)
+	delete(s.instance.securityGroupRules, securityGroup.ID)
+
+	return nil, nil
+}
+
+// instanceDetachSecurityGroup removes a server from its security group
+func (s *Server) instanceDetachSecurityGroup(server *instance.Server) {
+	if server.SecurityGroup == nil {
+		return
+	}
+
+	if securityGroup, exists := s.instance.securityGroups.get(server.SecurityGroup.ID); exists {
+		for i, summary := range securityGroup.Servers {
+			if summary.ID == server.ID {
+				securityGroup.Servers = append(securityGroup.Servers[:i], securityGroup.Servers[i+1:]...)
+				break
+			}
+		}
+	}
+	server.SecurityGroup = nil
+}
+
+// instanceSecurityGroupRules returns the rules of a security group, including the default ones
+func (s *Server) instanceSecurityGroupRules(securityGroup *instance.SecurityGroup) []*instance.SecurityGroupRule {
+	rules := []*instance.SecurityGroupRule{}
+	if securityGroup.EnableDefaultSecurity {
+		rules = append(rules, instanceDefaultSecurityGroupRules(securityGroup.Zone)...)
+	}
+
+	return append(rules, s.instance.securityGroupRules[securityGroup.ID]...)
+}
+
+func (s *Server) instanceListSecurityGroupRules(r *http.Request) (interface{}, error) {
+	securityGroup, err := s.instanceSecurityGroup(r)
+	if err != nil {
+		return nil, err
+	}
+
+	return paginate(r, "rules", s.instanceSecurityGroupRules(securityGroup)), nil
+}
+
+func (s *Server) instanceCreateSecurityGroupRule(r *http.Request) (interface{}, error) {
+	securityGroup, err := s.instanceSecurityGroup(r)
+	if err != nil {
+		return nil, err
+	}
+
+	req := &instance.CreateSecurityGroupRuleRequest{}
+	if err := decode(r, req); err != nil {
+		return nil, err
+	}
+
+	rules := s.instance.securityGroupRules[securityGroup.ID]
+	rule := &instance.SecurityGroupRule{
+		ID:           newID(),
+		Protocol:     req.Protocol,
+		Direction:    req.Direction,
+		Action:       req.Action,
+		IPRange:      req.IPRange,
+		DestPortFrom: req.DestPortFrom,
+		DestPortTo:   req.DestPortTo,
+		Editable:     true,
+		Zone:         securityGroup.Zone,
+	}
+	s.instance.securityGroupRules[securityGroup.ID] = instanceMoveSecurityGroupRule(append(rules, rule), len(rules), req.Position)
+
+	return &instance.CreateSecurityGroupRuleResponse{Rule: rule}, nil
+}
+
+func (s *Server) instanceSetSecurityGroupRules(r *http.Request) (interface{}, error) {
+	securityGroup, err := s.instanceSecurityGroup(r)
+	if err != nil {
+		return nil, err
+	}
+
+	req := &instance.SetSecurityGroupRulesRequest{}
+	if err := decode(r, req); err != nil {
+		return nil, err
+	}
+
+	rules := make([]*instance.SecurityGroupRule, 0, len(req.Rules))
+	for i, requestRule := range req.Rules {
+		if requestRule.Editable != nil && !*requestRule.Editable {
+			continue
+		}
+		id := newID()
+		if requestRule.ID != nil && *requestRule.ID != "" {
+			id = *requestRule.ID
+		}
+		rules = append(rules, &instance.SecurityGroupRule{
+			ID:           id,
+			Protocol:     requestRule.Protocol,
+			Direction:    requestRule.Direction,
+			Action:       requestRule.Action,
+			IPRange:      requestRule.IPRange,
+			DestPortFrom: requestRule.DestPortFrom,
+			DestPortTo:   requestRule.DestPortTo,
+			Position:     uint32(i + 1),
+			Editable:     true,
+			Zone:         securityGroup.Zone,
+		})
+	}
+	s.instance.securityGroupRules[securityGroup.ID] = rules
+	securityGroup.ModificationDate = s.timePtr()
+
+	return &instance.SetSecurityGroupRulesResponse{Rules: s.instanceSecurityGroupRules(securityGroup)}, nil
+}
+
+func (s *Server) instanceSecurityGroupRule(r *http.Request) (*instance.SecurityGroup, int, error) {
+	securityGroup, err := s.instanceSecurityGroup(r)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	id := r.PathValue("rule_id")
+	for i, rule := range s.instance.securityGroupRules[securityGroup.ID] {
+		if rule.ID == id {
+			return securityGroup, i, nil
+		}
+	}
+
+	return nil, 0, notFoundError("instance_security_group_rule", id)
+}
+
+func (s *Server) instanceGetSecurityGroupRule(r *http.Request) (interface{}, error) {
+	securityGroup, index, err := s.instanceSecurityGroupRule(r)
+	if err != nil {
+		return nil, err
+	}
+
+	return &instance.GetSecurityGroupRuleResponse{Rule: s.instance.securityGroupRules[securityGroup.ID][index]}, nil
+}
+
+func (s *Server) instanceUpdateSecurityGroupRule(r *http.Request) (interface{}, error) {
+	securityGroup, index, err := s.instanceSecurityGroupRule(r)
+	if err != nil {
+		return nil, err
+	}
+
+	req := &instance.UpdateSecurityGroupRuleRequest{}
+	if err := decode(r, req); err != nil {
+		return nil, err
+	}
+
+	rule := s.instance.securityGroupRules[securityGroup.ID][index]
+	if req.Protocol != "" {
+		rule.Protocol = req.Protocol
+	}
+	if req.Direction != "" {
+		rule.Direction = req.Direction
+	}
+	if req.Action != "" {
+		rule.Action = req.Action
+	}
+	if req.IPRange != nil {
+		rule.IPRange = *req.IPRange
+	}
+	if req.DestPortFrom != nil {
+		rule.DestPortFrom = req.DestPortFrom
+	}
+	if req.DestPortTo != nil {
+		rule.DestPortTo = req.DestPortTo
+	}
+	if req.Position != nil {
+		s.instance.securityGroupRules[securityGroup.ID] = instanceMoveSecurityGroupRule(s.instance.securityGroupRules[securityGroup.ID], index, *req.Position)
+	}
+
+	return &instance.UpdateSecurityGroupRuleResponse{Rule: rule}, nil
+}
+
+func (s *Server) instanceDeleteSecurityGroupRule(r *http.Request) (interface{}, error) {
+	securityGroup, index, err := s.instanceSecurityGroupRule(r)
+	if err != nil {
+		return nil, err
+	}
+
+	rules := s.instance.securityGroupRules[securityGroup.ID]
+	rules = append(rules[:index], rules[index+1:]...)
+	for i, rule := range rules {
+		rule.Position = uint32(i + 1)
+	}
+	s.instance.securityGroupRules[securityGroup.ID] = rules
+
+	return nil, nil
+}
+
+// instanceMoveSecurityGroupRule moves the rule at index to a position, starting at 1, and renumbers the rules.
+// The rule stays at its index if the position is not set or out of range.
+func instanceMoveSecurityGroupRule(rules []*instance.SecurityGroupRule, index int, position uint32) []*instance.SecurityGroupRule {
+	if position > 0 && int(position) <= len(rules) {
+		rule := rules[index]
+		rules = append(rules[:index], rules[index+1:]...)
+		rules = append(rules[:position-1], append([]*instance.SecurityGroupRule{rule}, rules[position-1:]...)...)
+	}
+	for i, rule := range rules {
+		rule.Position = uint32(i + 1)
+	}
+
+	return rules
+}
+
+// instancePublicImage returns a public image of the catalog, used by marketplace local images
+func instancePublicImage(id, label string, zone scw.Zone, arch instance.Arch, creationDate *time.Time) *instance.Image {
+	return &instance.Image{
+		ID:               id,
+		Name:             strings.ReplaceAll(label, "_", " "),
+		Arch:             arch,
+		CreationDate:     creationDate,
+		ModificationDate: creationDate,
+		ExtraVolumes:     map[string]*instance.Volume{},
+		Organization:     "51b656e3-4865-41e8-adbc-0c45bdd780db",
+		Project:          "51b656e3-4865-41e8-adbc-0c45bdd780db",
+		Public:           true,
+		RootVolume: &instance.VolumeSummary{
+			ID:         id,
+			Name:       label,
+			Size:       instanceDefaultVolumeSize,
+			VolumeType: instance.VolumeVolumeTypeLSSD,
+		},
+		State: instance.ImageStateAvailable,
+		Tags:  []string{},
+		Zone:  zone,
+	}
+}
@@ -0,0 +1,306 @@
+package fakeapi
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"strconv"
+
+	ipam "github.com/scaleway/scaleway-sdk-go/api/ipam/v1"
+	vpc "github.com/scaleway/scaleway-sdk-go/api/vpc/v2"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+)
+
+const ipamPrefix = "/ipam/v1/regions/{region}"
+
+type ipamStore struct {
+	ips *collection[ipam.IP]
+	// autoBooked are the IDs of the IPs booked by other products, they are released with their resource
+	autoBooked map[string]bool
+}
+
+// ipamResource is a resource of another product an IP is attached to
+type ipamResource struct {
+	Type       ipam.ResourceType
+	ID         string
+	MacAddress string
+	Name       string
+}
+
+func (s *Server) registerIpam() {
+	s.ipam = &ipamStore{
+		ips:        newCollection[ipam.IP](),
+		autoBooked: map[string]bool{},
+	}
+
+	s.handle("GET "+ipamPrefix+"/ips", s.ipamListIPs)
+	s.handle("POST "+ipamPrefix+"/ips", s.ipamCreateIP)
+	s.handle("GET "+ipamPrefix+"/ips/{ip_id}", s.ipamGetIP)
+	s.handle("PATCH "+ipamPrefix+"/ips/{ip_id}", s.ipamUpdateIP)
+	s.handle("DELETE "+ipamPrefix+"/ips/{ip_id}", s.ipamDeleteIP)
+}
+
+// ipamBookIP books the first free IP of a private network, attached to resource if not nil
+func (s *Server) ipamBookIP(privateNetwork *vpc.PrivateNetwork, projectID string, resource *ipamResource, isIPv6 bool) (*ipam.IP, error) {
+	for _, subnet := range privateNetwork.Subnets {
+		if (subnet.Subnet.IP.To4() == nil) != isIPv6 {
+			continue
+		}
+
+		address, err := s.ipamFreeAddress(privateNetwork.ID, subnet.Subnet.IPNet)
+		if err != nil {
+			return nil, err
+		}
+
+		ip := s.ipamNewIP(privateNetwork, subnet, projectID, address)
+		if resource != nil {
+			ip.Resource = resource.toIPAM()
+			s.ipam.autoBooked[ip.ID] = true
+		}
+
+		return ip, nil
+	}
+
+	return nil, preconditionFailedError("subnet_available", fmt.Sprintf("private network %s has no subnet for this IP family", privateNetwork.ID))
+}
+
+func (s *Server) ipamNewIP(privateNetwork *vpc.PrivateNetwork, subnet *vpc.Subnet, projectID string, address net.IP) *ipam.IP {
+	ip := &ipam.IP{
+		ID:        newID(),
+		Address:   scw.IPNet{IPNet: net.IPNet{IP: address, Mask: subnet.Subnet.Mask}},
+		ProjectID: projectID,
+		IsIPv6:    address.To4() == nil,
+		CreatedAt: s.timePtr(),
+		UpdatedAt: s.timePtr(),
+		Source: &ipam.Source{
+			PrivateNetworkID: scw.StringPtr(privateNetwork.ID),
+			SubnetID:         scw.StringPtr(subnet.ID),
+		},
+		Tags:   []string{},
+		Region: privateNetwork.Region,
+	}
+	s.ipam.ips.add(ip.ID, ip)
+
+	return ip
+}
+
+// ipamFreeAddress returns the first address of a subnet that is not booked, the first ones are reserved for the gateway
+func (s *Server) ipamFreeAddress(privateNetworkID string, subnet net.IPNet) (net.IP, error) {
+	used := map[string]bool{}
+	for _, ip := range s.ipamPrivateNetworkIPs(privateNetworkID) {
+		used[ip.Address.IP.String()] = true
+	}
+
+	address := make(net.IP, len(subnet.IP))
+	copy(address, subnet.IP)
+	for i := 0; i < 2; i++ {
+		address = nextIP(address)
+	}
+	for ; subnet.Contains(address); address = nextIP(address) {
+		if !used[address.String()] {
+			return address, nil
+		}
+	}
+
+	return nil, &Error{
+		StatusCode: http.StatusConflict,
+		Type:       "out_of_stock",
+		Message:    fmt.Sprintf("subnet %s is full", subnet.String()),
+	}
+}
+
+func nextIP(ip net.IP) net.IP {
+	next := make(net.IP, len(ip))
+	copy(next, ip)
+	for i := len(next) - 1; i >= 0; i-- {
+		next[i]++
+		if next[i] != 0 {
+			break
+		}
+	}
+
+	return next
+}
+
+func (r *ipamResource) toIPAM() *ipam.Resource {
+	resource := &ipam.Resource{
+		Type: r.Type,
+		ID:   r.ID,
+	}
+	if r.MacAddress != "" {
+		resource.MacAddress = scw.StringPtr(r.MacAddress)
+	}
+	if r.Name != "" {
+		resource.Name = scw.StringPtr(r.Name)
+	}
+
+	return resource
+}
+
+// ipamAttachIP attaches a booked IP to a resource of another product
+func (s *Server) ipamAttachIP(ipID string, resource *ipamResource) error {
+	ip, exists := s.ipam.ips.get(ipID)
+	if !exists {
+		return notFoundError("ip", ipID)
+	}
+	if ip.Resource != nil {
+		return preconditionFailedError("ip_not_attached", fmt.Sprintf("ip %s is already attached to %s %s", ip.ID, ip.Resource.Type, ip.Resource.ID))
+	}
+
+	ip.Resource = resource.toIPAM()
+	ip.UpdatedAt = s.timePtr()
+
+	return nil
+}
+
+// ipamReleaseResourceIPs detaches the IPs of a deleted resource, the ones booked with it are deleted
+func (s *Server) ipamReleaseResourceIPs(resourceID string) {
+	for _, ip := range s.ipam.ips.list(nil) {
+		if ip.Resource == nil || ip.Resource.ID != resourceID {
+			continue
+		}
+
+		if s.ipam.autoBooked[ip.ID] {
+			delete(s.ipam.autoBooked, ip.ID)
+			s.ipam.ips.delete(ip.ID)
+			continue
+		}
+		ip.Resource = nil
+		ip.UpdatedAt = s.timePtr()
+	}
+}
+
+func (s *Server) ipamPrivateNetworkIPs(privateNetworkID string) []*ipam.IP {
+	return s.ipam.ips.list(func(ip *ipam.IP) bool {
+		return ip.Source != nil && ip.Source.PrivateNetworkID != nil && *ip.Source.PrivateNetworkID == privateNetworkID
+	})
+}
+
+// ipamHasPrivateNetworkIPs returns true if IPs are still booked in a private network
+func (s *Server) ipamHasPrivateNetworkIPs(privateNetworkID string) bool {
+	return len(s.ipamPrivateNetworkIPs(privateNetworkID)) > 0
+}
+
+func (s *Server) ipamIP(r *http.Request) (*ipam.IP, error) {
+	id := r.PathValue("ip_id")
+	ip, exists := s.ipam.ips.get(id)
+	if !exists || ip.Region != region(r) {
+		return nil, notFoundError("ip", id)
+	}
+
+	return ip, nil
+}
+
+func (s *Server) ipamListIPs(r *http.Request) (interface{}, error) {
+	f := newFilter(r)
+	ips := s.ipam.ips.list(func(ip *ipam.IP) bool {
+		resourceID, resourceType, macAddress, resourceName := "", "", "", ""
+		if ip.Resource != nil {
+			resourceID, resourceType = ip.Resource.ID, string(ip.Resource.Type)
+			if ip.Resource.MacAddress != nil {
+				macAddress = *ip.Resource.MacAddress
+			}
+			if ip.Resource.Name != nil {
+				resourceName = *ip.Resource.Name
+			}
+		}
+		privateNetworkID := ""
+		if ip.Source != nil && ip.Source.PrivateNetworkID != nil {
+			privateNetworkID = *ip.Source.PrivateNetworkID
+		}
+
+		return ip.Region == region(r) &&
+			f.matchString(ip.ProjectID, "project_id") &&
+			f.matchTags(ip.Tags) &&
+			f.matchString(privateNetworkID, "private_network_id") &&
+			f.matchString(resourceID, "resource_id") &&
+			f.matchString(resourceType, "resource_type") &&
+			f.matchString(macAddress, "mac_address") &&
+			f.matchString(resourceName, "resource_name") &&
+			f.matchString(strconv.FormatBool(ip.Resource != nil), "attached") &&
+			f.matchString(strconv.FormatBool(ip.IsIPv6), "is_ipv6")
+	})
+
+	return paginate(r, "ips", ips), nil
+}
+
+func (s *Server) ipamCreateIP(r *http.Request) (interface{}, error) {
+	req := &ipam.BookIPRequest{}
+	if err := decode(r, req); err != nil {
+		return nil, err
+	}
+
+	if req.Source == nil || req.Source.PrivateNetworkID == nil {
+		return nil, invalidArgumentError("source.private_network_id", "required", "only IPs of private networks can be booked with the fake API")
+	}
+	privateNetwork, exists := s.vpc.privateNetworks.get(*req.Source.PrivateNetworkID)
+	if !exists || privateNetwork.Region != region(r) {
+		return nil, notFoundError("private_network", *req.Source.PrivateNetworkID)
+	}
+
+	projectID := projectOrDefault(&req.ProjectID)
+	var ip *ipam.IP
+	if req.Address != nil {
+		for _, subnet := range privateNetwork.Subnets {
+			if !subnet.Subnet.Contains(*req.Address) {
+				continue
+			}
+			for _, existingIP := range s.ipamPrivateNetworkIPs(privateNetwork.ID) {
+				if existingIP.Address.IP.Equal(*req.Address) {
+					return nil, &Error{StatusCode: http.StatusConflict, Type: "conflict", Message: fmt.Sprintf("ip %s is already booked", req.Address.String())}
+				}
+			}
+			ip = s.ipamNewIP(privateNetwork, subnet, projectID, *req.Address)
+		}
+		if ip == nil {
+			return nil, invalidArgumentError("address", "constraint", "address is not in a subnet of the private network")
+		}
+	} else {
+		bookedIP, err := s.ipamBookIP(privateNetwork, projectID, nil, req.IsIPv6)
+		if err != nil {
+			return nil, err
+		}
+		ip = bookedIP
+	}
+	if req.Tags != nil {
+		ip.Tags = req.Tags
+	}
+
+	return ip, nil
+}
+
+func (s *Server) ipamGetIP(r *http.Request) (interface{}, error) {
+	return s.ipamIP(r)
+}
+
+func (s *Server) ipamUpdateIP(r *http.Request) (interface{}, error) {
+	ip, err := s.ipamIP(r)
+	if err != nil {
+		return nil, err
+	}
+
+	req := &ipam.UpdateIPRequest{}
+	if err := decode(r, req); err != nil {
+		return nil, err
+	}
+	if req.Tags != nil {
+		ip.Tags = *req.Tags
+	}
+	ip.UpdatedAt = s.timePtr()
+
+	return ip, nil
+}
+
+func (s *Server) ipamDeleteIP(r *http.Request) (interface{}, error) {
+	ip, err := s.ipamIP(r)
+	if err != nil {
+		return nil, err
+	}
+	if ip.Resource != nil {
+		return nil, preconditionFailedError("ip_not_attached", fmt.Sprintf("ip is attached to %s %s", ip.Resource.Type, ip.Resource.ID))
+	}
+
+	s.ipam.ips.delete(ip.ID)
+
+	return nil, nil
+}
@@ -0,0 +1,1287 @@
+package fakeapi
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+
+	"github.com/scaleway/scaleway-sdk-go/api/k8s/v1"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+)
+
+const k8sPrefix = "/k8s/v1/regions/{region}"
+
+type k8sStore struct {
+	versions []*k8s.Version
+	clusters *collection[k8s.Cluster]
+	pools    *collection[k8s.Pool]
+	nodes    *collection[k8s.Node]
+	// aclRules are the rules allowing access to the control plane of each cluster, keyed by cluster ID
+	aclRules map[string][]*k8sACLRule
+	// pods are the pods running in the clusters, keyed by namespace and name
+	pods *collection[k8sPod]
+	// unschedulable are the IDs of the cordoned nodes
+	unschedulable map[string]bool
+	// evictedPods are the namespaced names of the pods evicted from the nodes of each cluster, keyed by cluster ID
+	evictedPods map[string][]string
+	// poolNodeConfigs are the labels and taints of the nodes of each pool, keyed by pool ID
+	poolNodeConfigs map[string]*k8sPoolNodeConfig
+}
+
+// k8sACLRule is an ACL rule of the control plane of a cluster, it is not part of the vendored SDK yet
+type k8sACLRule struct {
+	ID             string     `json:"id"`
+	IP             *scw.IPNet `json:"ip,omitempty"`
+	ScalewayRanges *bool      `json:"scaleway_ranges,omitempty"`
+	Description    string     `json:"description"`
+}
+
+func (s *Server) registerK8S() {
+	s.k8s = &k8sStore{
+		versions: []*k8s.Version{
+			k8sVersion("1.31.2"),
+			k8sVersion("1.30.6"),
+			k8sVersion("1.29.10"),
+		},
+		clusters:        newCollection[k8s.Cluster](),
+		pools:           newCollection[k8s.Pool](),
+		nodes:           newCollection[k8s.Node](),
+		aclRules:        map[string][]*k8sACLRule{},
+		pods:            newCollection[k8sPod](),
+		unschedulable:   map[string]bool{},
+		evictedPods:     map[string][]string{},
+		poolNodeConfigs: map[string]*k8sPoolNodeConfig{},
+	}
+
+	s.handle("GET "+k8sPrefix+"/versions", s.k8sListVersions)
+	s.handle("GET "+k8sPrefix+"/versions/{version_name}", s.k8sGetVersion)
+
+	s.handle("GET "+k8sPrefix+"/clusters", s.k8sListClusters)
+	s.handle("POST "+k8sPrefix+"/clusters", s.k8sCreateCluster)
+	s.handle("GET "+k8sPrefix+"/clusters/{cluster_id}", s.k8sGetCluster)
+	s.handle("PATCH "+k8sPrefix+"/clusters/{cluster_id}", s.k8sUpdateCluster)
+	s.handle("DELETE "+k8sPrefix+"/clusters/{cluster_id}", s.k8sDeleteCluster)
+	s.handle("GET "+k8sPrefix+"/clusters/{cluster_id}/available-types", s.k8sListClusterAvailableTypes)
+	s.handle("GET "+k8sPrefix+"/clusters/{cluster_id}/kubeconfig", s.k8sGetClusterKubeConfig)
+
+	s.handle("GET "+k8sPrefix+"/clusters/{cluster_id}/acls", s.k8sListClusterACLRules)
+	s.handle("POST "+k8sPrefix+"/clusters/{cluster_id}/acls", s.k8sAddClusterACLRules)
+	s.handle("PUT "+k8sPrefix+"/clusters/{cluster_id}/acls", s.k8sSetClusterACLRules)
+	s.handle("DELETE "+k8sPrefix+"/acls/{acl_id}", s.k8sDeleteACLRule)
+
+	s.handle("GET "+k8sPrefix+"/clusters/{cluster_id}/pools", s.k8sListPools)
+	s.handle("POST "+k8sPrefix+"/clusters/{cluster_id}/pools", s.k8sCreatePool)
+	s.handle("GET "+k8sPrefix+"/pools/{pool_id}", s.k8sGetPool)
+	s.handle("PATCH "+k8sPrefix+"/pools/{pool_id}", s.k8sUpdatePool)
+	s.handle("DELETE "+k8sPrefix+"/pools/{pool_id}", s.k8sDeletePool)
+	s.handle("PUT "+k8sPrefix+"/pools/{pool_id}/set-labels", s.k8sSetPoolLabels)
+	s.handle("PUT "+k8sPrefix+"/pools/{pool_id}/set-taints", s.k8sSetPoolTaints)
+	s.handle("PUT "+k8sPrefix+"/pools/{pool_id}/set-startup-taints", s.k8sSetPoolStartupTaints)
+
+	s.handle("GET "+k8sPrefix+"/clusters/{cluster_id}/nodes", s.k8sListNodes)
+	s.handle("GET "+k8sPrefix+"/nodes/{node_id}", s.k8sGetNode)
+
+	// The Kubernetes API served by the control plane of the clusters, on their cluster URL
+	s.handle("PATCH /api/v1/nodes/{node_name}", s.k8sKubePatchNode)
+	s.handle("GET /api/v1/pods", s.k8sKubeListPods)
+	s.handle("POST /api/v1/namespaces/{namespace}/pods/{pod_name}/eviction", s.k8sKubeEvictPod)
+}
+
+func k8sVersion(name string) *k8s.Version {
+	return &k8s.Version{
+		Name:                       name,
+		Label:                      "Kubernetes " + name,
+		AvailableCnis:              []k8s.CNI{k8s.CNICilium, k8s.CNICalico, k8s.CNIKilo, k8s.CNI("none")},
+		AvailableContainerRuntimes: []k8s.Runtime{k8s.RuntimeContainerd},
+		AvailableFeatureGates:      []string{"HPAScaleToZero", "InPlacePodVerticalScaling"},
+		AvailableAdmissionPlugins:  []string{"AlwaysPullImages", "PodNodeSelector", "PodTolerationRestriction"},
+		AvailableKubeletArgs:       map[string]string{"maxPods": "uint16", "containerLogMaxSize": "quantity"},
+	}
+}
+
+func (s *Server) k8sListVersions(r *http.Request) (interface{}, error) {
+	versions := make([]*k8s.Version, 0, len(s.k8s.versions))
+	for _, version := range s.k8s.versions {
+		regionVersion := *version
+		regionVersion.Region = region(r)
+		versions = append(versions, &regionVersion)
+	}
+
+	return &k8s.ListVersionsResponse{Versions: versions}, nil
+}
+
+func (s *Server) k8sGetVersion(r *http.Request) (interface{}, error) {
+	name := r.PathValue("version_name")
+	for _, version := range s.k8s.versions {
+		if version.Name == name {
+			regionVersion := *version
+			regionVersion.Region = region(r)
+			return &regionVersion, nil
+		}
+	}
+
+	return nil, notFoundError("version", name)
+}
+
+// k8sMatchStatus returns true if the status matches the status query parameter, the SDK sends the unknown status when it is not set
+func k8sMatchStatus(f filter, status string) bool {
+	expected := f.get("status")
+	return expected == "" || expected == "unknown" || expected == status
+}
+
+////
+// Clusters
+////
+
+func (s *Server) k8sCluster(r *http.Request) (*k8s.Cluster, error) {
+	id := r.PathValue("cluster_id")
+	cluster, exists := s.k8s.clusters.get(id)
+	if !exists || cluster.Region != region(r) {
+		return nil, notFoundError("cluster", id)
+	}
+
+	return cluster, nil
+}
+
+// k8sClusterIdle returns the cluster of a request, or an error if it is in a transient state
+func (s *Server) k8sClusterIdle(r *http.Request) (*k8s.Cluster, error) {
+	cluster, err := s.k8sCluster(r)
+	if err != nil {
+		return nil, err
+	}
+	if s.isTransient(cluster.ID) {
+		return nil, transientStateError("cluster", cluster.ID, cluster.Status.String())
+	}
+
+	return cluster, nil
+}
+
+// k8sClusterStatus returns the status of an idle cluster, clusters without pool wait for one
+func (s *Server) k8sClusterStatus(cluster *k8s.Cluster) k8s.ClusterStatus {
+	if len(s.k8sClusterPools(cluster.ID)) == 0 && cluster.Type != "multicloud" {
+		return k8s.ClusterStatusPoolRequired
+	}
+
+	return k8s.ClusterStatusReady
+}
+
+// k8sSetClusterStatus sets the transient status of a cluster, it becomes idle once read
+func (s *Server) k8sSetClusterStatus(cluster *k8s.Cluster, status k8s.ClusterStatus) {
+	cluster.Status = status
+	cluster.UpdatedAt = s.timePtr()
+	s.schedule(cluster.ID, func() {
+		cluster.Status = s.k8sClusterStatus(cluster)
+	})
+}
+
+func (s *Server) k8sClusterPools(clusterID string) []*k8s.Pool {
+	return s.k8s.pools.list(func(pool *k8s.Pool) bool {
+		return pool.ClusterID == clusterID
+	})
+}
+
+func (s *Server) k8sListClusters(r *http.Request) (interface{}, error) {
+	f := newFilter(r)
+	clusters := s.k8s.clusters.list(func(cluster *k8s.Cluster) bool {
+		return cluster.Region == region(r) &&
+			f.matchName(cluster.Name) &&
+			f.matchProject(cluster.ProjectID, cluster.OrganizationID) &&
+			k8sMatchStatus(f, cluster.Status.String()) &&
+			f.matchString(cluster.Type, "type") &&
+			f.matchString(stringOrDefault(cluster.PrivateNetworkID, ""), "private_network_id")
+	})
+	for _, cluster := range clusters {
+		s.settle(cluster.ID)
+	}
+
+	return paginate(r, "clusters", clusters), nil
+}
+
+func (s *Server) k8sCreateCluster(r *http.Request) (interface{}, error) {
+	req := &k8s.CreateClusterRequest{}
+	if err := decode(r, req); err != nil {
+		return nil, err
+	}
+
+	if req.Version == "" {
+		return nil, invalidArgumentError("version", "required", "version is required")
+	}
+	versionExists := false
+	for _, version := range s.k8s.versions {
+		versionExists = versionExists || version.Name == req.Version
+	}
+	if !versionExists {
+		return nil, invalidArgumentError("version", "constraint", fmt.Sprintf("version %s is not available", req.Version))
+	}
+
+	clusterType := req.Type
+	if clusterType == "" {
+		clusterType = "kapsule"
+	}
+	cni := req.Cni
+	if cni == "" || cni == k8s.CNIUnknownCni {
+		cni = k8s.CNICilium
+	}
+
+	id := newID()
+	cluster := &k8s.Cluster{
+		ID:                  id,
+		Type:                clusterType,
+		Name:                req.Name,
+		Status:              k8s.ClusterStatusCreating,
+		Version:             req.Version,
+		Region:              region(r),
+		OrganizationID:      DefaultOrganizationID,
+		ProjectID:           projectOrDefault(req.ProjectID, req.OrganizationID),
+		Tags:                req.Tags,
+		Cni:                 cni,
+		Description:         req.Description,
+		ClusterURL:          fmt.Sprintf("https://%s.api.k8s.%s.scw.cloud:6443", id, region(r)),
+		DNSWildcard:         fmt.Sprintf("*.%s.nodes.k8s.%s.scw.cloud", id, region(r)),
+		CreatedAt:           s.timePtr(),
+		UpdatedAt:           s.timePtr(),
+		AutoscalerConfig:    k8sClusterAutoscalerConfig(req.AutoscalerConfig),
+		AutoUpgrade:         &k8s.ClusterAutoUpgrade{MaintenanceWindow: &k8s.MaintenanceWindow{StartHour: 0, Day: k8s.MaintenanceWindowDayOfTheWeekAny}},
+		FeatureGates:        req.FeatureGates,
+		AdmissionPlugins:    req.AdmissionPlugins,
+		OpenIDConnectConfig: &k8s.ClusterOpenIDConnectConfig{GroupsClaim: []string{}, RequiredClaim: []string{}},
+		ApiserverCertSans:   req.ApiserverCertSans,
+		PrivateNetworkID:    req.PrivateNetworkID,
+	}
+	if cluster.Tags == nil {
+		cluster.Tags = []string{}
+	}
+	if cluster.FeatureGates == nil {
+		cluster.FeatureGates = []string{}
+	}
+	if cluster.AdmissionPlugins == nil {
+		cluster.AdmissionPlugins = []string{}
+	}
+	if cluster.ApiserverCertSans == nil {
+		cluster.ApiserverCertSans = []string{}
+	}
+	if req.AutoUpgrade != nil {
+		cluster.AutoUpgrade = &k8s.ClusterAutoUpgrade{
+			Enabled:           req.AutoUpgrade.Enable,
+			MaintenanceWindow: req.AutoUpgrade.MaintenanceWindow,
+		}
+	}
+	if oidc := req.OpenIDConnectConfig; oidc != nil {
+		cluster.OpenIDConnectConfig = &k8s.ClusterOpenIDConnectConfig{
+			IssuerURL:      oidc.IssuerURL,
+			ClientID:       oidc.ClientID,
+			UsernameClaim:  stringOrDefault(oidc.UsernameClaim, ""),
+			UsernamePrefix: stringOrDefault(oidc.UsernamePrefix, ""),
+			GroupsClaim:    stringsOrEmpty(oidc.GroupsClaim),
+			GroupsPrefix:   stringOrDefault(oidc.GroupsPrefix, ""),
+			RequiredClaim:  stringsOrEmpty(oidc.RequiredClaim),
+		}
+	}
+
+	s.k8s.clusters.add(cluster.ID, cluster)
+	_, allowAll, _ := net.ParseCIDR("0.0.0.0/0")
+	s.k8s.aclRules[cluster.ID] = []*k8sACLRule{{
+		ID:          newID(),
+		IP:          &scw.IPNet{IPNet: *allowAll},
+		Description: "Automatically generated ACL rule allowing all IPs",
+	}}
+	s.k8sSetClusterStatus(cluster, k8s.ClusterStatusCreating)
+
+	return cluster, nil
+}
+
+// k8sClusterAutoscalerConfig returns the autoscaler configuration of a new cluster, with the defaults of the API
+func k8sClusterAutoscalerConfig(req *k8s.CreateClusterRequestAutoscalerConfig) *k8s.ClusterAutoscalerConfig {
+	config := &k8s.ClusterAutoscalerConfig{
+		ScaleDownDelayAfterAdd:        "10m",
+		Estimator:                     k8s.AutoscalerEstimatorBinpacking,
+		Expander:                      k8s.AutoscalerExpanderRandom,
+		ExpendablePodsPriorityCutoff:  -10,
+		ScaleDownUnneededTime:         "10m",
+		ScaleDownUtilizationThreshold: 0.5,
+		MaxGracefulTerminationSec:     600,
+	}
+	if req == nil {
+		return config
+	}
+
+	k8sUpdateClusterAutoscalerConfig(config, &k8s.UpdateClusterRequestAutoscalerConfig{
+		ScaleDownDisabled:             req.ScaleDownDisabled,
+		ScaleDownDelayAfterAdd:        req.ScaleDownDelayAfterAdd,
+		Estimator:                     req.Estimator,
+		Expander:                      req.Expander,
+		IgnoreDaemonsetsUtilization:   req.IgnoreDaemonsetsUtilization,
+		BalanceSimilarNodeGroups:      req.BalanceSimilarNodeGroups,
+		ExpendablePodsPriorityCutoff:  req.ExpendablePodsPriorityCutoff,
+		ScaleDownUnneededTime:         req.ScaleDownUnneededTime,
+		ScaleDownUtilizationThreshold: req.ScaleDownUtilizationThreshold,
+		MaxGracefulTerminationSec:     req.MaxGracefulTerminationSec,
+	})
+
+	return config
+}
+
+func k8sUpdateClusterAutoscalerConfig(config *k8s.ClusterAutoscalerConfig, req *k8s.UpdateClusterRequestAutoscalerConfig) {
+	if req.ScaleDownDisabled != nil {
+		config.ScaleDownDisabled = *req.ScaleDownDisabled
+	}
+	if req.ScaleDownDelayAfterAdd != nil {
+		config.ScaleDownDelayAfterAdd = *req.ScaleDownDelayAfterAdd
+	}
+	if req.Estimator != "" && req.Estimator != k8s.AutoscalerEstimatorUnknownEstimator {
+		config.Estimator = req.Estimator
+	}
+	if req.Expander != "" && req.Expander != k8s.AutoscalerExpanderUnknownExpander {
+		config.Expander = req.Expander
+	}
+	if req.IgnoreDaemonsetsUtilization != nil {
+		config.IgnoreDaemonsetsUtilization = *req.IgnoreDaemonsetsUtilization
+	}
+	if req.BalanceSimilarNodeGroups != nil {
+		config.BalanceSimilarNodeGroups = *req.BalanceSimilarNodeGroups
+	}
+	if req.ExpendablePodsPriorityCutoff != nil {
+		config.ExpendablePodsPriorityCutoff = *req.ExpendablePodsPriorityCutoff
+	}
+	if req.ScaleDownUnneededTime != nil {
+		config.ScaleDownUnneededTime = *req.ScaleDownUnneededTime
+	}
+	if req.ScaleDownUtilizationThreshold != nil {
+		config.ScaleDownUtilizationThreshold = *req.ScaleDownUtilizationThreshold
+	}
+	if req.MaxGracefulTerminationSec != nil {
+		config.MaxGracefulTerminationSec = *req.MaxGracefulTerminationSec
+	}
+}
+
+func (s *Server) k8sGetCluster(r *http.Request) (interface{}, error) {
+	cluster, err := s.k8sCluster(r)
+	if err != nil {
+		return nil, err
+	}
+	s.settle(cluster.ID)
+
+	// The cluster may have been deleted when settled
+	return s.k8sCluster(r)
+}
+
+func (s *Server) k8sUpdateCluster(r *http.Request) (interface{}, error) {
+	cluster, err := s.k8sClusterIdle(r)
+	if err != nil {
+		return nil, err
+	}
+
+	req := &k8s.UpdateClusterRequest{}
+	if err := decode(r, req); err != nil {
+		return nil, err
+	}
+
+	if req.Name != nil {
+		cluster.Name = *req.Name
+	}
+	if req.Description != nil {
+		cluster.Description = *req.Description
+	}
+	if req.Tags != nil {
+		cluster.Tags = stringsOrEmpty(req.Tags)
+	}
+	if req.AutoscalerConfig != nil {
+		k8sUpdateClusterAutoscalerConfig(cluster.AutoscalerConfig, req.AutoscalerConfig)
+	}
+	if req.AutoUpgrade != nil {
+		if req.AutoUpgrade.Enable != nil {
+			cluster.AutoUpgrade.Enabled = *req.AutoUpgrade.Enable
+		}
+		if req.AutoUpgrade.MaintenanceWindow != nil {
+			cluster.AutoUpgrade.MaintenanceWindow = req.AutoUpgrade.MaintenanceWindow
+		}
+	}
+	if req.FeatureGates != nil {
+		cluster.FeatureGates = stringsOrEmpty(req.FeatureGates)
+	}
+	if req.AdmissionPlugins != nil {
+		cluster.AdmissionPlugins = stringsOrEmpty(req.AdmissionPlugins)
+	}
+	if req.ApiserverCertSans != nil {
+		cluster.ApiserverCertSans = stringsOrEmpty(req.ApiserverCertSans)
+	}
+	if oidc := req.OpenIDConnectConfig; oidc != nil {
+		config := cluster.OpenIDConnectConfig
+		config.IssuerURL = stringOrDefault(oidc.IssuerURL, config.IssuerURL)
+		config.ClientID = stringOrDefault(oidc.ClientID, config.ClientID)
+		config.UsernameClaim = stringOrDefault(oidc.UsernameClaim, config.UsernameClaim)
+		config.UsernamePrefix = stringOrDefault(oidc.UsernamePrefix, config.UsernamePrefix)
+		config.GroupsPrefix = stringOrDefault(oidc.GroupsPrefix, config.GroupsPrefix)
+		if oidc.GroupsClaim != nil {
+			config.GroupsClaim = stringsOrEmpty(oidc.GroupsClaim)
+		}
+		if oidc.RequiredClaim != nil {
+			config.RequiredClaim = stringsOrEmpty(oidc.RequiredClaim)
+		}
+	}
+	s.k8sSetClusterStatus(cluster, k8s.ClusterStatusUpdating)
+
+	return cluster, nil
+}
+
+func (s *Server) k8sDeleteCluster(r *http.Request) (interface{}, error) {
+	cluster, err := s.k8sClusterIdle(r)
+	if err != nil {
+		return nil, err
+	}
+
+	cluster.Status = k8s.ClusterStatusDeleting
+	s.schedule(cluster.ID, func() {
+		for _, pool := range s.k8sClusterPools(cluster.ID) {
+			s.k8sRemovePool(pool)
+		}
+		for _, pod := range s.k8sClusterPods(cluster.ID) {
+			s.k8s.pods.delete(pod.key())
+		}
+		s.k8s.clusters.delete(cluster.ID)
+		delete(s.k8s.aclRules, cluster.ID)
+		delete(s.k8s.evictedPods, cluster.ID)
+	})
+
+	return cluster, nil
+}
+
+func (s *Server) k8sListClusterAvailableTypes(r *http.Request) (interface{}, error) {
+	if _, err := s.k8sCluster(r); err != nil {
+		return nil, err
+	}
+
+	return &k8s.ListClusterAvailableTypesResponse{ClusterTypes: []*k8s.ClusterType{}}, nil
+}
+
+// k8sGetClusterKubeConfig returns the kubeconfig of the cluster as a file, its content is encoded in base64
+func (s *Server) k8sGetClusterKubeConfig(r *http.Request) (interface{}, error) {
+	cluster, err := s.k8sCluster(r)
+	if err != nil {
+		return nil, err
+	}
+
+	kubeconfig := fmt.Sprintf(`apiVersion: v1
+kind: Config
+clusters:
+- name: %[1]s
+  cluster:
+    certificate-authority-data: ZmFrZS1jZXJ0aWZpY2F0ZS1hdXRob3JpdHk=
+    server: %[2]s
+contexts:
+- name: admin@%[1]s
+  context:
+    cluster: %[1]s
+    user: %[1]s-admin
+current-context: admin@%[1]s
+users:
+- name: %[1]s-admin
+  user:
+    token: fake-token-%[3]s
+`, cluster.Name, cluster.ClusterURL, cluster.ID)
+
+	return map[string]interface{}{
+		"name":         "kubeconfig-" + cluster.Name + ".yaml",
+		"content_type": "application/octet-stream",
+		"content":      []byte(kubeconfig),
+	}, nil
+}
+
+////
+// ACL rules
+////
+
+// k8sACLRulesRequest is the body of the requests adding or setting the ACL rules of a cluster
+type k8sACLRulesRequest struct {
+	ACLs []*k8sACLRule `json:"acls"`
+}
+
+func (s *Server) k8sListClusterACLRules(r *http.Request) (interface{}, error) {
+	cluster, err := s.k8sCluster(r)
+	if err != nil {
+		return nil, err
+	}
+
+	return paginate(r, "rules", s.k8s.aclRules[cluster.ID]), nil
+}
+
+// k8sNewACLRules validates the rules of a request and returns them with an ID
+func k8sNewACLRules(existingRules []*k8sACLRule, req *k8sACLRulesRequest) ([]*k8sACLRule, error) {
+	scalewayRanges := 0
+	for _, rule := range existingRules {
+		if rule.ScalewayRanges != nil && *rule.ScalewayRanges {
+			scalewayRanges++
+		}
+	}
+
+	rules := make([]*k8sACLRule, 0, len(req.ACLs))
+	for _, rule := range req.ACLs {
+		hasScalewayRanges := rule.ScalewayRanges != nil && *rule.ScalewayRanges
+		if (rule.IP == nil) == !hasScalewayRanges {
+			return nil, invalidArgumentError("acls", "constraint", "precisely one of ip and scaleway_ranges must be set")
+		}
+		if hasScalewayRanges {
+			scalewayRanges++
+		}
+		rules = append(rules, &k8sACLRule{
+			ID:             newID(),
+			IP:             rule.IP,
+			ScalewayRanges: rule.ScalewayRanges,
+			Description:    rule.Description,
+		})
+	}
+	if scalewayRanges > 1 {
+		return nil, invalidArgumentError("acls", "constraint", "only one rule can allow the scaleway ranges")
+	}
+
+	return rules, nil
+}
+
+func (s *Server) k8sAddClusterACLRules(r *http.Request) (interface{}, error) {
+	cluster, err := s.k8sClusterIdle(r)
+	if err != nil {
+		return nil, err
+	}
+
+	req := &k8sACLRulesRequest{}
+	if err := decode(r, req); err != nil {
+		return nil, err
+	}
+
+	rules, err := k8sNewACLRules(s.k8s.aclRules[cluster.ID], req)
+	if err != nil {
+		return nil, err
+	}
+	s.k8s.aclRules[cluster.ID] = append(s.k8s.aclRules[cluster.ID], rules...)
+	s.k8sSetClusterStatus(cluster, k8s.ClusterStatusUpdating)
+
+	return map[string]interface{}{"rules": rules}, nil
+}
+
+func (s *Server) k8sSetClusterACLRules(r *http.Request) (interface{}, error) {
+	cluster, err := s.k8sClusterIdle(r)
+	if err != nil {
+		return nil, err
+	}
+
+	req := &k8sACLRulesRequest{}
+	if err := decode(r, req); err != nil {
+		return nil, err
+	}
+
+	rules, err := k8sNewACLRules(nil, req)
+	if err != nil {
+		return nil, err
+	}
+	s.k8s.aclRules[cluster.ID] = rules
+	s.k8sSetClusterStatus(cluster, k8s.ClusterStatusUpdating)
+
+	return map[string]interface{}{"rules": rules}, nil
+}
+
+func (s *Server) k8sDeleteACLRule(r *http.Request) (interface{}, error) {
+	id := r.PathValue("acl_id")
+	for clusterID, rules := range s.k8s.aclRules {
+		for i, rule := range rules {
+			if rule.ID != id {
+				continue
+			}
+			cluster, _ := s.k8s.clusters.get(clusterID)
+			if cluster.Region != region(r) {
+				break
+			}
+			if s.isTransient(cluster.ID) {
+				return nil, transientStateError("cluster", cluster.ID, cluster.Status.String())
+			}
+			s.k8s.aclRules[clusterID] = append(rules[:i:i], rules[i+1:]...)
+			s.k8sSetClusterStatus(cluster, k8s.ClusterStatusUpdating)
+
+			return nil, nil
+		}
+	}
+
+	return nil, notFoundError("acl", id)
+}
+
+////
+// Pools
+////
+
+// k8sTaint is a taint of the nodes of a pool, it is not part of the vendored SDK yet
+type k8sTaint struct {
+	Key    string `json:"key"`
+	Value  string `json:"value"`
+	Effect string `json:"effect"`
+}
+
+// k8sPoolNodeConfig holds the labels and taints of the nodes of a pool, they are not part of the vendored SDK yet
+type k8sPoolNodeConfig struct {
+	Labels        map[string]string `json:"labels"`
+	Taints        []*k8sTaint       `json:"taints"`
+	StartupTaints []*k8sTaint       `json:"startup_taints"`
+}
+
+// k8sPoolResponse is a pool with the labels and taints of its nodes
+type k8sPoolResponse struct {
+	*k8s.Pool
+	*k8sPoolNodeConfig
+}
+
+func (s *Server) k8sPoolResponse(pool *k8s.Pool) *k8sPoolResponse {
+	return &k8sPoolResponse{
+		Pool:              pool,
+		k8sPoolNodeConfig: s.k8s.poolNodeConfigs[pool.ID],
+	}
+}
+
+// k8sCreatePoolRequest is the request creating a pool, with the labels and taints of its nodes
+type k8sCreatePoolRequest struct {
+	k8s.CreatePoolRequest
+	k8sPoolNodeConfig
+}
+
+// k8sValidateTaints validates the taints of a request, the effect defaults to NoSchedule
+func k8sValidateTaints(field string, taints []*k8sTaint) ([]*k8sTaint, error) {
+	validTaints := []*k8sTaint{}
+	for _, taint := range taints {
+		if taint.Key == "" {
+			return nil, invalidArgumentError(field, "required", "the key of a taint is required")
+		}
+		switch taint.Effect {
+		case "":
+			taint.Effect = "NoSchedule"
+		case "NoSchedule", "PreferNoSchedule", "NoExecute":
+		default:
+			return nil, invalidArgumentError(field, "enum", fmt.Sprintf("unknown taint effect %s", taint.Effect))
+		}
+		validTaints = append(validTaints, taint)
+	}
+
+	return validTaints, nil
+}
+
+func (s *Server) k8sPool(r *http.Request) (*k8s.Pool, error) {
+	id := r.PathValue("pool_id")
+	pool, exists := s.k8s.pools.get(id)
+	if !exists || pool.Region != region(r) {
+		return nil, notFoundError("pool", id)
+	}
+
+	return pool, nil
+}
+
+// k8sPoolIdle returns the pool of a request, or an error if it is in a transient state
+func (s *Server) k8sPoolIdle(r *http.Request) (*k8s.Pool, error) {
+	pool, err := s.k8sPool(r)
+	if err != nil {
+		return nil, err
+	}
+	if s.isTransient(pool.ID) {
+		return nil, transientStateError("pool", pool.ID, pool.Status.String())
+	}
+
+	return pool, nil
+}
+
+// k8sPoolNodes returns the nodes of a pool
+func (s *Server) k8sPoolNodes(poolID string) []*k8s.Node {
+	return s.k8s.nodes.list(func(node *k8s.Node) bool {
+		return node.PoolID == poolID
+	})
+}
+
+// k8sScalePool sets the pool in the given transient status, its nodes are created or removed to match its size once read
+func (s *Server) k8sScalePool(pool *k8s.Pool, status k8s.PoolStatus) {
+	pool.Status = status
+	pool.UpdatedAt = s.timePtr()
+
+	nodes := s.k8sPoolNodes(pool.ID)
+	for i := len(nodes); i < int(pool.Size); i++ {
+		id := newID()
+		count := len(s.k8s.nodes.ids) + 1
+		publicIP := net.IPv4(51, 15, byte(count/256), byte(count%256))
+		node := &k8s.Node{
+			ID:         id,
+			PoolID:     pool.ID,
+			ClusterID:  pool.ClusterID,
+			ProviderID: fmt.Sprintf("scaleway://instance/%s/%s", pool.Zone, newID()),
+			Region:     pool.Region,
+			Name:       fmt.Sprintf("scw-%s-%s-%s", pool.ClusterID[:8], pool.Name, id[:8]),
+			Status:     k8s.NodeStatusCreating,
+			CreatedAt:  s.timePtr(),
+			UpdatedAt:  s.timePtr(),
+		}
+		if !pool.PublicIPDisabled {
+			node.PublicIPV4 = &publicIP
+		}
+		s.k8s.nodes.add(node.ID, node)
+	}
+	for i := int(pool.Size); i < len(nodes); i++ {
+		nodes[i].Status = k8s.NodeStatusDeleting
+	}
+
+	s.schedule(pool.ID, func() {
+		pool.Status = k8s.PoolStatusReady
+		for _, node := range s.k8sPoolNodes(pool.ID) {
+			if node.Status == k8s.NodeStatusDeleting {
+				s.k8sRemoveNode(node)
+				continue
+			}
+			if node.Status != k8s.NodeStatusReady {
+				node.Status = k8s.NodeStatusReady
+				s.k8sStartNodePods(node)
+			}
+		}
+		if cluster, exists := s.k8s.clusters.get(pool.ClusterID); exists && cluster.Status == k8s.ClusterStatusPoolRequired {
+			cluster.Status = k8s.ClusterStatusReady
+		}
+	})
+}
+
+// k8sRemovePool removes a pool and its nodes
+func (s *Server) k8sRemovePool(pool *k8s.Pool) {
+	for _, node := range s.k8sPoolNodes(pool.ID) {
+		s.k8sRemoveNode(node)
+	}
+	s.k8s.pools.delete(pool.ID)
+	delete(s.k8s.poolNodeConfigs, pool.ID)
+	delete(s.transitions, pool.ID)
+}
+
+func (s *Server) k8sListPools(r *http.Request) (interface{}, error) {
+	cluster, err := s.k8sCluster(r)
+	if err != nil {
+		return nil, err
+	}
+
+	f := newFilter(r)
+	pools := s.k8s.pools.list(func(pool *k8s.Pool) bool {
+		return pool.ClusterID == cluster.ID &&
+			f.matchName(pool.Name) &&
+			k8sMatchStatus(f, pool.Status.String())
+	})
+	responses := make([]*k8sPoolResponse, 0, len(pools))
+	for _, pool := range pools {
+		s.settle(pool.ID)
+		responses = append(responses, s.k8sPoolResponse(pool))
+	}
+
+	return paginate(r, "pools", responses), nil
+}
+
+func (s *Server) k8sCreatePool(r *http.Request) (interface{}, error) {
+	cluster, err := s.k8sCluster(r)
+	if err != nil {
+		return nil, err
+	}
+	if cluster.Status != k8s.ClusterStatusReady && cluster.Status != k8s.ClusterStatusPoolRequired {
+		return nil, transientStateError("cluster", cluster.ID, cluster.Status.String())
+	}
+
+	req := &k8sCreatePoolRequest{}
+	if err := decode(r, req); err != nil {
+		return nil, err
+	}
+
+	if req.NodeType == "" {
+		return nil, invalidArgumentError("node_type", "required", "node_type is required")
+	}
+	taints, err := k8sValidateTaints("taints", req.Taints)
+	if err != nil {
+		return nil, err
+	}
+	startupTaints, err := k8sValidateTaints("startup_taints", req.StartupTaints)
+	if err != nil {
+		return nil, err
+	}
+	for _, pool := range s.k8sClusterPools(cluster.ID) {
+		if pool.Name == req.Name {
+			return nil, invalidArgumentError("name", "constraint", fmt.Sprintf("a pool named %s already exists in the cluster", req.Name))
+		}
+	}
+
+	zone := req.Zone
+	if zone == "" {
+		zone = scw.Zone(cluster.Region + "-1")
+	}
+	minSize := uint32(1)
+	if req.MinSize != nil {
+		minSize = *req.MinSize
+	}
+	maxSize := req.Size
+	if req.MaxSize != nil {
+		maxSize = *req.MaxSize
+	}
+	containerRuntime := req.ContainerRuntime
+	if containerRuntime == "" || containerRuntime == k8s.RuntimeUnknownRuntime {
+		containerRuntime = k8s.RuntimeContainerd
+	}
+	rootVolumeType := req.RootVolumeType
+	if rootVolumeType == "" || rootVolumeType == k8s.PoolVolumeTypeDefaultVolumeType {
+		rootVolumeType = k8s.PoolVolumeTypeBSSD
+	}
+	rootVolumeSize := 20 * scw.GB
+	if req.RootVolumeSize != nil {
+		rootVolumeSize = *req.RootVolumeSize
+	}
+	upgradePolicy := &k8s.PoolUpgradePolicy{MaxUnavailable: 1, MaxSurge: 0}
+	if req.UpgradePolicy != nil {
+		if req.UpgradePolicy.MaxUnavailable != nil {
+			upgradePolicy.MaxUnavailable = *req.UpgradePolicy.MaxUnavailable
+		}
+		if req.UpgradePolicy.MaxSurge != nil {
+			upgradePolicy.MaxSurge = *req.UpgradePolicy.MaxSurge
+		}
+	}
+
+	pool := &k8s.Pool{
+		ID:               newID(),
+		ClusterID:        cluster.ID,
+		CreatedAt:        s.timePtr(),
+		UpdatedAt:        s.timePtr(),
+		Name:             req.Name,
+		Version:          cluster.Version,
+		NodeType:         req.NodeType,
+		Autoscaling:      req.Autoscaling,
+		Size:             req.Size,
+		MinSize:          minSize,
+		MaxSize:          maxSize,
+		ContainerRuntime: containerRuntime,
+		Autohealing:      req.Autohealing,
+		Tags:             req.Tags,
+		PlacementGroupID: req.PlacementGroupID,
+		KubeletArgs:      req.KubeletArgs,
+		UpgradePolicy:    upgradePolicy,
+		Zone:             zone,
+		RootVolumeType:   rootVolumeType,
+		RootVolumeSize:   &rootVolumeSize,
+		PublicIPDisabled: req.PublicIPDisabled,
+		Region:           cluster.Region,
+	}
+	if pool.Tags == nil {
+		pool.Tags = []string{}
+	}
+	if pool.KubeletArgs == nil {
+		pool.KubeletArgs = map[string]string{}
+	}
+
+	labels := req.Labels
+	if labels == nil {
+		labels = map[string]string{}
+	}
+	s.k8s.pools.add(pool.ID, pool)
+	s.k8s.poolNodeConfigs[pool.ID] = &k8sPoolNodeConfig{
+		Labels:        labels,
+		Taints:        taints,
+		StartupTaints: startupTaints,
+	}
+	s.k8sScalePool(pool, k8s.PoolStatusScaling)
+
+	return s.k8sPoolResponse(pool), nil
+}
+
+func (s *Server) k8sGetPool(r *http.Request) (interface{}, error) {
+	pool, err := s.k8sPool(r)
+	if err != nil {
+		return nil, err
+	}
+	s.settle(pool.ID)
+
+	// The pool may have been deleted when settled
+	pool, err = s.k8sPool(r)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.k8sPoolResponse(pool), nil
+}
+
+func (s *Server) k8sUpdatePool(r *http.Request) (interface{}, error) {
+	pool, err := s.k8sPoolIdle(r)
+	if err != nil {
+		return nil, err
+	}
+
+	req := &k8s.UpdatePoolRequest{}
+	if err := decode(r, req); err != nil {
+		return nil, err
+	}
+
+	if req.Autoscaling != nil {
+		pool.Autoscaling = *req.Autoscaling
+	}
+	if req.Autohealing != nil {
+		pool.Autohealing = *req.Autohealing
+	}
+	if req.MinSize != nil {
+		pool.MinSize = *req.MinSize
+	}
+	if req.MaxSize != nil {
+		pool.MaxSize = *req.MaxSize
+	}
+	if req.Tags != nil {
+		pool.Tags = stringsOrEmpty(req.Tags)
+	}
+	if req.KubeletArgs != nil {
+		pool.KubeletArgs = *req.KubeletArgs
+	}
+	if req.UpgradePolicy != nil {
+		if req.UpgradePolicy.MaxUnavailable != nil {
+			pool.UpgradePolicy.MaxUnavailable = *req.UpgradePolicy.MaxUnavailable
+		}
+		if req.UpgradePolicy.MaxSurge != nil {
+			pool.UpgradePolicy.MaxSurge = *req.UpgradePolicy.MaxSurge
+		}
+	}
+	pool.UpdatedAt = s.timePtr()
+	if req.Size != nil && *req.Size != pool.Size {
+		pool.Size = *req.Size
+		s.k8sScalePool(pool, k8s.PoolStatusScaling)
+	}
+
+	return s.k8sPoolResponse(pool), nil
+}
+
+func (s *Server) k8sDeletePool(r *http.Request) (interface{}, error) {
+	pool, err := s.k8sPoolIdle(r)
+	if err != nil {
+		return nil, err
+	}
+
+	pool.Status = k8s.PoolStatusDeleting
+	for _, node := range s.k8sPoolNodes(pool.ID) {
+		node.Status = k8s.NodeStatusDeleting
+	}
+	s.schedule(pool.ID, func() {
+		s.k8sRemovePool(pool)
+	})
+
+	return s.k8sPoolResponse(pool), nil
+}
+
+func (s *Server) k8sSetPoolLabels(r *http.Request) (interface{}, error) {
+	pool, err := s.k8sPoolIdle(r)
+	if err != nil {
+		return nil, err
+	}
+
+	req := &k8sPoolNodeConfig{}
+	if err := decode(r, req); err != nil {
+		return nil, err
+	}
+
+	labels := req.Labels
+	if labels == nil {
+		labels = map[string]string{}
+	}
+	s.k8s.poolNodeConfigs[pool.ID].Labels = labels
+	pool.UpdatedAt = s.timePtr()
+
+	return s.k8sPoolResponse(pool), nil
+}
+
+func (s *Server) k8sSetPoolTaints(r *http.Request) (interface{}, error) {
+	pool, err := s.k8sPoolIdle(r)
+	if err != nil {
+		return nil, err
+	}
+
+	req := &k8sPoolNodeConfig{}
+	if err := decode(r, req); err != nil {
+		return nil, err
+	}
+
+	taints, err := k8sValidateTaints("taints", req.Taints)
+	if err != nil {
+		return nil, err
+	}
+	s.k8s.poolNodeConfigs[pool.ID].Taints = taints
+	pool.UpdatedAt = s.timePtr()
+
+	return s.k8sPoolResponse(pool), nil
+}
+
+func (s *Server) k8sSetPoolStartupTaints(r *http.Request) (interface{}, error) {
+	pool, err := s.k8sPoolIdle(r)
+	if err != nil {
+		return nil, err
+	}
+
+	req := &k8sPoolNodeConfig{}
+	if err := decode(r, req); err != nil {
+		return nil, err
+	}
+
+	startupTaints, err := k8sValidateTaints("startup_taints", req.StartupTaints)
+	if err != nil {
+		return nil, err
+	}
+	s.k8s.poolNodeConfigs[pool.ID].StartupTaints = startupTaints
+	pool.UpdatedAt = s.timePtr()
+
+	return s.k8sPoolResponse(pool), nil
+}
+
+////
+// Nodes
+////
+
+func (s *Server) k8sListNodes(r *http.Request) (interface{}, error) {
+	cluster, err := s.k8sCluster(r)
+	if err != nil {
+		return nil, err
+	}
+
+	f := newFilter(r)
+	nodes := s.k8s.nodes.list(func(node *k8s.Node) bool {
+		return node.ClusterID == cluster.ID &&
+			f.matchString(node.PoolID, "pool_id") &&
+			f.matchName(node.Name) &&
+			k8sMatchStatus(f, node.Status.String())
+	})
+
+	return paginate(r, "nodes", nodes), nil
+}
+
+func (s *Server) k8sGetNode(r *http.Request) (interface{}, error) {
+	id := r.PathValue("node_id")
+	node, exists := s.k8s.nodes.get(id)
+	if !exists || node.Region != region(r) {
+		return nil, notFoundError("node", id)
+	}
+
+	return node, nil
+}
+
+////
+// Kubernetes API
+////
+
+// k8sPod is a pod of a cluster, with the fields of the Kubernetes API needed to drain nodes
+type k8sPod struct {
+	clusterID string
+	Metadata  k8sPodMetadata `json:"metadata"`
+	Spec      k8sPodSpec     `json:"spec"`
+	Status    k8sPodStatus   `json:"status"`
+}
+
+type k8sPodMetadata struct {
+	Name            string              `json:"name"`
+	Namespace       string              `json:"namespace"`
+	OwnerReferences []k8sOwnerReference `json:"ownerReferences,omitempty"`
+}
+
+type k8sOwnerReference struct {
+	Kind string `json:"kind"`
+	Name string `json:"name"`
+}
+
+type k8sPodSpec struct {
+	NodeName string `json:"nodeName,omitempty"`
+}
+
+type k8sPodStatus struct {
+	Phase string `json:"phase"`
+}
+
+func (pod *k8sPod) key() string {
+	return pod.Metadata.Namespace + "/" + pod.Metadata.Name
+}
+
+// k8sCoreDNSReplicas is the number of replicas of the coredns deployment started in every cluster
+const k8sCoreDNSReplicas = 2
+
+// k8sNewPod adds a pending pod owned by the given controller, its name is generated like the ones of the controllers
+func (s *Server) k8sNewPod(clusterID, ownerKind, ownerName string) *k8sPod {
+	pod := &k8sPod{
+		clusterID: clusterID,
+		Metadata: k8sPodMetadata{
+			Name:            ownerName + "-" + newID()[:5],
+			Namespace:       "kube-system",
+			OwnerReferences: []k8sOwnerReference{{Kind: ownerKind, Name: ownerName}},
+		},
+		Status: k8sPodStatus{Phase: "Pending"},
+	}
+	s.k8s.pods.add(pod.key(), pod)
+
+	return pod
+}
+
+func (s *Server) k8sClusterPods(clusterID string) []*k8sPod {
+	return s.k8s.pods.list(func(pod *k8sPod) bool {
+		return pod.clusterID == clusterID
+	})
+}
+
+// k8sStartNodePods starts the daemon set pods on a ready node, and the pods of the cluster waiting for a node
+func (s *Server) k8sStartNodePods(node *k8s.Node) {
+	pod := s.k8sNewPod(node.ClusterID, "DaemonSet", "cilium")
+	pod.Spec.NodeName = node.Name
+	pod.Status.Phase = "Running"
+
+	if len(s.k8sClusterPods(node.ClusterID)) == 1 {
+		for i := 0; i < k8sCoreDNSReplicas; i++ {
+			s.k8sNewPod(node.ClusterID, "ReplicaSet", "coredns")
+		}
+	}
+	s.k8sSchedulePods(node.ClusterID)
+}
+
+// k8sSchedulePods runs the pending pods of a cluster on its ready and schedulable nodes
+func (s *Server) k8sSchedulePods(clusterID string) {
+	nodes := s.k8s.nodes.list(func(node *k8s.Node) bool {
+		return node.ClusterID == clusterID && node.Status == k8s.NodeStatusReady && !s.k8s.unschedulable[node.ID]
+	})
+	if len(nodes) == 0 {
+		return
+	}
+
+	for i, pod := range s.k8sClusterPods(clusterID) {
+		if pod.Spec.NodeName == "" {
+			pod.Spec.NodeName = nodes[i%len(nodes)].Name
+			pod.Status.Phase = "Running"
+		}
+	}
+}
+
+// k8sRemovePod removes a pod, the pods of a replica set are replaced
+func (s *Server) k8sRemovePod(pod *k8sPod) {
+	s.k8s.pods.delete(pod.key())
+	for _, owner := range pod.Metadata.OwnerReferences {
+		if owner.Kind == "ReplicaSet" {
+			s.k8sNewPod(pod.clusterID, owner.Kind, owner.Name)
+			s.k8sSchedulePods(pod.clusterID)
+		}
+	}
+}
+
+// k8sRemoveNode removes a node and the pods running on it
+func (s *Server) k8sRemoveNode(node *k8s.Node) {
+	s.k8s.nodes.delete(node.ID)
+	delete(s.k8s.unschedulable, node.ID)
+	for _, pod := range s.k8sClusterPods(node.ClusterID) {
+		if pod.Spec.NodeName != node.Name {
+			continue
+		}
+		if pod.Metadata.OwnerReferences[0].Kind == "DaemonSet" {
+			s.k8s.pods.delete(pod.key())
+			continue
+		}
+		s.k8sRemovePod(pod)
+	}
+}
+
+// k8sKubeCluster returns the cluster whose control plane is requested, authenticated with the token of its kubeconfig
+func (s *Server) k8sKubeCluster(r *http.Request) (*k8s.Cluster, error) {
+	clusterID, _, _ := strings.Cut(r.URL.Host, ".")
+	cluster, exists := s.k8s.clusters.get(clusterID)
+	if !exists {
+		return nil, notFoundError("cluster", clusterID)
+	}
+	if r.Header.Get("Authorization") != "Bearer fake-token-"+cluster.ID {
+		return nil, &Error{
+			StatusCode: http.StatusUnauthorized,
+			Type:       "Unauthorized",
+			Message:    "Unauthorized",
+		}
+	}
+
+	return cluster, nil
+}
+
+// K8SEvictedPods returns the namespaced names of the pods evicted from the nodes of a cluster through the Kubernetes API
+func (s *Server) K8SEvictedPods(clusterID string) []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]string{}, s.k8s.evictedPods[clusterID]...)
+}
+
+// K8SPodNodes returns the name of the node running each pod of a cluster, empty for the pending pods
+func (s *Server) K8SPodNodes(clusterID string) map[string]string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	podNodes := map[string]string{}
+	for _, pod := range s.k8sClusterPods(clusterID) {
+		podNodes[pod.key()] = pod.Spec.NodeName
+	}
+
+	return podNodes
+}
+
+// k8sKubePatchNode only supports cordoning and uncordoning nodes
+func (s *Server) k8sKubePatchNode(r *http.Request) (interface{}, error) {
+	cluster, err := s.k8sKubeCluster(r)
+	if err != nil {
+		return nil, err
+	}
+
+	name := r.PathValue("node_name")
+	nodes := s.k8s.nodes.list(func(node *k8s.Node) bool {
+		return node.ClusterID == cluster.ID && node.Name == name
+	})
+	if len(nodes) == 0 {
+		return nil, notFoundError("node", name)
+	}
+	node := nodes[0]
+
+	req := &struct {
+		Spec struct {
+			Unschedulable *bool `json:"unschedulable"`
+		} `json:"spec"`
+	}{}
+	if err := decode(r, req); err != nil {
+		return nil, err
+	}
+	if req.Spec.Unschedulable != nil {
+		s.k8s.unschedulable[node.ID] = *req.Spec.Unschedulable
+	}
+
+	return map[string]interface{}{
+		"metadata": map[string]interface{}{"name": node.Name},
+		"spec":     map[string]interface{}{"unschedulable": s.k8s.unschedulable[node.ID]},
+	}, nil
+}
+
+// k8sKubeListPods lists the pods of all the namespaces, only the spec.nodeName field selector is supported
+func (s *Server) k8sKubeListPods(r *http.Request) (interface{}, error) {
+	cluster, err := s.k8sKubeCluster(r)
+	if err != nil {
+		return nil, err
+	}
+
+	nodeName, filterByNode := strings.CutPrefix(r.URL.Query().Get("fieldSelector"), "spec.nodeName=")
+	pods := s.k8s.pods.list(func(pod *k8sPod) bool {
+		return pod.clusterID == cluster.ID && (!filterByNode || pod.Spec.NodeName == nodeName)
+	})
+
+	return map[string]interface{}{
+		"apiVersion": "v1",
+		"kind":       "PodList",
+		"items":      pods,
+	}, nil
+}
+
+func (s *Server) k8sKubeEvictPod(r *http.Request) (interface{}, error) {
+	cluster, err := s.k8sKubeCluster(r)
+	if err != nil {
+		return nil, err
+	}
+
+	key := r.PathValue("namespace") + "/" + r.PathValue("pod_name")
+	pod, exists := s.k8s.pods.get(key)
+	if !exists || pod.clusterID != cluster.ID {
+		return nil, notFoundError("pod", key)
+	}
+
+	s.k8s.evictedPods[cluster.ID] = append(s.k8s.evictedPods[cluster.ID], key)
+	s.k8sRemovePod(pod)
+
+	return map[string]interface{}{
+		"apiVersion": "v1",
+		"kind":       "Status",
+		"status":     "Success",
+	}, nil
+}
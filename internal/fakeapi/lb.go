@@ -0,0 +1,890 @@
+package fakeapi
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	lb "github.com/scaleway/scaleway-sdk-go/api/lb/v1"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+)
+
+const lbPrefix = "/lb/v1/zones/{zone}"
+
+type lbStore struct {
+	lbs             *collection[lb.LB]
+	ips             *collection[lb.IP]
+	backends        *collection[lb.Backend]
+	frontends       *collection[lb.Frontend]
+	acls            *collection[lb.ACL]
+	privateNetworks map[string][]*lb.PrivateNetwork
+}
+
+func (s *Server) registerLB() {
+	s.lb = &lbStore{
+		lbs:             newCollection[lb.LB](),
+		ips:             newCollection[lb.IP](),
+		backends:        newCollection[lb.Backend](),
+		frontends:       newCollection[lb.Frontend](),
+		acls:            newCollection[lb.ACL](),
+		privateNetworks: map[string][]*lb.PrivateNetwork{},
+	}
+
+	s.handle("GET "+lbPrefix+"/lbs", s.lbListLBs)
+	s.handle("POST "+lbPrefix+"/lbs", s.lbCreateLB)
+	s.handle("GET "+lbPrefix+"/lbs/{lb_id}", s.lbGetLB)
+	s.handle("PUT "+lbPrefix+"/lbs/{lb_id}", s.lbUpdateLB)
+	s.handle("DELETE "+lbPrefix+"/lbs/{lb_id}", s.lbDeleteLB)
+	s.handle("POST "+lbPrefix+"/lbs/{lb_id}/migrate", s.lbMigrateLB)
+
+	s.handle("GET "+lbPrefix+"/lbs/{lb_id}/private-networks", s.lbListLBPrivateNetworks)
+	s.handle("POST "+lbPrefix+"/lbs/{lb_id}/private-networks/{private_network_id}/attach", s.lbAttachPrivateNetwork)
+	s.handle("POST "+lbPrefix+"/lbs/{lb_id}/private-networks/{private_network_id}/detach", s.lbDetachPrivateNetwork)
+
+	s.handle("GET "+lbPrefix+"/ips", s.lbListIPs)
+	s.handle("POST "+lbPrefix+"/ips", s.lbCreateIP)
+	s.handle("GET "+lbPrefix+"/ips/{ip_id}", s.lbGetIP)
+	s.handle("PATCH "+lbPrefix+"/ips/{ip_id}", s.lbUpdateIP)
+	s.handle("DELETE "+lbPrefix+"/ips/{ip_id}", s.lbDeleteIP)
+
+	s.handle("GET "+lbPrefix+"/lbs/{lb_id}/backends", s.lbListBackends)
+	s.handle("POST "+lbPrefix+"/lbs/{lb_id}/backends", s.lbCreateBackend)
+	s.handle("GET "+lbPrefix+"/backends/{backend_id}", s.lbGetBackend)
+	s.handle("PUT "+lbPrefix+"/backends/{backend_id}", s.lbUpdateBackend)
+	s.handle("DELETE "+lbPrefix+"/backends/{backend_id}", s.lbDeleteBackend)
+	s.handle("PUT "+lbPrefix+"/backends/{backend_id}/servers", s.lbSetBackendServers)
+	s.handle("PUT "+lbPrefix+"/backends/{backend_id}/healthcheck", s.lbUpdateHealthCheck)
+
+	s.handle("GET "+lbPrefix+"/lbs/{lb_id}/frontends", s.lbListFrontends)
+	s.handle("POST "+lbPrefix+"/lbs/{lb_id}/frontends", s.lbCreateFrontend)
+	s.handle("GET "+lbPrefix+"/frontends/{frontend_id}", s.lbGetFrontend)
+	s.handle("PUT "+lbPrefix+"/frontends/{frontend_id}", s.lbUpdateFrontend)
+	s.handle("DELETE "+lbPrefix+"/frontends/{frontend_id}", s.lbDeleteFrontend)
+
+	s.handle("GET "+lbPrefix+"/frontends/{frontend_id}/acls", s.lbListACLs)
+	s.handle("POST "+lbPrefix+"/frontends/{frontend_id}/acls", s.lbCreateACL)
+	s.handle("GET "+lbPrefix+"/acls/{acl_id}", s.lbGetACL)
+	s.handle("PUT "+lbPrefix+"/acls/{acl_id}", s.lbUpdateACL)
+	s.handle("DELETE "+lbPrefix+"/acls/{acl_id}", s.lbDeleteACL)
+}
+
+////
+// Load balancers
+////
+
+func (s *Server) lbLB(r *http.Request) (*lb.LB, error) {
+	id := r.PathValue("lb_id")
+	loadBalancer, exists := s.lb.lbs.get(id)
+	if !exists || loadBalancer.Zone != zone(r) {
+		return nil, notFoundError("lb", id)
+	}
+
+	return loadBalancer, nil
+}
+
+// lbLBIdle returns the load balancer of the request, or an error if it is in a transient state
+func (s *Server) lbLBIdle(r *http.Request) (*lb.LB, error) {
+	loadBalancer, err := s.lbLB(r)
+	if err != nil {
+		return nil, err
+	}
+	if s.isTransient(loadBalancer.ID) {
+		return nil, transientStateError("lb", loadBalancer.ID, string(loadBalancer.Status))
+	}
+
+	return loadBalancer, nil
+}
+
+// lbSetStatus sets the transient status of a load balancer and its instances, they are ready once read
+func (s *Server) lbSetStatus(loadBalancer *lb.LB, status lb.LBStatus) {
+	loadBalancer.Status = status
+	for _, lbInstance := range loadBalancer.Instances {
+		lbInstance.Status = lb.InstanceStatusPending
+	}
+	s.schedule(loadBalancer.ID, func() {
+		loadBalancer.Status = lb.LBStatusReady
+		for _, lbInstance := range loadBalancer.Instances {
+			lbInstance.Status = lb.InstanceStatusReady
+			lbInstance.UpdatedAt = s.timePtr()
+		}
+	})
+}
+
+func (s *Server) lbListLBs(r *http.Request) (interface{}, error) {
+	f := newFilter(r)
+	lbs := s.lb.lbs.list(func(loadBalancer *lb.LB) bool {
+		return loadBalancer.Zone == zone(r) &&
+			f.matchName(loadBalancer.Name) &&
+			f.matchProject(loadBalancer.ProjectID, loadBalancer.OrganizationID) &&
+			f.matchTags(loadBalancer.Tags)
+	})
+	for _, loadBalancer := range lbs {
+		s.settle(loadBalancer.ID)
+	}
+
+	return paginate(r, "lbs", lbs), nil
+}
+
+func (s *Server) lbCreateLB(r *http.Request) (interface{}, error) {
+	req := &lb.ZonedAPICreateLBRequest{}
+	if err := decode(r, req); err != nil {
+		return nil, err
+	}
+
+	lbRegion, _ := zone(r).Region()
+	loadBalancer := &lb.LB{
+		ID:                    newID(),
+		Name:                  req.Name,
+		Description:           req.Description,
+		OrganizationID:        DefaultOrganizationID,
+		ProjectID:             projectOrDefault(req.ProjectID, req.OrganizationID),
+		IP:                    []*lb.IP{},
+		Tags:                  req.Tags,
+		Type:                  req.Type,
+		SslCompatibilityLevel: req.SslCompatibilityLevel,
+		CreatedAt:             s.timePtr(),
+		UpdatedAt:             s.timePtr(),
+		Region:                &lbRegion,
+		Zone:                  zone(r),
+	}
+	if loadBalancer.Tags == nil {
+		loadBalancer.Tags = []string{}
+	}
+	if loadBalancer.SslCompatibilityLevel == "" {
+		loadBalancer.SslCompatibilityLevel = lb.SSLCompatibilityLevelSslCompatibilityLevelIntermediate
+	}
+
+	var ip *lb.IP
+	switch {
+	case req.IPID != nil:
+		existingIP, exists := s.lb.ips.get(*req.IPID)
+		if !exists {
+			return nil, notFoundError("ip", *req.IPID)
+		}
+		if existingIP.LBID != nil {
+			return nil, preconditionFailedError("ip_not_attached", fmt.Sprintf("ip %s is already used by load balancer %s", existingIP.ID, *existingIP.LBID))
+		}
+		ip = existingIP
+	case req.AssignFlexibleIP == nil || *req.AssignFlexibleIP:
+		ip = s.lbNewIP(zone(r), loadBalancer.ProjectID)
+	}
+	if ip != nil {
+		ip.LBID = scw.StringPtr(loadBalancer.ID)
+		loadBalancer.IP = append(loadBalancer.IP, ip)
+	}
+
+	loadBalancer.Instances = []*lb.Instance{{
+		ID:        newID(),
+		IPAddress: "10.0.0.1",
+		CreatedAt: s.timePtr(),
+		UpdatedAt: s.timePtr(),
+		Region:    &lbRegion,
+		Zone:      zone(r),
+	}}
+	s.lb.lbs.add(loadBalancer.ID, loadBalancer)
+	s.lbSetStatus(loadBalancer, lb.LBStatusCreating)
+
+	return loadBalancer, nil
+}
+
+func (s *Server) lbGetLB(r *http.Request) (interface{}, error) {
+	loadBalancer, err := s.lbLB(r)
+	if err != nil {
+		return nil, err
+	}
+	s.settle(loadBalancer.ID)
+
+	// The load balancer may have been deleted once settled
+	if _, exists := s.lb.lbs.get(loadBalancer.ID); !exists {
+		return nil, notFoundError("lb", loadBalancer.ID)
+	}
+
+	return loadBalancer, nil
+}
+
+func (s *Server) lbUpdateLB(r *http.Request) (interface{}, error) {
+	loadBalancer, err := s.lbLBIdle(r)
+	if err != nil {
+		return nil, err
+	}
+
+	req := &lb.ZonedAPIUpdateLBRequest{}
+	if err := decode(r, req); err != nil {
+		return nil, err
+	}
+
+	loadBalancer.Name = req.Name
+	loadBalancer.Description = req.Description
+	loadBalancer.Tags = req.Tags
+	if loadBalancer.Tags == nil {
+		loadBalancer.Tags = []string{}
+	}
+	if req.SslCompatibilityLevel != "" {
+		loadBalancer.SslCompatibilityLevel = req.SslCompatibilityLevel
+	}
+	loadBalancer.UpdatedAt = s.timePtr()
+
+	return loadBalancer, nil
+}
+
+func (s *Server) lbDeleteLB(r *http.Request) (interface{}, error) {
+	loadBalancer, err := s.lbLBIdle(r)
+	if err != nil {
+		return nil, err
+	}
+
+	releaseIP := r.URL.Query().Get("release_ip") == "true"
+	loadBalancer.Status = lb.LBStatusDeleting
+	s.schedule(loadBalancer.ID, func() {
+		for _, ip := range loadBalancer.IP {
+			ip.LBID = nil
+			if releaseIP {
+				s.lb.ips.delete(ip.ID)
+			}
+		}
+		for _, frontend := range s.lb.frontends.list(func(frontend *lb.Frontend) bool { return frontend.LB.ID == loadBalancer.ID }) {
+			s.lbRemoveFrontend(frontend)
+		}
+		for _, backend := range s.lb.backends.list(func(backend *lb.Backend) bool { return backend.LB.ID == loadBalancer.ID }) {
+			s.lb.backends.delete(backend.ID)
+		}
+		for _, privateNetwork := range s.lb.privateNetworks[loadBalancer.ID] {
+			s.lbReleasePrivateNetwork(loadBalancer, privateNetwork)
+		}
+		delete(s.lb.privateNetworks, loadBalancer.ID)
+		s.lb.lbs.delete(loadBalancer.ID)
+	})
+
+	return nil, nil
+}
+
+func (s *Server) lbMigrateLB(r *http.Request) (interface{}, error) {
+	loadBalancer, err := s.lbLBIdle(r)
+	if err != nil {
+		return nil, err
+	}
+
+	req := &lb.ZonedAPIMigrateLBRequest{}
+	if err := decode(r, req); err != nil {
+		return nil, err
+	}
+
+	loadBalancer.Type = req.Type
+	loadBalancer.UpdatedAt = s.timePtr()
+	s.lbSetStatus(loadBalancer, lb.LBStatusMigrating)
+
+	return loadBalancer, nil
+}
+
+////
+// Private networks
+////
+
+func (s *Server) lbListLBPrivateNetworks(r *http.Request) (interface{}, error) {
+	loadBalancer, err := s.lbLB(r)
+	if err != nil {
+		return nil, err
+	}
+
+	privateNetworks := s.lb.privateNetworks[loadBalancer.ID]
+	for _, privateNetwork := range privateNetworks {
+		s.settle(loadBalancer.ID + "/" + privateNetwork.PrivateNetworkID)
+	}
+
+	return paginate(r, "private_network", privateNetworks), nil
+}
+
+func (s *Server) lbAttachPrivateNetwork(r *http.Request) (interface{}, error) {
+	loadBalancer, err := s.lbLBIdle(r)
+	if err != nil {
+		return nil, err
+	}
+
+	req := &lb.ZonedAPIAttachPrivateNetworkRequest{}
+	if err := decode(r, req); err != nil {
+		return nil, err
+	}
+
+	privateNetworkID := r.PathValue("private_network_id")
+	vpcPrivateNetwork, exists := s.vpc.privateNetworks.get(privateNetworkID)
+	if !exists {
+		return nil, notFoundError("private_network", privateNetworkID)
+	}
+	for _, privateNetwork := range s.lb.privateNetworks[loadBalancer.ID] {
+		if privateNetwork.PrivateNetworkID == privateNetworkID {
+			return nil, &Error{StatusCode: http.StatusConflict, Type: "conflict", Message: "private network is already attached to the load balancer"}
+		}
+	}
+
+	privateNetwork := &lb.PrivateNetwork{
+		LB:               loadBalancer,
+		IpamIDs:          []string{},
+		StaticConfig:     req.StaticConfig,
+		DHCPConfig:       req.DHCPConfig,
+		IpamConfig:       req.IpamConfig,
+		PrivateNetworkID: privateNetworkID,
+		Status:           lb.PrivateNetworkStatusPending,
+		CreatedAt:        s.timePtr(),
+		UpdatedAt:        s.timePtr(),
+	}
+	if req.StaticConfig == nil {
+		resource := &ipamResource{Type: "lb_server", ID: loadBalancer.ID, Name: loadBalancer.Name}
+		if req.DHCPConfig != nil && req.DHCPConfig.IPID != nil {
+			if err := s.ipamAttachIP(*req.DHCPConfig.IPID, resource); err != nil {
+				return nil, err
+			}
+			privateNetwork.IpamIDs = append(privateNetwork.IpamIDs, *req.DHCPConfig.IPID)
+		} else {
+			ip, err := s.ipamBookIP(vpcPrivateNetwork, loadBalancer.ProjectID, resource, false)
+			if err != nil {
+				return nil, err
+			}
+			privateNetwork.IpamIDs = append(privateNetwork.IpamIDs, ip.ID)
+		}
+	}
+
+	s.lb.privateNetworks[loadBalancer.ID] = append(s.lb.privateNetworks[loadBalancer.ID], privateNetwork)
+	loadBalancer.PrivateNetworkCount++
+	s.schedule(loadBalancer.ID+"/"+privateNetworkID, func() {
+		privateNetwork.Status = lb.PrivateNetworkStatusReady
+	})
+
+	return privateNetwork, nil
+}
+
+func (s *Server) lbDetachPrivateNetwork(r *http.Request) (interface{}, error) {
+	loadBalancer, err := s.lbLBIdle(r)
+	if err != nil {
+		return nil, err
+	}
+
+	privateNetworkID := r.PathValue("private_network_id")
+	for _, privateNetwork := range s.lb.privateNetworks[loadBalancer.ID] {
+		if privateNetwork.PrivateNetworkID == privateNetworkID {
+			s.lbReleasePrivateNetwork(loadBalancer, privateNetwork)
+			return nil, nil
+		}
+	}
+
+	return nil, notFoundError("private_network", privateNetworkID)
+}
+
+// lbReleasePrivateNetwork detaches a private network from a load balancer and releases its IPAM IPs
+func (s *Server) lbReleasePrivateNetwork(loadBalancer *lb.LB, privateNetwork *lb.PrivateNetwork) {
+	for _, ipID := range privateNetwork.IpamIDs {
+		if ip, exists := s.ipam.ips.get(ipID); exists && ip.Resource != nil && ip.Resource.ID == loadBalancer.ID {
+			if s.ipam.autoBooked[ip.ID] {
+				delete(s.ipam.autoBooked, ip.ID)
+				s.ipam.ips.delete(ip.ID)
+			} else {
+				ip.Resource = nil
+			}
+		}
+	}
+
+	privateNetworks := s.lb.privateNetworks[loadBalancer.ID]
+	for i, attached := range privateNetworks {
+		if attached == privateNetwork {
+			s.lb.privateNetworks[loadBalancer.ID] = append(privateNetworks[:i], privateNetworks[i+1:]...)
+			break
+		}
+	}
+	delete(s.transitions, loadBalancer.ID+"/"+privateNetwork.PrivateNetworkID)
+	loadBalancer.PrivateNetworkCount--
+}
+
+////
+// IPs
+////
+
+// lbNewIP allocates a flexible IP, addresses are allocated sequentially with the instance ones
+func (s *Server) lbNewIP(zone scw.Zone, projectID string) *lb.IP {
+	s.instance.lastIP++
+	ipRegion, _ := zone.Region()
+	ip := &lb.IP{
+		ID:             newID(),
+		IPAddress:      fmt.Sprintf("51.159.%d.%d", s.instance.lastIP/250, s.instance.lastIP%250+1),
+		OrganizationID: DefaultOrganizationID,
+		ProjectID:      projectID,
+		Region:         &ipRegion,
+		Zone:           zone,
+	}
+	ip.Reverse = ip.IPAddress + ".lb." + ipRegion.String() + ".scw.cloud"
+	s.lb.ips.add(ip.ID, ip)
+
+	return ip
+}
+
+func (s *Server) lbIP(r *http.Request) (*lb.IP, error) {
+	id := r.PathValue("ip_id")
+	ip, exists := s.lb.ips.get(id)
+	if !exists || ip.Zone != zone(r) {
+		return nil, notFoundError("ip", id)
+	}
+
+	return ip, nil
+}
+
+func (s *Server) lbListIPs(r *http.Request) (interface{}, error) {
+	f := newFilter(r)
+	ips := s.lb.ips.list(func(ip *lb.IP) bool {
+		return ip.Zone == zone(r) &&
+			f.matchProject(ip.ProjectID, ip.OrganizationID) &&
+			f.matchString(ip.IPAddress, "ip_address")
+	})
+
+	return paginate(r, "ips", ips), nil
+}
+
+func (s *Server) lbCreateIP(r *http.Request) (interface{}, error) {
+	req := &lb.ZonedAPICreateIPRequest{}
+	if err := decode(r, req); err != nil {
+		return nil, err
+	}
+
+	ip := s.lbNewIP(zone(r), projectOrDefault(req.ProjectID, req.OrganizationID))
+	if req.Reverse != nil {
+		ip.Reverse = *req.Reverse
+	}
+
+	return ip, nil
+}
+
+func (s *Server) lbGetIP(r *http.Request) (interface{}, error) {
+	return s.lbIP(r)
+}
+
+func (s *Server) lbUpdateIP(r *http.Request) (interface{}, error) {
+	ip, err := s.lbIP(r)
+	if err != nil {
+		return nil, err
+	}
+
+	req := &lb.ZonedAPIUpdateIPRequest{}
+	if err := decode(r, req); err != nil {
+		return nil, err
+	}
+	if req.Reverse != nil {
+		ip.Reverse = *req.Reverse
+	}
+
+	return ip, nil
+}
+
+func (s *Server) lbDeleteIP(r *http.Request) (interface{}, error) {
+	ip, err := s.lbIP(r)
+	if err != nil {
+		return nil, err
+	}
+	if ip.LBID != nil {
+		return nil, preconditionFailedError("ip_not_attached", fmt.Sprintf("ip is used by load balancer %s", *ip.LBID))
+	}
+
+	s.lb.ips.delete(ip.ID)
+
+	return nil, nil
+}
+
+////
+// Backends
+////
+
+func (s *Server) lbBackend(r *http.Request) (*lb.Backend, error) {
+	id := r.PathValue("backend_id")
+	backend, exists := s.lb.backends.get(id)
+	if !exists || backend.LB.Zone != zone(r) {
+		return nil, notFoundError("backend", id)
+	}
+
+	return backend, nil
+}
+
+func (s *Server) lbListBackends(r *http.Request) (interface{}, error) {
+	loadBalancer, err := s.lbLB(r)
+	if err != nil {
+		return nil, err
+	}
+
+	f := newFilter(r)
+	backends := s.lb.backends.list(func(backend *lb.Backend) bool {
+		return backend.LB.ID == loadBalancer.ID && f.matchName(backend.Name)
+	})
+
+	return paginate(r, "backends", backends), nil
+}
+
+func (s *Server) lbCreateBackend(r *http.Request) (interface{}, error) {
+	loadBalancer, err := s.lbLBIdle(r)
+	if err != nil {
+		return nil, err
+	}
+
+	req := &lb.ZonedAPICreateBackendRequest{}
+	if err := decode(r, req); err != nil {
+		return nil, err
+	}
+
+	backend := &lb.Backend{
+		ID:                       newID(),
+		Name:                     req.Name,
+		ForwardProtocol:          req.ForwardProtocol,
+		ForwardPort:              req.ForwardPort,
+		ForwardPortAlgorithm:     req.ForwardPortAlgorithm,
+		StickySessions:           req.StickySessions,
+		StickySessionsCookieName: req.StickySessionsCookieName,
+		HealthCheck:              req.HealthCheck,
+		Pool:                     req.ServerIP,
+		LB:                       loadBalancer,
+		SendProxyV2:              req.SendProxyV2,
+		TimeoutServer:            req.TimeoutServer,
+		TimeoutConnect:           req.TimeoutConnect,
+		TimeoutTunnel:            req.TimeoutTunnel,
+		OnMarkedDownAction:       req.OnMarkedDownAction,
+		ProxyProtocol:            req.ProxyProtocol,
+		CreatedAt:                s.timePtr(),
+		UpdatedAt:                s.timePtr(),
+		FailoverHost:             req.FailoverHost,
+		SslBridging:              req.SslBridging,
+		IgnoreSslServerVerify:    req.IgnoreSslServerVerify,
+		RedispatchAttemptCount:   req.RedispatchAttemptCount,
+		MaxRetries:               req.MaxRetries,
+		MaxConnections:           req.MaxConnections,
+		TimeoutQueue:             req.TimeoutQueue,
+	}
+	if backend.Pool == nil {
+		backend.Pool = []string{}
+	}
+	if backend.HealthCheck == nil {
+		backend.HealthCheck = &lb.HealthCheck{
+			Port:            req.ForwardPort,
+			CheckDelay:      scw.TimeDurationPtr(60 * time.Second),
+			CheckTimeout:    scw.TimeDurationPtr(30 * time.Second),
+			CheckMaxRetries: 2,
+			TCPConfig:       &lb.HealthCheckTCPConfig{},
+		}
+	}
+	if backend.ProxyProtocol == "" {
+		backend.ProxyProtocol = lb.ProxyProtocolProxyProtocolNone
+	}
+	if backend.OnMarkedDownAction == "" {
+		backend.OnMarkedDownAction = lb.OnMarkedDownActionOnMarkedDownActionNone
+	}
+
+	s.lb.backends.add(backend.ID, backend)
+	loadBalancer.BackendCount++
+
+	return backend, nil
+}
+
+func (s *Server) lbGetBackend(r *http.Request) (interface{}, error) {
+	return s.lbBackend(r)
+}
+
+func (s *Server) lbUpdateBackend(r *http.Request) (interface{}, error) {
+	backend, err := s.lbBackend(r)
+	if err != nil {
+		return nil, err
+	}
+
+	req := &lb.ZonedAPIUpdateBackendRequest{}
+	if err := decode(r, req); err != nil {
+		return nil, err
+	}
+
+	backend.Name = req.Name
+	backend.ForwardProtocol = req.ForwardProtocol
+	backend.ForwardPort = req.ForwardPort
+	backend.ForwardPortAlgorithm = req.ForwardPortAlgorithm
+	backend.StickySessions = req.StickySessions
+	backend.StickySessionsCookieName = req.StickySessionsCookieName
+	backend.SendProxyV2 = req.SendProxyV2
+	backend.TimeoutServer = req.TimeoutServer
+	backend.TimeoutConnect = req.TimeoutConnect
+	backend.TimeoutTunnel = req.TimeoutTunnel
+	backend.OnMarkedDownAction = req.OnMarkedDownAction
+	backend.ProxyProtocol = req.ProxyProtocol
+	backend.FailoverHost = req.FailoverHost
+	backend.SslBridging = req.SslBridging
+	backend.IgnoreSslServerVerify = req.IgnoreSslServerVerify
+	backend.RedispatchAttemptCount = req.RedispatchAttemptCount
+	backend.MaxRetries = req.MaxRetries
+	backend.MaxConnections = req.MaxConnections
+	backend.TimeoutQueue = req.TimeoutQueue
+	backend.UpdatedAt = s.timePtr()
+
+	return backend, nil
+}
+
+func (s *Server) lbDeleteBackend(r *http.Request) (interface{}, error) {
+	backend, err := s.lbBackend(r)
+	if err != nil {
+		return nil, err
+	}
+	for _, frontend := range s.lb.frontends.list(nil) {
+		if frontend.Backend.ID == backend.ID {
+			return nil, preconditionFailedError("backend_unused", fmt.Sprintf("backend is used by frontend %s", frontend.ID))
+		}
+	}
+
+	s.lb.backends.delete(backend.ID)
+	backend.LB.BackendCount--
+
+	return nil, nil
+}
+
+func (s *Server) lbSetBackendServers(r *http.Request) (interface{}, error) {
+	backend, err := s.lbBackend(r)
+	if err != nil {
+		return nil, err
+	}
+
+	req := &lb.ZonedAPISetBackendServersRequest{}
+	if err := decode(r, req); err != nil {
+		return nil, err
+	}
+
+	backend.Pool = req.ServerIP
+	if backend.Pool == nil {
+		backend.Pool = []string{}
+	}
+	backend.UpdatedAt = s.timePtr()
+
+	return backend, nil
+}
+
+func (s *Server) lbUpdateHealthCheck(r *http.Request) (interface{}, error) {
+	backend, err := s.lbBackend(r)
+	if err != nil {
+		return nil, err
+	}
+
+	req := &lb.ZonedAPIUpdateHealthCheckRequest{}
+	if err := decode(r, req); err != nil {
+		return nil, err
+	}
+
+	backend.HealthCheck = &lb.HealthCheck{
+		Port:                req.Port,
+		CheckDelay:          req.CheckDelay,
+		CheckTimeout:        req.CheckTimeout,
+		CheckMaxRetries:     req.CheckMaxRetries,
+		TCPConfig:           req.TCPConfig,
+		MysqlConfig:         req.MysqlConfig,
+		PgsqlConfig:         req.PgsqlConfig,
+		LdapConfig:          req.LdapConfig,
+		RedisConfig:         req.RedisConfig,
+		HTTPConfig:          req.HTTPConfig,
+		HTTPSConfig:         req.HTTPSConfig,
+		CheckSendProxy:      req.CheckSendProxy,
+		TransientCheckDelay: req.TransientCheckDelay,
+	}
+	backend.UpdatedAt = s.timePtr()
+
+	return backend.HealthCheck, nil
+}
+
+////
+// Frontends
+////
+
+func (s *Server) lbFrontend(r *http.Request) (*lb.Frontend, error) {
+	id := r.PathValue("frontend_id")
+	frontend, exists := s.lb.frontends.get(id)
+	if !exists || frontend.LB.Zone != zone(r) {
+		return nil, notFoundError("frontend", id)
+	}
+
+	return frontend, nil
+}
+
+func (s *Server) lbListFrontends(r *http.Request) (interface{}, error) {
+	loadBalancer, err := s.lbLB(r)
+	if err != nil {
+		return nil, err
+	}
+
+	f := newFilter(r)
+	frontends := s.lb.frontends.list(func(frontend *lb.Frontend) bool {
+		return frontend.LB.ID == loadBalancer.ID && f.matchName(frontend.Name)
+	})
+
+	return paginate(r, "frontends", frontends), nil
+}
+
+func (s *Server) lbCreateFrontend(r *http.Request) (interface{}, error) {
+	loadBalancer, err := s.lbLBIdle(r)
+	if err != nil {
+		return nil, err
+	}
+
+	req := &lb.ZonedAPICreateFrontendRequest{}
+	if err := decode(r, req); err != nil {
+		return nil, err
+	}
+
+	backend, exists := s.lb.backends.get(req.BackendID)
+	if !exists || backend.LB.ID != loadBalancer.ID {
+		return nil, notFoundError("backend", req.BackendID)
+	}
+
+	frontend := &lb.Frontend{
+		ID:             newID(),
+		Name:           req.Name,
+		InboundPort:    req.InboundPort,
+		Backend:        backend,
+		LB:             loadBalancer,
+		TimeoutClient:  req.TimeoutClient,
+		CertificateIDs: stringsOrEmpty(req.CertificateIDs),
+		CreatedAt:      s.timePtr(),
+		UpdatedAt:      s.timePtr(),
+		EnableHTTP3:    req.EnableHTTP3,
+	}
+	s.lb.frontends.add(frontend.ID, frontend)
+	loadBalancer.FrontendCount++
+
+	return frontend, nil
+}
+
+func (s *Server) lbGetFrontend(r *http.Request) (interface{}, error) {
+	return s.lbFrontend(r)
+}
+
+func (s *Server) lbUpdateFrontend(r *http.Request) (interface{}, error) {
+	frontend, err := s.lbFrontend(r)
+	if err != nil {
+		return nil, err
+	}
+
+	req := &lb.ZonedAPIUpdateFrontendRequest{}
+	if err := decode(r, req); err != nil {
+		return nil, err
+	}
+
+	backend, exists := s.lb.backends.get(req.BackendID)
+	if !exists || backend.LB.ID != frontend.LB.ID {
+		return nil, notFoundError("backend", req.BackendID)
+	}
+
+	frontend.Name = req.Name
+	frontend.InboundPort = req.InboundPort
+	frontend.Backend = backend
+	frontend.TimeoutClient = req.TimeoutClient
+	frontend.CertificateIDs = stringsOrEmpty(req.CertificateIDs)
+	frontend.EnableHTTP3 = req.EnableHTTP3
+	frontend.UpdatedAt = s.timePtr()
+
+	return frontend, nil
+}
+
+func (s *Server) lbDeleteFrontend(r *http.Request) (interface{}, error) {
+	frontend, err := s.lbFrontend(r)
+	if err != nil {
+		return nil, err
+	}
+
+	s.lbRemoveFrontend(frontend)
+
+	return nil, nil
+}
+
+// lbRemoveFrontend deletes a frontend and its ACLs
+func (s *Server) lbRemoveFrontend(frontend *lb.Frontend) {
+	for _, acl := range s.lb.acls.list(func(acl *lb.ACL) bool { return acl.Frontend.ID == frontend.ID }) {
+		s.lb.acls.delete(acl.ID)
+	}
+	s.lb.frontends.delete(frontend.ID)
+	frontend.LB.FrontendCount--
+}
+
+////
+// ACLs
+////
+
+func (s *Server) lbACL(r *http.Request) (*lb.ACL, error) {
+	id := r.PathValue("acl_id")
+	acl, exists := s.lb.acls.get(id)
+	if !exists || acl.Frontend.LB.Zone != zone(r) {
+		return nil, notFoundError("acl", id)
+	}
+
+	return acl, nil
+}
+
+func (s *Server) lbListACLs(r *http.Request) (interface{}, error) {
+	frontend, err := s.lbFrontend(r)
+	if err != nil {
+		return nil, err
+	}
+
+	f := newFilter(r)
+	acls := s.lb.acls.list(func(acl *lb.ACL) bool {
+		return acl.Frontend.ID == frontend.ID && f.matchName(acl.Name)
+	})
+
+	return paginate(r, "acls", acls), nil
+}
+
+func (s *Server) lbCreateACL(r *http.Request) (interface{}, error) {
+	frontend, err := s.lbFrontend(r)
+	if err != nil {
+		return nil, err
+	}
+
+	req := &lb.ZonedAPICreateACLRequest{}
+	if err := decode(r, req); err != nil {
+		return nil, err
+	}
+
+	acl := &lb.ACL{
+		ID:          newID(),
+		Name:        req.Name,
+		Match:       req.Match,
+		Action:      req.Action,
+		Frontend:    frontend,
+		Index:       req.Index,
+		CreatedAt:   s.timePtr(),
+		UpdatedAt:   s.timePtr(),
+		Description: req.Description,
+	}
+	s.lb.acls.add(acl.ID, acl)
+
+	return acl, nil
+}
+
+func (s *Server) lbGetACL(r *http.Request) (interface{}, error) {
+	return s.lbACL(r)
+}
+
+func (s *Server) lbUpdateACL(r *http.Request) (interface{}, error) {
+	acl, err := s.lbACL(r)
+	if err != nil {
+		return nil, err
+	}
+
+	req := &lb.ZonedAPIUpdateACLRequest{}
+	if err := decode(r, req); err != nil {
+		return nil, err
+	}
+
+	acl.Name = req.Name
+	acl.Action = req.Action
+	acl.Match = req.Match
+	acl.Index = req.Index
+	if req.Description != nil {
+		acl.Description = *req.Description
+	}
+	acl.UpdatedAt = s.timePtr()
+
+	return acl, nil
+}
+
+func (s *Server) lbDeleteACL(r *http.Request) (interface{}, error) {
+	acl, err := s.lbACL(r)
+	if err != nil {
+		return nil, err
+	}
+
+	s.lb.acls.delete(acl.ID)
+
+	return nil, nil
+}
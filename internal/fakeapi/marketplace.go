@@ -0,0 +1,119 @@
+package fakeapi
+
+import (
+	"crypto/sha1" //nolint:gosec
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	instance "github.com/scaleway/scaleway-sdk-go/api/instance/v1"
+	marketplace "github.com/scaleway/scaleway-sdk-go/api/marketplace/v2"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+)
+
+// marketplaceLabels are the labels of the public images of the catalog
+var marketplaceLabels = []string{
+	"ubuntu_jammy",
+	"ubuntu_focal",
+	"ubuntu_noble",
+	"debian_bookworm",
+	"debian_bullseye",
+	"centos_stream_9",
+	"rockylinux_9",
+}
+
+type marketplaceStore struct {
+	images      *collection[marketplace.Image]
+	localImages *collection[marketplace.LocalImage]
+}
+
+// registerMarketplace registers the marketplace endpoints and seeds the public images, in every zone.
+// The ID of a local image is the ID of its instance image, like the real API.
+func (s *Server) registerMarketplace() {
+	s.marketplace = &marketplaceStore{
+		images:      newCollection[marketplace.Image](),
+		localImages: newCollection[marketplace.LocalImage](),
+	}
+
+	creationDate := time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+	commercialTypes := sortedKeys(s.instance.serverTypes)
+	for _, label := range marketplaceLabels {
+		image := &marketplace.Image{
+			ID:          deterministicID("marketplace", label),
+			Name:        strings.ReplaceAll(label, "_", " "),
+			Description: label,
+			Categories:  []string{"distribution"},
+			CreatedAt:   &creationDate,
+			UpdatedAt:   &creationDate,
+			Label:       label,
+		}
+		s.marketplace.images.add(image.ID, image)
+
+		for _, zone := range scw.AllZones {
+			localImage := &marketplace.LocalImage{
+				ID:                        deterministicID("local_image", label, zone.String()),
+				CompatibleCommercialTypes: commercialTypes,
+				Arch:                      string(instance.ArchX86_64),
+				Zone:                      zone,
+				Label:                     label,
+				Type:                      marketplace.LocalImageTypeInstanceLocal,
+			}
+			s.marketplace.localImages.add(localImage.ID, localImage)
+			s.instance.images.add(localImage.ID, instancePublicImage(localImage.ID, label, zone, instance.ArchX86_64, &creationDate))
+		}
+	}
+
+	s.handle("GET /marketplace/v2/images", s.marketplaceListImages)
+	s.handle("GET /marketplace/v2/images/{image_id}", s.marketplaceGetImage)
+	s.handle("GET /marketplace/v2/local-images", s.marketplaceListLocalImages)
+	s.handle("GET /marketplace/v2/local-images/{local_image_id}", s.marketplaceGetLocalImage)
+}
+
+// deterministicID returns an ID in the UUID format derived from parts, public resources have the same IDs in every fake API
+func deterministicID(parts ...string) string {
+	b := sha1.Sum([]byte(strings.Join(parts, "/"))) //nolint:gosec
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16])
+}
+
+func (s *Server) marketplaceListImages(r *http.Request) (interface{}, error) {
+	f := newFilter(r)
+	images := s.marketplace.images.list(func(image *marketplace.Image) bool {
+		return f.matchString(image.Label, "label")
+	})
+
+	return paginate(r, "images", images), nil
+}
+
+func (s *Server) marketplaceGetImage(r *http.Request) (interface{}, error) {
+	id := r.PathValue("image_id")
+	image, exists := s.marketplace.images.get(id)
+	if !exists {
+		return nil, notFoundError("image", id)
+	}
+
+	return image, nil
+}
+
+func (s *Server) marketplaceListLocalImages(r *http.Request) (interface{}, error) {
+	f := newFilter(r)
+	// The SDK sends the default value of the type when it is not set
+	imageType := f.get("type")
+	localImages := s.marketplace.localImages.list(func(localImage *marketplace.LocalImage) bool {
+		return f.matchString(localImage.Label, "image_label") &&
+			f.matchString(localImage.Zone.String(), "zone") &&
+			(imageType == "" || imageType == string(marketplace.LocalImageTypeUnknownType) || imageType == string(localImage.Type))
+	})
+
+	return paginate(r, "local_images", localImages), nil
+}
+
+func (s *Server) marketplaceGetLocalImage(r *http.Request) (interface{}, error) {
+	id := r.PathValue("local_image_id")
+	localImage, exists := s.marketplace.localImages.get(id)
+	if !exists {
+		return nil, notFoundError("local_image", id)
+	}
+
+	return localImage, nil
+}
@@ -0,0 +1,769 @@
+package fakeapi
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"strconv"
+
+	rdb "github.com/scaleway/scaleway-sdk-go/api/rdb/v1"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+)
+
+const (
+	rdbPrefix = "/rdb/v1/regions/{region}"
+
+	// rdbCertificate is the certificate returned for every database instance
+	rdbCertificate = "-----BEGIN CERTIFICATE-----\nfakeapi\n-----END CERTIFICATE-----\n"
+)
+
+type rdbStore struct {
+	instances *collection[rdb.Instance]
+	// users, databases and privileges are keyed by instance ID
+	users      map[string]*collection[rdb.User]
+	passwords  map[string]map[string]string
+	databases  map[string]*collection[rdb.Database]
+	privileges map[string]*collection[rdb.Privilege]
+	aclRules   map[string][]*rdb.ACLRule
+	// endpoints are the instance IDs of endpoints, by endpoint ID
+	endpoints map[string]string
+}
+
+func (s *Server) registerRdb() {
+	s.rdb = &rdbStore{
+		instances:  newCollection[rdb.Instance](),
+		users:      map[string]*collection[rdb.User]{},
+		passwords:  map[string]map[string]string{},
+		databases:  map[string]*collection[rdb.Database]{},
+		privileges: map[string]*collection[rdb.Privilege]{},
+		aclRules:   map[string][]*rdb.ACLRule{},
+		endpoints:  map[string]string{},
+	}
+
+	s.handle("GET "+rdbPrefix+"/instances", s.rdbListInstances)
+	s.handle("POST "+rdbPrefix+"/instances", s.rdbCreateInstance)
+	s.handle("GET "+rdbPrefix+"/instances/{instance_id}", s.rdbGetInstance)
+	s.handle("PATCH "+rdbPrefix+"/instances/{instance_id}", s.rdbUpdateInstance)
+	s.handle("DELETE "+rdbPrefix+"/instances/{instance_id}", s.rdbDeleteInstance)
+	s.handle("POST "+rdbPrefix+"/instances/{instance_id}/upgrade", s.rdbUpgradeInstance)
+	s.handle("GET "+rdbPrefix+"/instances/{instance_id}/certificate", s.rdbGetInstanceCertificate)
+	s.handle("PUT "+rdbPrefix+"/instances/{instance_id}/settings", s.rdbSetInstanceSettings)
+
+	s.handle("GET "+rdbPrefix+"/instances/{instance_id}/acls", s.rdbListInstanceACLRules)
+	s.handle("PUT "+rdbPrefix+"/instances/{instance_id}/acls", s.rdbSetInstanceACLRules)
+	s.handle("DELETE "+rdbPrefix+"/instances/{instance_id}/acls", s.rdbDeleteInstanceACLRules)
+
+	s.handle("POST "+rdbPrefix+"/instances/{instance_id}/endpoints", s.rdbCreateEndpoint)
+	s.handle("GET "+rdbPrefix+"/endpoints/{endpoint_id}", s.rdbGetEndpoint)
+	s.handle("DELETE "+rdbPrefix+"/endpoints/{endpoint_id}", s.rdbDeleteEndpoint)
+
+	s.handle("GET "+rdbPrefix+"/instances/{instance_id}/users", s.rdbListUsers)
+	s.handle("POST "+rdbPrefix+"/instances/{instance_id}/users", s.rdbCreateUser)
+	s.handle("PATCH "+rdbPrefix+"/instances/{instance_id}/users/{name}", s.rdbUpdateUser)
+	s.handle("DELETE "+rdbPrefix+"/instances/{instance_id}/users/{name}", s.rdbDeleteUser)
+
+	s.handle("GET "+rdbPrefix+"/instances/{instance_id}/databases", s.rdbListDatabases)
+	s.handle("POST "+rdbPrefix+"/instances/{instance_id}/databases", s.rdbCreateDatabase)
+	s.handle("DELETE "+rdbPrefix+"/instances/{instance_id}/databases/{name}", s.rdbDeleteDatabase)
+
+	s.handle("GET "+rdbPrefix+"/instances/{instance_id}/privileges", s.rdbListPrivileges)
+	s.handle("PUT "+rdbPrefix+"/instances/{instance_id}/privileges", s.rdbSetPrivilege)
+}
+
+////
+// Instances
+////
+
+func (s *Server) rdbInstance(r *http.Request) (*rdb.Instance, error) {
+	id := r.PathValue("instance_id")
+	instance, exists := s.rdb.instances.get(id)
+	if !exists || instance.Region != region(r) {
+		return nil, notFoundError("instance", id)
+	}
+
+	return instance, nil
+}
+
+// rdbInstanceIdle returns the database instance of the request, or an error if it is in a transient state
+func (s *Server) rdbInstanceIdle(r *http.Request) (*rdb.Instance, error) {
+	instance, err := s.rdbInstance(r)
+	if err != nil {
+		return nil, err
+	}
+	if s.isTransient(instance.ID) {
+		return nil, transientStateError("instance", instance.ID, string(instance.Status))
+	}
+
+	return instance, nil
+}
+
+// rdbSetStatus sets the transient status of a database instance, it is ready once read
+func (s *Server) rdbSetStatus(instance *rdb.Instance, status rdb.InstanceStatus) {
+	instance.Status = status
+	s.schedule(instance.ID, func() {
+		instance.Status = rdb.InstanceStatusReady
+	})
+}
+
+func (s *Server) rdbListInstances(r *http.Request) (interface{}, error) {
+	f := newFilter(r)
+	instances := s.rdb.instances.list(func(instance *rdb.Instance) bool {
+		return instance.Region == region(r) &&
+			f.matchName(instance.Name) &&
+			f.matchProject(instance.ProjectID, instance.OrganizationID) &&
+			f.matchTags(instance.Tags)
+	})
+	for _, instance := range instances {
+		s.settle(instance.ID)
+	}
+
+	return paginate(r, "instances", instances), nil
+}
+
+func (s *Server) rdbCreateInstance(r *http.Request) (interface{}, error) {
+	req := &rdb.CreateInstanceRequest{}
+	if err := decode(r, req); err != nil {
+		return nil, err
+	}
+
+	if req.Engine == "" {
+		return nil, invalidArgumentError("engine", "required", "engine is required")
+	}
+	if req.UserName != "" && req.Password == "" {
+		return nil, invalidArgumentError("password", "required", "password is required to create the admin user")
+	}
+
+	volumeType := req.VolumeType
+	if volumeType == "" {
+		volumeType = rdb.VolumeTypeLssd
+	}
+	volumeSize := req.VolumeSize
+	if volumeSize == 0 {
+		volumeSize = 5 * scw.GB
+	}
+
+	instance := &rdb.Instance{
+		CreatedAt:         s.timePtr(),
+		Volume:            &rdb.Volume{Type: volumeType, Size: volumeSize, Class: rdbStorageClass(volumeType)},
+		Region:            region(r),
+		ID:                newID(),
+		Name:              req.Name,
+		OrganizationID:    DefaultOrganizationID,
+		ProjectID:         projectOrDefault(req.ProjectID, req.OrganizationID),
+		Engine:            req.Engine,
+		UpgradableVersion: []*rdb.UpgradableVersion{},
+		Tags:              req.Tags,
+		Settings:          []*rdb.InstanceSetting{},
+		BackupSchedule:    &rdb.BackupSchedule{Frequency: 24, Retention: 7, Disabled: req.DisableBackup},
+		IsHaCluster:       req.IsHaCluster,
+		ReadReplicas:      []*rdb.ReadReplica{},
+		NodeType:          req.NodeType,
+		InitSettings:      req.InitSettings,
+		Endpoints:         []*rdb.Endpoint{},
+		LogsPolicy:        &rdb.LogsPolicy{MaxAgeRetention: scw.Uint32Ptr(30)},
+		BackupSameRegion:  req.BackupSameRegion,
+		Maintenances:      []*rdb.Maintenance{},
+	}
+	if instance.Tags == nil {
+		instance.Tags = []string{}
+	}
+	if instance.InitSettings == nil {
+		instance.InitSettings = []*rdb.InstanceSetting{}
+	}
+
+	initEndpoints := req.InitEndpoints
+	if len(initEndpoints) == 0 {
+		initEndpoints = []*rdb.EndpointSpec{{LoadBalancer: &rdb.EndpointSpecLoadBalancer{}}}
+	}
+	for _, spec := range initEndpoints {
+		if _, err := s.rdbAddEndpoint(instance, spec); err != nil {
+			return nil, err
+		}
+	}
+
+	s.rdb.instances.add(instance.ID, instance)
+	s.rdb.users[instance.ID] = newCollection[rdb.User]()
+	s.rdb.passwords[instance.ID] = map[string]string{}
+	s.rdb.databases[instance.ID] = newCollection[rdb.Database]()
+	s.rdb.privileges[instance.ID] = newCollection[rdb.Privilege]()
+	_, ipNet, _ := net.ParseCIDR("0.0.0.0/0")
+	s.rdb.aclRules[instance.ID] = []*rdb.ACLRule{rdbACLRule(scw.IPNet{IPNet: *ipNet}, "Allow All")}
+
+	if req.UserName != "" {
+		s.rdb.users[instance.ID].add(req.UserName, &rdb.User{Name: req.UserName, IsAdmin: true})
+		s.rdb.passwords[instance.ID][req.UserName] = req.Password
+	}
+	s.rdbSetStatus(instance, rdb.InstanceStatusProvisioning)
+
+	return instance, nil
+}
+
+func rdbStorageClass(volumeType rdb.VolumeType) rdb.StorageClass {
+	switch volumeType {
+	case rdb.VolumeTypeBssd:
+		return rdb.StorageClassBssd
+	case rdb.VolumeTypeSbs5k, rdb.VolumeTypeSbs15k:
+		return rdb.StorageClassSbs
+	default:
+		return rdb.StorageClassLssd
+	}
+}
+
+func (s *Server) rdbGetInstance(r *http.Request) (interface{}, error) {
+	instance, err := s.rdbInstance(r)
+	if err != nil {
+		return nil, err
+	}
+	s.settle(instance.ID)
+
+	// The instance may have been deleted once settled
+	if _, exists := s.rdb.instances.get(instance.ID); !exists {
+		return nil, notFoundError("instance", instance.ID)
+	}
+
+	return instance, nil
+}
+
+func (s *Server) rdbUpdateInstance(r *http.Request) (interface{}, error) {
+	instance, err := s.rdbInstanceIdle(r)
+	if err != nil {
+		return nil, err
+	}
+
+	req := &rdb.UpdateInstanceRequest{}
+	if err := decode(r, req); err != nil {
+		return nil, err
+	}
+
+	if req.Name != nil {
+		instance.Name = *req.Name
+	}
+	if req.Tags != nil {
+		instance.Tags = *req.Tags
+	}
+	if req.BackupScheduleFrequency != nil {
+		instance.BackupSchedule.Frequency = *req.BackupScheduleFrequency
+	}
+	if req.BackupScheduleRetention != nil {
+		instance.BackupSchedule.Retention = *req.BackupScheduleRetention
+	}
+	if req.IsBackupScheduleDisabled != nil {
+		instance.BackupSchedule.Disabled = *req.IsBackupScheduleDisabled
+	}
+	if req.BackupSameRegion != nil {
+		instance.BackupSameRegion = *req.BackupSameRegion
+	}
+	if req.LogsPolicy != nil {
+		instance.LogsPolicy = req.LogsPolicy
+	}
+
+	return instance, nil
+}
+
+func (s *Server) rdbUpgradeInstance(r *http.Request) (interface{}, error) {
+	instance, err := s.rdbInstanceIdle(r)
+	if err != nil {
+		return nil, err
+	}
+
+	req := &rdb.UpgradeInstanceRequest{}
+	if err := decode(r, req); err != nil {
+		return nil, err
+	}
+
+	switch {
+	case req.NodeType != nil:
+		instance.NodeType = *req.NodeType
+	case req.EnableHa != nil:
+		instance.IsHaCluster = *req.EnableHa
+	case req.VolumeSize != nil:
+		if scw.Size(*req.VolumeSize) < instance.Volume.Size {
+			return nil, invalidArgumentError("volume_size", "constraint", "volumes cannot be shrunk")
+		}
+		instance.Volume.Size = scw.Size(*req.VolumeSize)
+	case req.VolumeType != nil:
+		instance.Volume.Type = *req.VolumeType
+		instance.Volume.Class = rdbStorageClass(*req.VolumeType)
+	default:
+		return nil, invalidArgumentError("upgrade", "required", "one upgrade parameter is required")
+	}
+	s.rdbSetStatus(instance, rdb.InstanceStatusConfiguring)
+
+	return instance, nil
+}
+
+func (s *Server) rdbDeleteInstance(r *http.Request) (interface{}, error) {
+	instance, err := s.rdbInstanceIdle(r)
+	if err != nil {
+		return nil, err
+	}
+
+	instance.Status = rdb.InstanceStatusDeleting
+	s.schedule(instance.ID, func() {
+		for _, endpoint := range instance.Endpoints {
+			s.rdbRemoveEndpoint(instance, endpoint)
+		}
+		s.rdb.instances.delete(instance.ID)
+		delete(s.rdb.users, instance.ID)
+		delete(s.rdb.passwords, instance.ID)
+		delete(s.rdb.databases, instance.ID)
+		delete(s.rdb.privileges, instance.ID)
+		delete(s.rdb.aclRules, instance.ID)
+	})
+
+	return instance, nil
+}
+
+// rdbGetInstanceCertificate returns the certificate as a file, its content is encoded in base64
+func (s *Server) rdbGetInstanceCertificate(r *http.Request) (interface{}, error) {
+	instance, err := s.rdbInstance(r)
+	if err != nil {
+		return nil, err
+	}
+
+	return map[string]interface{}{
+		"name":         instance.Name + ".pem",
+		"content_type": "application/x-pem-file",
+		"content":      []byte(rdbCertificate),
+	}, nil
+}
+
+func (s *Server) rdbSetInstanceSettings(r *http.Request) (interface{}, error) {
+	instance, err := s.rdbInstanceIdle(r)
+	if err != nil {
+		return nil, err
+	}
+
+	req := &rdb.SetInstanceSettingsRequest{}
+	if err := decode(r, req); err != nil {
+		return nil, err
+	}
+
+	instance.Settings = req.Settings
+	if instance.Settings == nil {
+		instance.Settings = []*rdb.InstanceSetting{}
+	}
+	s.rdbSetStatus(instance, rdb.InstanceStatusConfiguring)
+
+	return &rdb.SetInstanceSettingsResponse{Settings: instance.Settings}, nil
+}
+
+////
+// ACLs
+////
+
+func rdbACLRule(ip scw.IPNet, description string) *rdb.ACLRule {
+	return &rdb.ACLRule{
+		IP:          ip,
+		Protocol:    rdb.ACLRuleProtocolTCP,
+		Direction:   rdb.ACLRuleDirectionInbound,
+		Action:      rdb.ACLRuleActionAllow,
+		Description: description,
+	}
+}
+
+func (s *Server) rdbListInstanceACLRules(r *http.Request) (interface{}, error) {
+	instance, err := s.rdbInstance(r)
+	if err != nil {
+		return nil, err
+	}
+
+	return paginate(r, "rules", s.rdb.aclRules[instance.ID]), nil
+}
+
+func (s *Server) rdbSetInstanceACLRules(r *http.Request) (interface{}, error) {
+	instance, err := s.rdbInstanceIdle(r)
+	if err != nil {
+		return nil, err
+	}
+
+	req := &rdb.SetInstanceACLRulesRequest{}
+	if err := decode(r, req); err != nil {
+		return nil, err
+	}
+
+	rules := make([]*rdb.ACLRule, 0, len(req.Rules))
+	for _, rule := range req.Rules {
+		rules = append(rules, rdbACLRule(rule.IP, rule.Description))
+	}
+	s.rdb.aclRules[instance.ID] = rules
+	s.rdbSetStatus(instance, rdb.InstanceStatusConfiguring)
+
+	return &rdb.SetInstanceACLRulesResponse{Rules: rules}, nil
+}
+
+func (s *Server) rdbDeleteInstanceACLRules(r *http.Request) (interface{}, error) {
+	instance, err := s.rdbInstanceIdle(r)
+	if err != nil {
+		return nil, err
+	}
+
+	req := &rdb.DeleteInstanceACLRulesRequest{}
+	if err := decode(r, req); err != nil {
+		return nil, err
+	}
+
+	deleted := map[string]bool{}
+	for _, ip := range req.ACLRuleIPs {
+		deleted[ip] = true
+	}
+	rules := []*rdb.ACLRule{}
+	deletedRules := []*rdb.ACLRule{}
+	for _, rule := range s.rdb.aclRules[instance.ID] {
+		if deleted[rule.IP.String()] || deleted[rule.IP.IP.String()] {
+			deletedRules = append(deletedRules, rule)
+			continue
+		}
+		rules = append(rules, rule)
+	}
+	s.rdb.aclRules[instance.ID] = rules
+	s.rdbSetStatus(instance, rdb.InstanceStatusConfiguring)
+
+	return &rdb.DeleteInstanceACLRulesResponse{Rules: deletedRules}, nil
+}
+
+////
+// Endpoints
+////
+
+// rdbAddEndpoint adds an endpoint to a database instance, the public one is on the load balancer of the region
+func (s *Server) rdbAddEndpoint(instance *rdb.Instance, spec *rdb.EndpointSpec) (*rdb.Endpoint, error) {
+	endpoint := &rdb.Endpoint{
+		ID:   newID(),
+		Port: 5432,
+	}
+
+	switch {
+	case spec.PrivateNetwork != nil:
+		privateNetwork, exists := s.vpc.privateNetworks.get(spec.PrivateNetwork.PrivateNetworkID)
+		if !exists {
+			return nil, notFoundError("private_network", spec.PrivateNetwork.PrivateNetworkID)
+		}
+
+		var serviceIP scw.IPNet
+		if spec.PrivateNetwork.ServiceIP != nil {
+			serviceIP = *spec.PrivateNetwork.ServiceIP
+		} else {
+			ip, err := s.ipamBookIP(privateNetwork, instance.ProjectID, &ipamResource{Type: "rdb_instance", ID: instance.ID, Name: instance.Name}, false)
+			if err != nil {
+				return nil, err
+			}
+			serviceIP = ip.Address
+		}
+		endpoint.IP = &serviceIP.IP
+		endpoint.PrivateNetwork = &rdb.EndpointPrivateNetworkDetails{
+			PrivateNetworkID: privateNetwork.ID,
+			ServiceIP:        serviceIP,
+			Zone:             scw.Zone(instance.Region.String() + "-1"),
+		}
+	case spec.LoadBalancer != nil:
+		s.instance.lastIP++
+		ip := net.IPv4(195, 154, byte(s.instance.lastIP/250), byte(s.instance.lastIP%250+1)).To4()
+		endpoint.IP = &ip
+		endpoint.Port = uint32(20000 + s.instance.lastIP)
+		endpoint.Name = scw.StringPtr("lb")
+		endpoint.LoadBalancer = &rdb.EndpointLoadBalancerDetails{}
+	default:
+		return nil, invalidArgumentError("endpoint_spec", "required", "endpoint spec must have a private network or a load balancer")
+	}
+
+	instance.Endpoints = append(instance.Endpoints, endpoint)
+	if instance.Endpoint == nil {
+		instance.Endpoint = endpoint
+	}
+	s.rdb.endpoints[endpoint.ID] = instance.ID
+
+	return endpoint, nil
+}
+
+// rdbRemoveEndpoint removes an endpoint of a database instance and releases its IPAM IP
+func (s *Server) rdbRemoveEndpoint(instance *rdb.Instance, endpoint *rdb.Endpoint) {
+	if endpoint.PrivateNetwork != nil {
+		for _, ip := range s.ipamPrivateNetworkIPs(endpoint.PrivateNetwork.PrivateNetworkID) {
+			if ip.Resource != nil && ip.Resource.ID == instance.ID && ip.Address.IP.Equal(endpoint.PrivateNetwork.ServiceIP.IP) {
+				delete(s.ipam.autoBooked, ip.ID)
+				s.ipam.ips.delete(ip.ID)
+			}
+		}
+	}
+
+	for i, instanceEndpoint := range instance.Endpoints {
+		if instanceEndpoint.ID == endpoint.ID {
+			instance.Endpoints = append(instance.Endpoints[:i], instance.Endpoints[i+1:]...)
+			break
+		}
+	}
+	if instance.Endpoint != nil && instance.Endpoint.ID == endpoint.ID {
+		instance.Endpoint = nil
+		if len(instance.Endpoints) > 0 {
+			instance.Endpoint = instance.Endpoints[0]
+		}
+	}
+	delete(s.rdb.endpoints, endpoint.ID)
+}
+
+func (s *Server) rdbCreateEndpoint(r *http.Request) (interface{}, error) {
+	instance, err := s.rdbInstanceIdle(r)
+	if err != nil {
+		return nil, err
+	}
+
+	req := &rdb.CreateEndpointRequest{}
+	if err := decode(r, req); err != nil {
+		return nil, err
+	}
+	if req.EndpointSpec == nil {
+		return nil, invalidArgumentError("endpoint_spec", "required", "endpoint spec is required")
+	}
+
+	endpoint, err := s.rdbAddEndpoint(instance, req.EndpointSpec)
+	if err != nil {
+		return nil, err
+	}
+	s.rdbSetStatus(instance, rdb.InstanceStatusConfiguring)
+
+	return endpoint, nil
+}
+
+func (s *Server) rdbEndpoint(r *http.Request) (*rdb.Instance, *rdb.Endpoint, error) {
+	id := r.PathValue("endpoint_id")
+	if instance, exists := s.rdb.instances.get(s.rdb.endpoints[id]); exists && instance.Region == region(r) {
+		for _, endpoint := range instance.Endpoints {
+			if endpoint.ID == id {
+				return instance, endpoint, nil
+			}
+		}
+	}
+
+	return nil, nil, notFoundError("endpoint", id)
+}
+
+func (s *Server) rdbGetEndpoint(r *http.Request) (interface{}, error) {
+	_, endpoint, err := s.rdbEndpoint(r)
+	if err != nil {
+		return nil, err
+	}
+
+	return endpoint, nil
+}
+
+func (s *Server) rdbDeleteEndpoint(r *http.Request) (interface{}, error) {
+	instance, endpoint, err := s.rdbEndpoint(r)
+	if err != nil {
+		return nil, err
+	}
+	if s.isTransient(instance.ID) {
+		return nil, transientStateError("instance", instance.ID, string(instance.Status))
+	}
+
+	s.rdbRemoveEndpoint(instance, endpoint)
+	s.rdbSetStatus(instance, rdb.InstanceStatusConfiguring)
+
+	return nil, nil
+}
+
+////
+// Users
+////
+
+func (s *Server) rdbListUsers(r *http.Request) (interface{}, error) {
+	instance, err := s.rdbInstance(r)
+	if err != nil {
+		return nil, err
+	}
+
+	f := newFilter(r)
+	users := s.rdb.users[instance.ID].list(func(user *rdb.User) bool {
+		return f.matchString(user.Name, "name")
+	})
+
+	return paginate(r, "users", users), nil
+}
+
+func (s *Server) rdbCreateUser(r *http.Request) (interface{}, error) {
+	instance, err := s.rdbInstanceIdle(r)
+	if err != nil {
+		return nil, err
+	}
+
+	req := &rdb.CreateUserRequest{}
+	if err := decode(r, req); err != nil {
+		return nil, err
+	}
+
+	if req.Password == "" {
+		return nil, invalidArgumentError("password", "required", "password is required")
+	}
+	if _, exists := s.rdb.users[instance.ID].get(req.Name); exists {
+		return nil, &Error{StatusCode: http.StatusConflict, Type: "conflict", Message: fmt.Sprintf("user %s already exists", req.Name)}
+	}
+
+	user := &rdb.User{Name: req.Name, IsAdmin: req.IsAdmin}
+	s.rdb.users[instance.ID].add(user.Name, user)
+	s.rdb.passwords[instance.ID][user.Name] = req.Password
+	s.rdbSetStatus(instance, rdb.InstanceStatusConfiguring)
+
+	return user, nil
+}
+
+func (s *Server) rdbUpdateUser(r *http.Request) (interface{}, error) {
+	instance, err := s.rdbInstanceIdle(r)
+	if err != nil {
+		return nil, err
+	}
+
+	user, exists := s.rdb.users[instance.ID].get(r.PathValue("name"))
+	if !exists {
+		return nil, notFoundError("user", r.PathValue("name"))
+	}
+
+	req := &rdb.UpdateUserRequest{}
+	if err := decode(r, req); err != nil {
+		return nil, err
+	}
+	if req.Password != nil && *req.Password == "" {
+		return nil, invalidArgumentError("password", "required", "password cannot be empty")
+	}
+	if req.Password != nil {
+		s.rdb.passwords[instance.ID][user.Name] = *req.Password
+	}
+	if req.IsAdmin != nil {
+		user.IsAdmin = *req.IsAdmin
+	}
+	s.rdbSetStatus(instance, rdb.InstanceStatusConfiguring)
+
+	return user, nil
+}
+
+func (s *Server) rdbDeleteUser(r *http.Request) (interface{}, error) {
+	instance, err := s.rdbInstanceIdle(r)
+	if err != nil {
+		return nil, err
+	}
+
+	name := r.PathValue("name")
+	if _, exists := s.rdb.users[instance.ID].get(name); !exists {
+		return nil, notFoundError("user", name)
+	}
+
+	s.rdb.users[instance.ID].delete(name)
+	delete(s.rdb.passwords[instance.ID], name)
+	for _, privilege := range s.rdb.privileges[instance.ID].list(func(privilege *rdb.Privilege) bool { return privilege.UserName == name }) {
+		s.rdb.privileges[instance.ID].delete(privilege.DatabaseName + "/" + privilege.UserName)
+	}
+	s.rdbSetStatus(instance, rdb.InstanceStatusConfiguring)
+
+	return nil, nil
+}
+
+// Password returns the password of a user of a database instance, so tests can check it has been set
+func (s *Server) Password(instanceID, userName string) (string, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	password, exists := s.rdb.passwords[instanceID][userName]
+
+	return password, exists
+}
+
+////
+// Databases
+////
+
+func (s *Server) rdbListDatabases(r *http.Request) (interface{}, error) {
+	instance, err := s.rdbInstance(r)
+	if err != nil {
+		return nil, err
+	}
+
+	f := newFilter(r)
+	databases := s.rdb.databases[instance.ID].list(func(database *rdb.Database) bool {
+		return f.matchString(database.Name, "name") &&
+			f.matchString(database.Owner, "owner") &&
+			f.matchString(strconv.FormatBool(database.Managed), "managed")
+	})
+
+	return paginate(r, "databases", databases), nil
+}
+
+func (s *Server) rdbCreateDatabase(r *http.Request) (interface{}, error) {
+	instance, err := s.rdbInstanceIdle(r)
+	if err != nil {
+		return nil, err
+	}
+
+	req := &rdb.CreateDatabaseRequest{}
+	if err := decode(r, req); err != nil {
+		return nil, err
+	}
+	if _, exists := s.rdb.databases[instance.ID].get(req.Name); exists {
+		return nil, &Error{StatusCode: http.StatusConflict, Type: "conflict", Message: fmt.Sprintf("database %s already exists", req.Name)}
+	}
+
+	database := &rdb.Database{Name: req.Name, Owner: "_rdb_superadmin", Managed: true, Size: 0}
+	s.rdb.databases[instance.ID].add(database.Name, database)
+
+	return database, nil
+}
+
+func (s *Server) rdbDeleteDatabase(r *http.Request) (interface{}, error) {
+	instance, err := s.rdbInstanceIdle(r)
+	if err != nil {
+		return nil, err
+	}
+
+	name := r.PathValue("name")
+	if _, exists := s.rdb.databases[instance.ID].get(name); !exists {
+		return nil, notFoundError("database", name)
+	}
+
+	s.rdb.databases[instance.ID].delete(name)
+	for _, privilege := range s.rdb.privileges[instance.ID].list(func(privilege *rdb.Privilege) bool { return privilege.DatabaseName == name }) {
+		s.rdb.privileges[instance.ID].delete(privilege.DatabaseName + "/" + privilege.UserName)
+	}
+
+	return nil, nil
+}
+
+////
+// Privileges
+////
+
+func (s *Server) rdbListPrivileges(r *http.Request) (interface{}, error) {
+	instance, err := s.rdbInstance(r)
+	if err != nil {
+		return nil, err
+	}
+
+	f := newFilter(r)
+	privileges := s.rdb.privileges[instance.ID].list(func(privilege *rdb.Privilege) bool {
+		return f.matchString(privilege.DatabaseName, "database_name") &&
+			f.matchString(privilege.UserName, "user_name")
+	})
+
+	return paginate(r, "privileges", privileges), nil
+}
+
+func (s *Server) rdbSetPrivilege(r *http.Request) (interface{}, error) {
+	instance, err := s.rdbInstanceIdle(r)
+	if err != nil {
+		return nil, err
+	}
+
+	req := &rdb.SetPrivilegeRequest{}
+	if err := decode(r, req); err != nil {
+		return nil, err
+	}
+	if _, exists := s.rdb.databases[instance.ID].get(req.DatabaseName); !exists {
+		return nil, notFoundError("database", req.DatabaseName)
+	}
+	if _, exists := s.rdb.users[instance.ID].get(req.UserName); !exists {
+		return nil, notFoundError("user", req.UserName)
+	}
+
+	privilege := &rdb.Privilege{Permission: req.Permission, DatabaseName: req.DatabaseName, UserName: req.UserName}
+	s.rdb.privileges[instance.ID].add(privilege.DatabaseName+"/"+privilege.UserName, privilege)
+	s.rdbSetStatus(instance, rdb.InstanceStatusConfiguring)
+
+	return privilege, nil
+}
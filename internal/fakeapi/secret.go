@@ -0,0 +1,333 @@
+package fakeapi
+
+import (
+	"fmt"
+	"hash/crc32"
+	"net/http"
+	"strconv"
+
+	secret "github.com/scaleway/scaleway-sdk-go/api/secret/v1alpha1"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+)
+
+const secretPrefix = "/secret-manager/v1alpha1/regions/{region}"
+
+type secretStore struct {
+	secrets  *collection[secret.Secret]
+	versions map[string][]*secret.SecretVersion
+	// data is the payload of each version, keyed by secret ID then revision
+	data map[string]map[uint32][]byte
+}
+
+func (s *Server) registerSecret() {
+	s.secret = &secretStore{
+		secrets:  newCollection[secret.Secret](),
+		versions: map[string][]*secret.SecretVersion{},
+		data:     map[string]map[uint32][]byte{},
+	}
+
+	s.handle("GET "+secretPrefix+"/secrets", s.secretListSecrets)
+	s.handle("POST "+secretPrefix+"/secrets", s.secretCreateSecret)
+	s.handle("GET "+secretPrefix+"/secrets/{secret_id}", s.secretGetSecret)
+	s.handle("PATCH "+secretPrefix+"/secrets/{secret_id}", s.secretUpdateSecret)
+	s.handle("DELETE "+secretPrefix+"/secrets/{secret_id}", s.secretDeleteSecret)
+	s.handle("POST "+secretPrefix+"/secrets/{secret_id}/protect", s.secretProtectSecret(true))
+	s.handle("POST "+secretPrefix+"/secrets/{secret_id}/unprotect", s.secretProtectSecret(false))
+	s.handle("GET "+secretPrefix+"/secrets-by-name/{secret_name}", s.secretGetSecret)
+
+	s.handle("GET "+secretPrefix+"/secrets/{secret_id}/versions", s.secretListSecretVersions)
+	s.handle("POST "+secretPrefix+"/secrets/{secret_id}/versions", s.secretCreateSecretVersion)
+	s.handle("GET "+secretPrefix+"/secrets/{secret_id}/versions/{revision}", s.secretGetSecretVersion)
+	s.handle("PATCH "+secretPrefix+"/secrets/{secret_id}/versions/{revision}", s.secretUpdateSecretVersion)
+	s.handle("POST "+secretPrefix+"/secrets/{secret_id}/versions/{revision}/enable", s.secretSetSecretVersionStatus(secret.SecretVersionStatusEnabled))
+	s.handle("POST "+secretPrefix+"/secrets/{secret_id}/versions/{revision}/disable", s.secretSetSecretVersionStatus(secret.SecretVersionStatusDisabled))
+	s.handle("POST "+secretPrefix+"/secrets/{secret_id}/versions/{revision}/destroy", s.secretSetSecretVersionStatus(secret.SecretVersionStatusDestroyed))
+	s.handle("GET "+secretPrefix+"/secrets/{secret_id}/versions/{revision}/access", s.secretAccessSecretVersion)
+	s.handle("GET "+secretPrefix+"/secrets-by-name/{secret_name}/versions", s.secretListSecretVersions)
+	s.handle("GET "+secretPrefix+"/secrets-by-name/{secret_name}/versions/{revision}", s.secretGetSecretVersion)
+	s.handle("GET "+secretPrefix+"/secrets-by-name/{secret_name}/versions/{revision}/access", s.secretAccessSecretVersion)
+}
+
+// secretSecret returns the secret of a request, by ID or by name
+func (s *Server) secretSecret(r *http.Request) (*secret.Secret, error) {
+	if name := r.PathValue("secret_name"); name != "" {
+		projectID := r.URL.Query().Get("project_id")
+		for _, sec := range s.secret.secrets.list(nil) {
+			if sec.Name == name && sec.Region == region(r) && (projectID == "" || sec.ProjectID == projectID) {
+				return sec, nil
+			}
+		}
+
+		return nil, notFoundError("secret", name)
+	}
+
+	id := r.PathValue("secret_id")
+	sec, exists := s.secret.secrets.get(id)
+	if !exists || sec.Region != region(r) {
+		return nil, notFoundError("secret", id)
+	}
+
+	return sec, nil
+}
+
+// secretVersion returns the version of a request, the revision is either a number, "latest" or "latest_enabled"
+func (s *Server) secretVersion(r *http.Request) (*secret.Secret, *secret.SecretVersion, error) {
+	sec, err := s.secretSecret(r)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	revision := r.PathValue("revision")
+	versions := s.secret.versions[sec.ID]
+	for i := len(versions) - 1; i >= 0; i-- {
+		version := versions[i]
+		switch revision {
+		case "latest":
+			return sec, version, nil
+		case "latest_enabled":
+			if version.Status == secret.SecretVersionStatusEnabled {
+				return sec, version, nil
+			}
+		default:
+			if strconv.FormatUint(uint64(version.Revision), 10) == revision {
+				return sec, version, nil
+			}
+		}
+	}
+
+	return nil, nil, notFoundError("secret_version", revision)
+}
+
+func (s *Server) secretListSecrets(r *http.Request) (interface{}, error) {
+	f := newFilter(r)
+	secrets := s.secret.secrets.list(func(sec *secret.Secret) bool {
+		return sec.Region == region(r) &&
+			f.matchProject(sec.ProjectID, DefaultOrganizationID) &&
+			f.matchString(sec.Name, "name") &&
+			f.matchString(sec.Path, "path") &&
+			f.matchTags(sec.Tags)
+	})
+
+	return paginate(r, "secrets", secrets), nil
+}
+
+func (s *Server) secretCreateSecret(r *http.Request) (interface{}, error) {
+	req := &secret.CreateSecretRequest{}
+	if err := decode(r, req); err != nil {
+		return nil, err
+	}
+
+	if req.Name == "" {
+		return nil, invalidArgumentError("name", "required", "name is required")
+	}
+	sec := &secret.Secret{
+		ID:              newID(),
+		ProjectID:       projectOrDefault(&req.ProjectID),
+		Name:            req.Name,
+		Status:          secret.SecretStatusReady,
+		CreatedAt:       s.timePtr(),
+		UpdatedAt:       s.timePtr(),
+		Tags:            tagsOrEmpty(req.Tags),
+		Description:     req.Description,
+		Type:            req.Type,
+		Path:            stringOrDefault(req.Path, "/"),
+		ExpiresAt:       req.ExpiresAt,
+		EphemeralAction: req.EphemeralAction,
+		Region:          region(r),
+	}
+	if sec.Type == "" || sec.Type == secret.SecretTypeUnknownSecretType {
+		sec.Type = secret.SecretTypeOpaque
+	}
+	for _, existing := range s.secret.secrets.list(nil) {
+		if existing.Name == sec.Name && existing.Path == sec.Path && existing.ProjectID == sec.ProjectID && existing.Region == sec.Region {
+			return nil, &Error{StatusCode: http.StatusConflict, Type: "conflict", Message: fmt.Sprintf("secret %s already exists in %s", sec.Name, sec.Path)}
+		}
+	}
+	s.secret.secrets.add(sec.ID, sec)
+	s.secret.data[sec.ID] = map[uint32][]byte{}
+
+	return sec, nil
+}
+
+func (s *Server) secretGetSecret(r *http.Request) (interface{}, error) {
+	return s.secretSecret(r)
+}
+
+func (s *Server) secretUpdateSecret(r *http.Request) (interface{}, error) {
+	sec, err := s.secretSecret(r)
+	if err != nil {
+		return nil, err
+	}
+
+	req := &secret.UpdateSecretRequest{}
+	if err := decode(r, req); err != nil {
+		return nil, err
+	}
+
+	if req.Name != nil {
+		sec.Name = *req.Name
+	}
+	if req.Tags != nil {
+		sec.Tags = *req.Tags
+	}
+	if req.Description != nil {
+		sec.Description = req.Description
+	}
+	if req.Path != nil {
+		sec.Path = *req.Path
+	}
+	sec.UpdatedAt = s.timePtr()
+
+	return sec, nil
+}
+
+func (s *Server) secretDeleteSecret(r *http.Request) (interface{}, error) {
+	sec, err := s.secretSecret(r)
+	if err != nil {
+		return nil, err
+	}
+	if sec.IsProtected {
+		return nil, preconditionFailedError("secret_unprotected", fmt.Sprintf("secret %s is protected, unprotect it before deleting it", sec.ID))
+	}
+
+	s.secret.secrets.delete(sec.ID)
+	delete(s.secret.versions, sec.ID)
+	delete(s.secret.data, sec.ID)
+
+	return nil, nil
+}
+
+func (s *Server) secretProtectSecret(protected bool) handlerFunc {
+	return func(r *http.Request) (interface{}, error) {
+		sec, err := s.secretSecret(r)
+		if err != nil {
+			return nil, err
+		}
+
+		sec.IsProtected = protected
+		sec.UpdatedAt = s.timePtr()
+
+		return sec, nil
+	}
+}
+
+func (s *Server) secretListSecretVersions(r *http.Request) (interface{}, error) {
+	sec, err := s.secretSecret(r)
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := newFilter(r).query["status"]
+	versions := []*secret.SecretVersion{}
+	for _, version := range s.secret.versions[sec.ID] {
+		if len(statuses) == 0 || containsString(statuses, string(version.Status)) {
+			versions = append(versions, version)
+		}
+	}
+
+	return paginate(r, "versions", versions), nil
+}
+
+func (s *Server) secretCreateSecretVersion(r *http.Request) (interface{}, error) {
+	sec, err := s.secretSecret(r)
+	if err != nil {
+		return nil, err
+	}
+
+	req := &secret.CreateSecretVersionRequest{}
+	if err := decode(r, req); err != nil {
+		return nil, err
+	}
+
+	if req.DataCrc32 != nil && crc32.ChecksumIEEE(req.Data) != *req.DataCrc32 {
+		return nil, invalidArgumentError("data_crc32", "constraint", "data_crc32 does not match the data")
+	}
+
+	versions := s.secret.versions[sec.ID]
+	for _, version := range versions {
+		version.IsLatest = false
+		if req.DisablePrevious != nil && *req.DisablePrevious && version.Status == secret.SecretVersionStatusEnabled {
+			version.Status = secret.SecretVersionStatusDisabled
+		}
+	}
+
+	version := &secret.SecretVersion{
+		Revision:    uint32(len(versions)) + 1,
+		SecretID:    sec.ID,
+		Status:      secret.SecretVersionStatusEnabled,
+		CreatedAt:   s.timePtr(),
+		UpdatedAt:   s.timePtr(),
+		Description: req.Description,
+		IsLatest:    true,
+	}
+	s.secret.versions[sec.ID] = append(versions, version)
+	s.secret.data[sec.ID][version.Revision] = req.Data
+	sec.VersionCount = uint32(len(s.secret.versions[sec.ID]))
+	sec.UpdatedAt = s.timePtr()
+
+	return version, nil
+}
+
+func (s *Server) secretGetSecretVersion(r *http.Request) (interface{}, error) {
+	_, version, err := s.secretVersion(r)
+
+	return version, err
+}
+
+func (s *Server) secretUpdateSecretVersion(r *http.Request) (interface{}, error) {
+	_, version, err := s.secretVersion(r)
+	if err != nil {
+		return nil, err
+	}
+
+	req := &secret.UpdateSecretVersionRequest{}
+	if err := decode(r, req); err != nil {
+		return nil, err
+	}
+
+	if req.Description != nil {
+		version.Description = req.Description
+	}
+	version.UpdatedAt = s.timePtr()
+
+	return version, nil
+}
+
+func (s *Server) secretSetSecretVersionStatus(status secret.SecretVersionStatus) handlerFunc {
+	return func(r *http.Request) (interface{}, error) {
+		sec, version, err := s.secretVersion(r)
+		if err != nil {
+			return nil, err
+		}
+		if version.Status == secret.SecretVersionStatusDestroyed {
+			return nil, preconditionFailedError("secret_version_not_destroyed", fmt.Sprintf("version %d is destroyed", version.Revision))
+		}
+
+		version.Status = status
+		version.UpdatedAt = s.timePtr()
+		if status == secret.SecretVersionStatusDestroyed {
+			delete(s.secret.data[sec.ID], version.Revision)
+		}
+
+		return version, nil
+	}
+}
+
+func (s *Server) secretAccessSecretVersion(r *http.Request) (interface{}, error) {
+	sec, version, err := s.secretVersion(r)
+	if err != nil {
+		return nil, err
+	}
+	if version.Status != secret.SecretVersionStatusEnabled {
+		return nil, preconditionFailedError("secret_version_enabled", fmt.Sprintf("version %d is %s", version.Revision, version.Status))
+	}
+
+	data := s.secret.data[sec.ID][version.Revision]
+
+	return &secret.AccessSecretVersionResponse{
+		SecretID:  sec.ID,
+		Revision:  version.Revision,
+		Data:      data,
+		DataCrc32: scw.Uint32Ptr(crc32.ChecksumIEEE(data)),
+	}, nil
+}
@@ -0,0 +1,289 @@
+package fakeapi
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"strconv"
+
+	vpc "github.com/scaleway/scaleway-sdk-go/api/vpc/v2"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+)
+
+const vpcPrefix = "/vpc/v2/regions/{region}"
+
+type vpcStore struct {
+	vpcs            *collection[vpc.VPC]
+	privateNetworks *collection[vpc.PrivateNetwork]
+
+	// lastSubnet is the index of the last generated subnet, subnets are generated sequentially
+	lastSubnet int
+}
+
+func (s *Server) registerVPC() {
+	s.vpc = &vpcStore{
+		vpcs:            newCollection[vpc.VPC](),
+		privateNetworks: newCollection[vpc.PrivateNetwork](),
+	}
+
+	s.handle("GET "+vpcPrefix+"/vpcs", s.vpcListVPCs)
+	s.handle("POST "+vpcPrefix+"/vpcs", s.vpcCreateVPC)
+	s.handle("GET "+vpcPrefix+"/vpcs/{vpc_id}", s.vpcGetVPC)
+	s.handle("PATCH "+vpcPrefix+"/vpcs/{vpc_id}", s.vpcUpdateVPC)
+	s.handle("DELETE "+vpcPrefix+"/vpcs/{vpc_id}", s.vpcDeleteVPC)
+
+	s.handle("GET "+vpcPrefix+"/private-networks", s.vpcListPrivateNetworks)
+	s.handle("POST "+vpcPrefix+"/private-networks", s.vpcCreatePrivateNetwork)
+	s.handle("GET "+vpcPrefix+"/private-networks/{private_network_id}", s.vpcGetPrivateNetwork)
+	s.handle("PATCH "+vpcPrefix+"/private-networks/{private_network_id}", s.vpcUpdatePrivateNetwork)
+	s.handle("DELETE "+vpcPrefix+"/private-networks/{private_network_id}", s.vpcDeletePrivateNetwork)
+}
+
+////
+// VPCs
+////
+
+func (s *Server) vpcNewVPC(region scw.Region, projectID, name string, tags []string) *vpc.VPC {
+	if tags == nil {
+		tags = []string{}
+	}
+
+	v := &vpc.VPC{
+		ID:             newID(),
+		Name:           name,
+		OrganizationID: DefaultOrganizationID,
+		ProjectID:      projectID,
+		Region:         region,
+		Tags:           tags,
+		CreatedAt:      s.timePtr(),
+		UpdatedAt:      s.timePtr(),
+	}
+	s.vpc.vpcs.add(v.ID, v)
+
+	return v
+}
+
+// vpcDefaultVPC returns the default VPC of a project in a region, it is created on first use like the real API does
+func (s *Server) vpcDefaultVPC(region scw.Region, projectID string) *vpc.VPC {
+	for _, v := range s.vpc.vpcs.list(nil) {
+		if v.Region == region && v.ProjectID == projectID && v.IsDefault {
+			return v
+		}
+	}
+
+	v := s.vpcNewVPC(region, projectID, "default", nil)
+	v.IsDefault = true
+
+	return v
+}
+
+func (s *Server) vpcVPC(r *http.Request) (*vpc.VPC, error) {
+	id := r.PathValue("vpc_id")
+	v, exists := s.vpc.vpcs.get(id)
+	if !exists || v.Region != region(r) {
+		return nil, notFoundError("vpc", id)
+	}
+
+	return v, nil
+}
+
+func (s *Server) vpcListVPCs(r *http.Request) (interface{}, error) {
+	f := newFilter(r)
+	vpcs := s.vpc.vpcs.list(func(v *vpc.VPC) bool {
+		return v.Region == region(r) &&
+			f.matchName(v.Name) &&
+			f.matchProject(v.ProjectID, v.OrganizationID) &&
+			f.matchTags(v.Tags) &&
+			f.matchString(strconv.FormatBool(v.IsDefault), "is_default")
+	})
+
+	return paginate(r, "vpcs", vpcs), nil
+}
+
+func (s *Server) vpcCreateVPC(r *http.Request) (interface{}, error) {
+	req := &vpc.CreateVPCRequest{}
+	if err := decode(r, req); err != nil {
+		return nil, err
+	}
+
+	return s.vpcNewVPC(region(r), projectOrDefault(&req.ProjectID), req.Name, req.Tags), nil
+}
+
+func (s *Server) vpcGetVPC(r *http.Request) (interface{}, error) {
+	return s.vpcVPC(r)
+}
+
+func (s *Server) vpcUpdateVPC(r *http.Request) (interface{}, error) {
+	v, err := s.vpcVPC(r)
+	if err != nil {
+		return nil, err
+	}
+
+	req := &vpc.UpdateVPCRequest{}
+	if err := decode(r, req); err != nil {
+		return nil, err
+	}
+
+	if req.Name != nil {
+		v.Name = *req.Name
+	}
+	if req.Tags != nil {
+		v.Tags = *req.Tags
+	}
+	v.UpdatedAt = s.timePtr()
+
+	return v, nil
+}
+
+func (s *Server) vpcDeleteVPC(r *http.Request) (interface{}, error) {
+	v, err := s.vpcVPC(r)
+	if err != nil {
+		return nil, err
+	}
+	if v.PrivateNetworkCount > 0 {
+		return nil, preconditionFailedError("resource_still_in_use", "vpc still has private networks")
+	}
+
+	s.vpc.vpcs.delete(v.ID)
+
+	return nil, nil
+}
+
+////
+// Private networks
+////
+
+// vpcNewSubnets returns an IPv4 and an IPv6 subnet not used by any other private network
+func (s *Server) vpcNewSubnets() []scw.IPNet {
+	s.vpc.lastSubnet++
+	_, ipv4, _ := net.ParseCIDR(fmt.Sprintf("172.16.%d.0/22", (s.vpc.lastSubnet*4)%256))
+	_, ipv6, _ := net.ParseCIDR(fmt.Sprintf("fd5f:519c:6d46:%x::/64", s.vpc.lastSubnet))
+
+	return []scw.IPNet{{IPNet: *ipv4}, {IPNet: *ipv6}}
+}
+
+func (s *Server) vpcPrivateNetwork(r *http.Request) (*vpc.PrivateNetwork, error) {
+	id := r.PathValue("private_network_id")
+	privateNetwork, exists := s.vpc.privateNetworks.get(id)
+	if !exists || privateNetwork.Region != region(r) {
+		return nil, notFoundError("private_network", id)
+	}
+
+	return privateNetwork, nil
+}
+
+func (s *Server) vpcListPrivateNetworks(r *http.Request) (interface{}, error) {
+	f := newFilter(r)
+	privateNetworks := s.vpc.privateNetworks.list(func(privateNetwork *vpc.PrivateNetwork) bool {
+		return privateNetwork.Region == region(r) &&
+			f.matchName(privateNetwork.Name) &&
+			f.matchProject(privateNetwork.ProjectID, privateNetwork.OrganizationID) &&
+			f.matchTags(privateNetwork.Tags) &&
+			f.matchString(privateNetwork.VpcID, "vpc_id")
+	})
+
+	return paginate(r, "private_networks", privateNetworks), nil
+}
+
+func (s *Server) vpcCreatePrivateNetwork(r *http.Request) (interface{}, error) {
+	req := &vpc.CreatePrivateNetworkRequest{}
+	if err := decode(r, req); err != nil {
+		return nil, err
+	}
+
+	projectID := projectOrDefault(&req.ProjectID)
+	var v *vpc.VPC
+	if req.VpcID != nil && *req.VpcID != "" {
+		existingVPC, exists := s.vpc.vpcs.get(*req.VpcID)
+		if !exists {
+			return nil, notFoundError("vpc", *req.VpcID)
+		}
+		v = existingVPC
+	} else {
+		v = s.vpcDefaultVPC(region(r), projectID)
+	}
+
+	subnets := req.Subnets
+	if len(subnets) == 0 {
+		subnets = s.vpcNewSubnets()
+	}
+
+	privateNetwork := &vpc.PrivateNetwork{
+		ID:             newID(),
+		Name:           req.Name,
+		OrganizationID: DefaultOrganizationID,
+		ProjectID:      projectID,
+		Region:         region(r),
+		Tags:           req.Tags,
+		CreatedAt:      s.timePtr(),
+		UpdatedAt:      s.timePtr(),
+		Subnets:        make([]*vpc.Subnet, 0, len(subnets)),
+		VpcID:          v.ID,
+		DHCPEnabled:    true,
+	}
+	if privateNetwork.Tags == nil {
+		privateNetwork.Tags = []string{}
+	}
+	for _, subnet := range subnets {
+		privateNetwork.Subnets = append(privateNetwork.Subnets, &vpc.Subnet{
+			ID:        newID(),
+			CreatedAt: s.timePtr(),
+			UpdatedAt: s.timePtr(),
+			Subnet:    subnet,
+		})
+	}
+
+	s.vpc.privateNetworks.add(privateNetwork.ID, privateNetwork)
+	v.PrivateNetworkCount++
+
+	return privateNetwork, nil
+}
+
+func (s *Server) vpcGetPrivateNetwork(r *http.Request) (interface{}, error) {
+	return s.vpcPrivateNetwork(r)
+}
+
+func (s *Server) vpcUpdatePrivateNetwork(r *http.Request) (interface{}, error) {
+	privateNetwork, err := s.vpcPrivateNetwork(r)
+	if err != nil {
+		return nil, err
+	}
+
+	req := &vpc.UpdatePrivateNetworkRequest{}
+	if err := decode(r, req); err != nil {
+		return nil, err
+	}
+
+	if req.Name != nil {
+		privateNetwork.Name = *req.Name
+	}
+	if req.Tags != nil {
+		privateNetwork.Tags = *req.Tags
+	}
+	privateNetwork.UpdatedAt = s.timePtr()
+
+	return privateNetwork, nil
+}
+
+func (s *Server) vpcDeletePrivateNetwork(r *http.Request) (interface{}, error) {
+	privateNetwork, err := s.vpcPrivateNetwork(r)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, nic := range s.instance.privateNICs.list(nil) {
+		if nic.PrivateNetworkID == privateNetwork.ID {
+			return nil, preconditionFailedError("resource_still_in_use", "private network is still attached to instance servers")
+		}
+	}
+	if s.ipamHasPrivateNetworkIPs(privateNetwork.ID) {
+		return nil, preconditionFailedError("resource_still_in_use", "private network still has reserved IPs")
+	}
+
+	s.vpc.privateNetworks.delete(privateNetwork.ID)
+	if v, exists := s.vpc.vpcs.get(privateNetwork.VpcID); exists {
+		v.PrivateNetworkCount--
+	}
+
+	return nil, nil
+}
@@ -1,88 +1,154 @@
 package internal
 
 import (
+	"context"
+	"errors"
 	"sync"
 )
 
-type WorkerPoolTask func() error
+// WorkerPoolTask is a task run by a worker, it should return early once its context is done.
+type WorkerPoolTask[T any] func(ctx context.Context) (T, error)
 
-type WorkerPool struct {
-	tasksToDispatch   chan WorkerPoolTask
-	tasksToRun        chan WorkerPoolTask
-	errors            []error
-	errorsMutex       sync.Mutex
-	tasksWaitingGroup sync.WaitGroup
+// WorkerPoolOption configures a WorkerPool
+type WorkerPoolOption func(*workerPoolConfig)
+
+type workerPoolConfig struct {
+	stopOnError bool
+	queueSize   int
+}
+
+// WithStopOnError stops the pool on the first error: the context of the running tasks is canceled
+// and the queued tasks are not run, like an errgroup.
+func WithStopOnError() WorkerPoolOption {
+	return func(c *workerPoolConfig) {
+		c.stopOnError = true
+	}
+}
+
+// WithQueueSize sets the number of tasks that can be queued before AddTask blocks, it defaults to the pool size.
+func WithQueueSize(size int) WorkerPoolOption {
+	return func(c *workerPoolConfig) {
+		c.queueSize = size
+	}
+}
+
+type workerPoolTask[T any] struct {
+	index int
+	run   WorkerPoolTask[T]
 }
 
-func NewWorkerPool(size int) *WorkerPool {
-	p := &WorkerPool{
-		tasksToDispatch: make(chan WorkerPoolTask),
-		tasksToRun:      make(chan WorkerPoolTask, size),
+// WorkerPool runs tasks concurrently with a fixed number of workers and collects their results.
+type WorkerPool[T any] struct {
+	ctx         context.Context
+	cancel      context.CancelFunc
+	stopOnError bool
+
+	tasks   chan workerPoolTask[T]
+	workers sync.WaitGroup
+
+	mutex   sync.Mutex
+	added   int
+	failed  bool
+	results map[int]T
+	errors  []error
+}
+
+// NewWorkerPool starts a pool of size workers, at least one. Tasks are stopped when ctx is canceled.
+func NewWorkerPool[T any](ctx context.Context, size int, opts ...WorkerPoolOption) *WorkerPool[T] {
+	if size < 1 {
+		size = 1
+	}
+
+	config := &workerPoolConfig{queueSize: size}
+	for _, opt := range opts {
+		opt(config)
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	p := &WorkerPool[T]{
+		ctx:         ctx,
+		cancel:      cancel,
+		stopOnError: config.stopOnError,
+		tasks:       make(chan workerPoolTask[T], config.queueSize),
+		results:     map[int]T{},
 	}
 
+	p.workers.Add(size)
 	for i := 0; i < size; i++ {
 		go p.worker()
 	}
 
-	go p.dispatcher()
-
 	return p
 }
 
-func (p *WorkerPool) dispatcher() {
-	var pendingTasks []WorkerPoolTask
+func (p *WorkerPool[T]) worker() {
+	defer p.workers.Done()
 
-	for {
-		if len(pendingTasks) > 0 {
-			select {
-			case p.tasksToRun <- pendingTasks[0]:
-				pendingTasks = pendingTasks[1:]
-			default:
-			}
+	for task := range p.tasks {
+		// Queued tasks are skipped once the pool is stopped
+		if p.ctx.Err() != nil {
+			continue
 		}
 
-		select {
-		case task, ok := <-p.tasksToDispatch:
-			if !ok {
-				if len(pendingTasks) > 0 {
-					continue
-				}
-
-				close(p.tasksToRun)
-				return
-			}
+		result, err := task.run(p.ctx)
 
-			select {
-			case p.tasksToRun <- task:
-			default:
-				pendingTasks = append(pendingTasks, task)
-			}
+		p.mutex.Lock()
+		switch {
+		case err == nil:
+			p.results[task.index] = result
+		case p.ctx.Err() != nil && (errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded)):
+			// The task was stopped with the pool, the cause is reported instead
 		default:
+			p.errors = append(p.errors, err)
+			if p.stopOnError && !p.failed {
+				p.failed = true
+				p.cancel()
+			}
 		}
+		p.mutex.Unlock()
 	}
 }
 
-func (p *WorkerPool) worker() {
-	for task := range p.tasksToRun {
-		err := task()
-		if err != nil {
-			p.errorsMutex.Lock()
-			p.errors = append(p.errors, err)
-			p.errorsMutex.Unlock()
-		}
+// AddTask queues a task, blocking while the queue is full.
+// It returns false if the task was not queued because the pool is stopped.
+// It must not be called after CloseAndWait.
+func (p *WorkerPool[T]) AddTask(task WorkerPoolTask[T]) bool {
+	if p.ctx.Err() != nil {
+		return false
+	}
 
-		p.tasksWaitingGroup.Done()
+	p.mutex.Lock()
+	index := p.added
+	p.added++
+	p.mutex.Unlock()
+
+	select {
+	case p.tasks <- workerPoolTask[T]{index: index, run: task}:
+		return true
+	case <-p.ctx.Done():
+		return false
 	}
 }
 
-func (p *WorkerPool) AddTask(task WorkerPoolTask) {
-	p.tasksWaitingGroup.Add(1)
-	p.tasksToDispatch <- task
-}
+// CloseAndWait waits for the queued tasks to complete.
+// It returns the results of the successful tasks, in the order they were added, and the errors of the failed ones.
+// If the context of the pool was canceled, its error is returned with the errors of the tasks.
+func (p *WorkerPool[T]) CloseAndWait() ([]T, []error) {
+	close(p.tasks)
+	p.workers.Wait()
 
-func (p *WorkerPool) CloseAndWait() []error {
-	close(p.tasksToDispatch)
-	p.tasksWaitingGroup.Wait()
+	errs := p.errors
+	if err := p.ctx.Err(); err != nil && !p.failed {
+		errs = append(errs, err)
+	}
+	p.cancel()
+
+	results := make([]T, 0, len(p.results))
+	for i := 0; i < p.added; i++ {
+		if result, ok := p.results[i]; ok {
+			results = append(results, result)
+		}
+	}
 
-	return p.errors
+	return results, errs
 }
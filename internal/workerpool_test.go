@@ -1,8 +1,10 @@
 package internal
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -10,69 +12,85 @@ import (
 )
 
 func TestWorkerPoolSimple(t *testing.T) {
-	pool := NewWorkerPool(2)
+	pool := NewWorkerPool[any](context.Background(), 2)
 
-	pool.AddTask(func() error {
-		return nil
+	pool.AddTask(func(_ context.Context) (any, error) {
+		return nil, nil
 	})
 
-	pool.AddTask(func() error {
-		return errors.New("error")
+	pool.AddTask(func(_ context.Context) (any, error) {
+		return nil, errors.New("error")
 	})
 
-	pool.AddTask(func() error {
-		return nil
+	pool.AddTask(func(_ context.Context) (any, error) {
+		return nil, nil
 	})
 
-	errs := pool.CloseAndWait()
+	_, errs := pool.CloseAndWait()
 
 	assert.Equal(t, 1, len(errs))
 	assert.Equal(t, "error", errs[0].Error())
 }
 
+func TestWorkerPoolMinimumSize(t *testing.T) {
+	pool := NewWorkerPool[int](context.Background(), 0)
+
+	for i := 0; i < 3; i++ {
+		i := i
+		assert.True(t, pool.AddTask(func(_ context.Context) (int, error) {
+			return i, nil
+		}))
+	}
+
+	results, errs := pool.CloseAndWait()
+
+	assert.Empty(t, errs)
+	assert.Equal(t, []int{0, 1, 2}, results)
+}
+
 func TestWorkerPoolWaitTime(t *testing.T) {
-	pool := NewWorkerPool(2)
+	pool := NewWorkerPool[any](context.Background(), 2)
 
-	pool.AddTask(func() error {
+	pool.AddTask(func(_ context.Context) (any, error) {
 		time.Sleep(50 * time.Millisecond) // lintignore: R018
-		return nil
+		return nil, nil
 	})
 
-	pool.AddTask(func() error {
+	pool.AddTask(func(_ context.Context) (any, error) {
 		time.Sleep(50 * time.Millisecond) // lintignore: R018
-		return errors.New("error")
+		return nil, errors.New("error")
 	})
 
-	pool.AddTask(func() error {
+	pool.AddTask(func(_ context.Context) (any, error) {
 		time.Sleep(50 * time.Millisecond) // lintignore: R018
-		return nil
+		return nil, nil
 	})
 
-	errs := pool.CloseAndWait()
+	_, errs := pool.CloseAndWait()
 
 	assert.Equal(t, 1, len(errs))
 	assert.Equal(t, "error", errs[0].Error())
 }
 
 func TestWorkerPoolWaitTimeMultiple(t *testing.T) {
-	pool := NewWorkerPool(5)
+	pool := NewWorkerPool[any](context.Background(), 5)
 	iterations := 20
 
 	for i := 0; i < iterations; i++ {
 		copyOfI := i
 
-		pool.AddTask(func() error {
+		pool.AddTask(func(_ context.Context) (any, error) {
 			time.Sleep(100 * time.Millisecond) // lintignore: R018
 
 			if copyOfI%2 == 0 {
-				return fmt.Errorf("error %d", copyOfI)
+				return nil, fmt.Errorf("error %d", copyOfI)
 			}
 
-			return nil
+			return nil, nil
 		})
 	}
 
-	errs := pool.CloseAndWait()
+	_, errs := pool.CloseAndWait()
 
 	assert.Equal(t, iterations/2, len(errs))
 
@@ -90,3 +108,103 @@ func TestWorkerPoolWaitTimeMultiple(t *testing.T) {
 		}
 	}
 }
+
+func TestWorkerPoolResults(t *testing.T) {
+	pool := NewWorkerPool[int](context.Background(), 3)
+
+	for i := 0; i < 10; i++ {
+		copyOfI := i
+
+		pool.AddTask(func(_ context.Context) (int, error) {
+			time.Sleep(time.Duration(10-copyOfI) * time.Millisecond) // lintignore: R018
+			if copyOfI == 5 {
+				return 0, errors.New("error")
+			}
+
+			return copyOfI * 2, nil
+		})
+	}
+
+	results, errs := pool.CloseAndWait()
+
+	assert.Equal(t, []int{0, 2, 4, 6, 8, 12, 14, 16, 18}, results)
+	assert.Equal(t, 1, len(errs))
+}
+
+func TestWorkerPoolStopOnError(t *testing.T) {
+	pool := NewWorkerPool[any](context.Background(), 2, WithStopOnError())
+	var ran atomic.Int32
+
+	pool.AddTask(func(_ context.Context) (any, error) {
+		ran.Add(1)
+		return nil, errors.New("error")
+	})
+
+	pool.AddTask(func(ctx context.Context) (any, error) {
+		ran.Add(1)
+		<-ctx.Done()
+		return nil, ctx.Err()
+	})
+
+	for i := 0; i < 20; i++ {
+		pool.AddTask(func(_ context.Context) (any, error) {
+			ran.Add(1)
+			time.Sleep(10 * time.Millisecond) // lintignore: R018
+			return nil, nil
+		})
+	}
+
+	_, errs := pool.CloseAndWait()
+
+	assert.Equal(t, 1, len(errs))
+	assert.Equal(t, "error", errs[0].Error())
+	assert.Less(t, ran.Load(), int32(22))
+	assert.False(t, pool.AddTask(func(_ context.Context) (any, error) { return nil, nil }))
+}
+
+func TestWorkerPoolCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	pool := NewWorkerPool[any](ctx, 1)
+
+	pool.AddTask(func(ctx context.Context) (any, error) {
+		cancel()
+		<-ctx.Done()
+		return nil, ctx.Err()
+	})
+
+	_, errs := pool.CloseAndWait()
+
+	assert.Equal(t, 1, len(errs))
+	assert.ErrorIs(t, errs[0], context.Canceled)
+}
+
+func TestWorkerPoolBoundedQueue(t *testing.T) {
+	pool := NewWorkerPool[any](context.Background(), 1, WithQueueSize(1))
+	release := make(chan struct{})
+
+	// The first task is running, the second one is queued
+	for i := 0; i < 2; i++ {
+		pool.AddTask(func(_ context.Context) (any, error) {
+			<-release
+			return nil, nil
+		})
+	}
+
+	added := make(chan struct{})
+	go func() {
+		pool.AddTask(func(_ context.Context) (any, error) { return nil, nil })
+		close(added)
+	}()
+
+	select {
+	case <-added:
+		t.Fatal("task should not be added while the queue is full")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	close(release)
+	<-added
+
+	_, errs := pool.CloseAndWait()
+	assert.Empty(t, errs)
+}
@@ -5,7 +5,7 @@ import (
 	"flag"
 	"log"
 
-	"github.com/hashicorp/terraform-plugin-sdk/v2/plugin"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6/tf6server"
 	"github.com/scaleway/terraform-provider-scaleway/v2/scaleway"
 )
 
@@ -15,17 +15,19 @@ func main() {
 	flag.BoolVar(&debugMode, "debuggable", false, "set to true to run the provider with support for debuggers like delve")
 	flag.Parse()
 
+	ctx := context.Background()
+	providerServer, err := scaleway.ProviderServerFactory(ctx, scaleway.DefaultProviderConfig())
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	var serveOpts []tf6server.ServeOpt
 	if debugMode {
-		err := plugin.Debug(context.Background(), "registry.terraform.io/scaleway/scaleway",
-			&plugin.ServeOpts{
-				ProviderFunc: scaleway.Provider(scaleway.DefaultProviderConfig()),
-			})
-		if err != nil {
-			log.Println(err.Error())
-		}
-	} else {
-		plugin.Serve(&plugin.ServeOpts{
-			ProviderFunc: scaleway.Provider(scaleway.DefaultProviderConfig()),
-		})
+		serveOpts = append(serveOpts, tf6server.WithManagedDebug())
+	}
+
+	err = tf6server.Serve("registry.terraform.io/scaleway/scaleway", providerServer, serveOpts...)
+	if err != nil {
+		log.Fatal(err)
 	}
 }
@@ -14,9 +14,9 @@ func TestAccScalewayDataSourceBaremetalOS_Basic(t *testing.T) {
 	defer tt.Cleanup()
 
 	resource.ParallelTest(t, resource.TestCase{
-		PreCheck:          func() { testAccPreCheck(t) },
-		ProviderFactories: tt.ProviderFactories,
-		CheckDestroy:      testAccCheckScalewayBaremetalServerDestroy(tt),
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: tt.ProviderFactories,
+		CheckDestroy:             testAccCheckScalewayBaremetalServerDestroy(tt),
 		Steps: []resource.TestStep{
 			{
 				Config: `
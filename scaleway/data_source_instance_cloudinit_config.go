@@ -0,0 +1,179 @@
+package scaleway
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"mime/multipart"
+	"net/textproto"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"gopkg.in/yaml.v3"
+)
+
+const instanceCloudInitCloudConfigContentType = "text/cloud-config"
+
+func dataSourceScalewayInstanceCloudInitConfig() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceScalewayInstanceCloudInitConfigRead,
+		Schema: map[string]*schema.Schema{
+			"part": {
+				Type:        schema.TypeList,
+				Required:    true,
+				MinItems:    1,
+				Description: "The parts of the multipart document, processed by cloud-init in this order",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"content_type": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Default:     instanceCloudInitCloudConfigContentType,
+							Description: "The MIME type of the part",
+							ValidateFunc: validation.StringInSlice([]string{
+								instanceCloudInitCloudConfigContentType,
+								"text/x-shellscript",
+								"text/x-shellscript-per-boot",
+								"text/x-shellscript-per-instance",
+								"text/x-shellscript-per-once",
+								"text/x-include-url",
+								"text/x-include-once-url",
+								"text/cloud-boothook",
+								"text/part-handler",
+								"text/jinja2",
+							}, false),
+						},
+						"content": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "The content of the part, cloud-config parts must be valid YAML",
+						},
+						"filename": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "The filename of the part, used by cloud-init for scripts",
+						},
+						"merge_type": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "How cloud-init merges this cloud-config part with the previous ones, e.g. list(append)+dict(recurse_array)+str()",
+						},
+					},
+				},
+			},
+			"gzip": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Compress the document with gzip, it must be base64 encoded and cannot be used as cloud_init of a server",
+			},
+			"base64_encode": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Encode the document in base64",
+			},
+			"boundary": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "MIMEBOUNDARY",
+				Description:  "The boundary between the parts of the document",
+				ValidateFunc: validation.StringLenBetween(1, 70),
+			},
+			"rendered": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The multipart document, to be used as cloud_init of a server when it is neither compressed nor base64 encoded",
+			},
+		},
+	}
+}
+
+func dataSourceScalewayInstanceCloudInitConfigRead(_ context.Context, d *schema.ResourceData, _ interface{}) diag.Diagnostics {
+	if d.Get("gzip").(bool) && !d.Get("base64_encode").(bool) {
+		return diag.FromErr(errors.New("gzip compressed documents must be base64 encoded"))
+	}
+
+	rendered, err := renderInstanceCloudInitConfig(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	hash := sha256.Sum256([]byte(rendered))
+	d.SetId(hex.EncodeToString(hash[:]))
+	_ = d.Set("rendered", rendered)
+
+	return nil
+}
+
+// renderInstanceCloudInitConfig assembles the parts in a multipart MIME document, as expected by cloud-init.
+// The metadata service serves the cloud_init of a server unchanged, a compressed or base64 encoded document is meant for other consumers.
+func renderInstanceCloudInitConfig(d *schema.ResourceData) (string, error) {
+	var document bytes.Buffer
+	writer := multipart.NewWriter(&document)
+	if err := writer.SetBoundary(d.Get("boundary").(string)); err != nil {
+		return "", err
+	}
+
+	fmt.Fprintf(&document, "Content-Type: multipart/mixed; boundary=%q\r\nMIME-Version: 1.0\r\n\r\n", writer.Boundary())
+
+	for i, rawPart := range d.Get("part").([]interface{}) {
+		part := rawPart.(map[string]interface{})
+		contentType := part["content_type"].(string)
+		content := part["content"].(string)
+
+		if contentType == instanceCloudInitCloudConfigContentType {
+			cloudConfig := map[string]interface{}{}
+			if err := yaml.Unmarshal([]byte(content), &cloudConfig); err != nil {
+				return "", fmt.Errorf("part %d is not a valid cloud-config: %w", i, err)
+			}
+		}
+
+		header := textproto.MIMEHeader{}
+		header.Set("Content-Type", contentType)
+		header.Set("MIME-Version", "1.0")
+		header.Set("Content-Transfer-Encoding", "7bit")
+		if filename := part["filename"].(string); filename != "" {
+			header.Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+		}
+		if mergeType := part["merge_type"].(string); mergeType != "" {
+			header.Set("X-Merge-Type", mergeType)
+		}
+
+		partWriter, err := writer.CreatePart(header)
+		if err != nil {
+			return "", err
+		}
+		if _, err := partWriter.Write([]byte(content)); err != nil {
+			return "", err
+		}
+	}
+
+	if err := writer.Close(); err != nil {
+		return "", err
+	}
+
+	if !d.Get("gzip").(bool) {
+		if d.Get("base64_encode").(bool) {
+			return base64.StdEncoding.EncodeToString(document.Bytes()), nil
+		}
+		return document.String(), nil
+	}
+
+	var compressed bytes.Buffer
+	gzipWriter := gzip.NewWriter(&compressed)
+	if _, err := gzipWriter.Write(document.Bytes()); err != nil {
+		return "", err
+	}
+	if err := gzipWriter.Close(); err != nil {
+		return "", err
+	}
+
+	return base64.StdEncoding.EncodeToString(compressed.Bytes()), nil
+}
@@ -0,0 +1,67 @@
+package scaleway
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccScalewayDataSourceInstanceCloudInitConfig_Basic(t *testing.T) {
+	tt := NewTestToolsWithFakeAPI(t)
+	defer tt.Cleanup()
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: tt.ProviderFactories,
+		CheckDestroy:             testAccCheckScalewayInstanceServerDestroy(tt),
+		Steps: []resource.TestStep{
+			{
+				Config: `
+					data "scaleway_instance_cloudinit_config" "main" {
+						part {
+							content_type = "text/cloud-config"
+							content      = "packages:\n  - nginx\n"
+							merge_type   = "list(append)+dict(recurse_array)+str()"
+						}
+
+						part {
+							content_type = "text/x-shellscript"
+							filename     = "setup.sh"
+							content      = "#!/bin/sh\necho hello\n"
+						}
+					}
+
+					data "scaleway_instance_cloudinit_config" "compressed" {
+						gzip          = true
+						base64_encode = true
+
+						part {
+							content = "packages:\n  - nginx\n"
+						}
+					}
+
+					resource "scaleway_instance_server" "main" {
+						image      = "ubuntu_jammy"
+						type       = "DEV1-S"
+						cloud_init = data.scaleway_instance_cloudinit_config.main.rendered
+					}
+				`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestMatchResourceAttr("data.scaleway_instance_cloudinit_config.main", "rendered",
+						regexp.MustCompile(`(?s)^Content-Type: multipart/mixed; boundary="MIMEBOUNDARY"\r\nMIME-Version: 1.0\r\n.*Content-Type: text/cloud-config\r\n.*X-Merge-Type: list\(append\)\+dict\(recurse_array\)\+str\(\)\r\n.*packages:\n  - nginx\n.*Content-Disposition: attachment; filename="setup.sh"\r\n.*Content-Type: text/x-shellscript\r\n.*echo hello\n\r\n--MIMEBOUNDARY--\r\n$`)),
+					resource.TestMatchResourceAttr("data.scaleway_instance_cloudinit_config.compressed", "rendered", regexp.MustCompile(`^H4sI[A-Za-z0-9+/=]+$`)),
+					resource.TestCheckResourceAttrPair("scaleway_instance_server.main", "cloud_init", "data.scaleway_instance_cloudinit_config.main", "rendered"),
+				),
+			},
+			{
+				Config: `
+					data "scaleway_instance_cloudinit_config" "main" {
+						part {
+							content = "packages: [nginx"
+						}
+					}
+				`,
+				ExpectError: regexp.MustCompile("part 0 is not a valid cloud-config"),
+			},
+		},
+	})
+}
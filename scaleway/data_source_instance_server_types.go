@@ -0,0 +1,301 @@
+package scaleway
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/scaleway/scaleway-sdk-go/api/instance/v1"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+)
+
+func dataSourceScalewayInstanceServerTypes() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceScalewayInstanceServerTypesRead,
+		Schema: map[string]*schema.Schema{
+			"arch": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Server types with this CPU architecture are listed.",
+				ValidateFunc: validation.StringInSlice([]string{
+					instance.ArchX86_64.String(),
+					instance.ArchArm64.String(),
+				}, false),
+			},
+			"min_vcpus": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Description:  "Server types with at least this number of vCPUs are listed.",
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"min_ram": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Description:  "Server types with at least this amount of RAM, in bytes, are listed.",
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"min_gpus": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Description:  "Server types with at least this number of GPUs are listed.",
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"max_hourly_price": {
+				Type:         schema.TypeFloat,
+				Optional:     true,
+				Description:  "Server types with an hourly price lower or equal to this one, in Euro, are listed.",
+				ValidateFunc: validation.FloatAtLeast(0),
+			},
+			"availabilities": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: "Server types with one of these stock availabilities are listed.",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+					ValidateFunc: validation.StringInSlice([]string{
+						instance.ServerTypesAvailabilityAvailable.String(),
+						instance.ServerTypesAvailabilityScarce.String(),
+						instance.ServerTypesAvailabilityShortage.String(),
+					}, false),
+				},
+			},
+			"local_volume_size": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Description:  "Server types accepting this total size of local volumes, in bytes, are listed.",
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"fail_if_empty": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Fail if no server type matches the filters.",
+			},
+			"names": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The names of the server types, from the cheapest to the most expensive.",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"server_types": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The server types, from the cheapest to the most expensive.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Computed: true,
+							Type:     schema.TypeString,
+						},
+						"alt_names": {
+							Computed: true,
+							Type:     schema.TypeList,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
+						"arch": {
+							Computed: true,
+							Type:     schema.TypeString,
+						},
+						"vcpus": {
+							Computed: true,
+							Type:     schema.TypeInt,
+						},
+						"ram": {
+							Computed: true,
+							Type:     schema.TypeInt,
+						},
+						"gpus": {
+							Computed: true,
+							Type:     schema.TypeInt,
+						},
+						"hourly_price": {
+							Computed: true,
+							Type:     schema.TypeFloat,
+						},
+						"availability": {
+							Computed: true,
+							Type:     schema.TypeString,
+						},
+						"baremetal": {
+							Computed: true,
+							Type:     schema.TypeBool,
+						},
+						"block_storage": {
+							Computed: true,
+							Type:     schema.TypeBool,
+						},
+						"ipv6_support": {
+							Computed: true,
+							Type:     schema.TypeBool,
+						},
+						"internet_bandwidth": {
+							Computed: true,
+							Type:     schema.TypeInt,
+						},
+						"internal_bandwidth": {
+							Computed: true,
+							Type:     schema.TypeInt,
+						},
+						"local_volume_min_size": {
+							Computed: true,
+							Type:     schema.TypeInt,
+						},
+						"local_volume_max_size": {
+							Computed: true,
+							Type:     schema.TypeInt,
+						},
+						"scratch_storage_max_size": {
+							Computed: true,
+							Type:     schema.TypeInt,
+						},
+					},
+				},
+			},
+			"zone": zoneSchema(),
+		},
+	}
+}
+
+func dataSourceScalewayInstanceServerTypesRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	instanceAPI, zone, err := instanceAPIWithZone(d, meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	res, err := instanceAPI.ListServersTypes(&instance.ListServersTypesRequest{
+		Zone: zone,
+	}, scw.WithAllPages(), scw.WithContext(ctx))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	availabilities, err := instanceAPI.GetServerTypesAvailability(&instance.GetServerTypesAvailabilityRequest{
+		Zone: zone,
+	}, scw.WithAllPages(), scw.WithContext(ctx))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	names := []string(nil)
+	for name, serverType := range res.Servers {
+		// Server types without a known availability do not match any availability filter
+		availability := instance.ServerTypesAvailability("")
+		if serverAvailability, exists := availabilities.Servers[name]; exists {
+			availability = serverAvailability.Availability
+		}
+		if instanceServerTypeMatches(d, serverType, availability) {
+			names = append(names, name)
+		}
+	}
+	sort.Slice(names, func(i, j int) bool {
+		if res.Servers[names[i]].HourlyPrice != res.Servers[names[j]].HourlyPrice {
+			return res.Servers[names[i]].HourlyPrice < res.Servers[names[j]].HourlyPrice
+		}
+		return names[i] < names[j]
+	})
+
+	if len(names) == 0 && d.Get("fail_if_empty").(bool) {
+		return diag.FromErr(errors.New("no server type matches the filters"))
+	}
+
+	serverTypes := []interface{}(nil)
+	for _, name := range names {
+		serverTypes = append(serverTypes, flattenInstanceServerType(name, res.Servers[name], availabilities.Servers[name]))
+	}
+
+	d.SetId(zone.String())
+	_ = d.Set("names", names)
+	_ = d.Set("server_types", serverTypes)
+	_ = d.Set("zone", zone.String())
+
+	return nil
+}
+
+// instanceServerTypeMatches returns whether a server type matches the filters of the data source
+func instanceServerTypeMatches(d *schema.ResourceData, serverType *instance.ServerType, availability instance.ServerTypesAvailability) bool {
+	if arch, ok := d.GetOk("arch"); ok && serverType.Arch.String() != arch.(string) {
+		return false
+	}
+	if uint64(serverType.Ncpus) < uint64(d.Get("min_vcpus").(int)) {
+		return false
+	}
+	if serverType.RAM < uint64(d.Get("min_ram").(int)) {
+		return false
+	}
+	if minGpus := d.Get("min_gpus").(int); minGpus > 0 && (serverType.Gpu == nil || *serverType.Gpu < uint64(minGpus)) {
+		return false
+	}
+	if maxHourlyPrice, ok := d.GetOk("max_hourly_price"); ok && serverType.HourlyPrice > float32(maxHourlyPrice.(float64)) {
+		return false
+	}
+	if rawAvailabilities, ok := d.GetOk("availabilities"); ok {
+		matches := false
+		for _, rawAvailability := range rawAvailabilities.([]interface{}) {
+			matches = matches || rawAvailability.(string) == availability.String()
+		}
+		if !matches {
+			return false
+		}
+	}
+	if localVolumeSize, ok := d.GetOk("local_volume_size"); ok {
+		size := scw.Size(localVolumeSize.(int))
+		if serverType.VolumesConstraint == nil || size < serverType.VolumesConstraint.MinSize || size > serverType.VolumesConstraint.MaxSize {
+			return false
+		}
+	}
+
+	return true
+}
+
+func flattenInstanceServerType(name string, serverType *instance.ServerType, availability *instance.GetServerTypesAvailabilityResponseAvailability) map[string]interface{} {
+	rawServerType := map[string]interface{}{
+		"name":         name,
+		"alt_names":    serverType.AltNames,
+		"arch":         serverType.Arch.String(),
+		"vcpus":        int(serverType.Ncpus),
+		"ram":          int(serverType.RAM),
+		"hourly_price": flattenInstanceServerTypePrice(serverType.HourlyPrice),
+		"baremetal":    serverType.Baremetal,
+	}
+	if serverType.Gpu != nil {
+		rawServerType["gpus"] = int(*serverType.Gpu)
+	}
+	if availability != nil {
+		rawServerType["availability"] = availability.Availability.String()
+	}
+	if serverType.Capabilities != nil && serverType.Capabilities.BlockStorage != nil {
+		rawServerType["block_storage"] = *serverType.Capabilities.BlockStorage
+	}
+	if serverType.Network != nil {
+		rawServerType["ipv6_support"] = serverType.Network.IPv6Support
+		if serverType.Network.SumInternetBandwidth != nil {
+			rawServerType["internet_bandwidth"] = int(*serverType.Network.SumInternetBandwidth)
+		}
+		if serverType.Network.SumInternalBandwidth != nil {
+			rawServerType["internal_bandwidth"] = int(*serverType.Network.SumInternalBandwidth)
+		}
+	}
+	if serverType.VolumesConstraint != nil {
+		rawServerType["local_volume_min_size"] = int(serverType.VolumesConstraint.MinSize)
+		rawServerType["local_volume_max_size"] = int(serverType.VolumesConstraint.MaxSize)
+	}
+	if serverType.ScratchStorageMaxSize != nil {
+		rawServerType["scratch_storage_max_size"] = int(*serverType.ScratchStorageMaxSize)
+	}
+
+	return rawServerType
+}
+
+// flattenInstanceServerTypePrice converts a price to a float64 without the float32 rounding error, e.g. 0.0088 instead of 0.00879999
+func flattenInstanceServerTypePrice(price float32) float64 {
+	flatPrice, _ := strconv.ParseFloat(strconv.FormatFloat(float64(price), 'f', -1, 32), 64)
+	return flatPrice
+}
@@ -0,0 +1,54 @@
+package scaleway
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/scaleway/scaleway-sdk-go/api/instance/v1"
+)
+
+func TestAccScalewayDataSourceInstanceServerTypes_Basic(t *testing.T) {
+	tt := NewTestToolsWithFakeAPI(t)
+	defer tt.Cleanup()
+	tt.FakeAPI.SetServerTypeAvailability("DEV1-M", instance.ServerTypesAvailabilityShortage)
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: tt.ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+					data "scaleway_instance_server_types" "cheapest" {
+						min_vcpus      = 3
+						availabilities = ["available"]
+					}
+
+					data "scaleway_instance_server_types" "local" {
+						max_hourly_price  = 0.05
+						local_volume_size = 30000000000
+					}
+				`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.scaleway_instance_server_types.cheapest", "names.#", "3"),
+					resource.TestCheckResourceAttr("data.scaleway_instance_server_types.cheapest", "names.0", "DEV1-L"),
+					resource.TestCheckResourceAttr("data.scaleway_instance_server_types.cheapest", "server_types.0.name", "DEV1-L"),
+					resource.TestCheckResourceAttr("data.scaleway_instance_server_types.cheapest", "server_types.0.vcpus", "4"),
+					resource.TestCheckResourceAttr("data.scaleway_instance_server_types.cheapest", "server_types.0.hourly_price", "0.042"),
+					resource.TestCheckResourceAttr("data.scaleway_instance_server_types.cheapest", "server_types.0.availability", "available"),
+					resource.TestCheckResourceAttr("data.scaleway_instance_server_types.local", "names.#", "2"),
+					resource.TestCheckResourceAttr("data.scaleway_instance_server_types.local", "names.0", "DEV1-M"),
+					resource.TestCheckResourceAttr("data.scaleway_instance_server_types.local", "server_types.0.availability", "shortage"),
+				),
+			},
+			{
+				Config: `
+					data "scaleway_instance_server_types" "gpu" {
+						min_gpus      = 1
+						fail_if_empty = true
+					}
+				`,
+				ExpectError: regexp.MustCompile("no server type matches the filters"),
+			},
+		},
+	})
+}
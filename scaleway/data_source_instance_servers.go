@@ -7,10 +7,14 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/scaleway/scaleway-sdk-go/api/instance/v1"
 	"github.com/scaleway/scaleway-sdk-go/scw"
 )
 
+// instanceServersAllZones is the zone of the data source listing the servers of every zone
+const instanceServersAllZones = "all"
+
 func dataSourceScalewayInstanceServers() *schema.Resource {
 	return &schema.Resource{
 		ReadContext: dataSourceScalewayInstanceServersRead,
@@ -28,6 +32,51 @@ func dataSourceScalewayInstanceServers() *schema.Resource {
 				Optional:    true,
 				Description: "Servers with these exact tags are listed.",
 			},
+			"type": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Servers with this commercial type are listed.",
+			},
+			"state": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Servers in this state are listed.",
+				ValidateFunc: validation.StringInSlice([]string{
+					InstanceServerStateStarted,
+					InstanceServerStateStopped,
+					InstanceServerStateStandby,
+				}, false),
+			},
+			"private_network_id": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Description:  "Servers attached to this private network are listed.",
+				ValidateFunc: validationUUIDorUUIDWithLocality(),
+			},
+			"security_group_id": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Description:  "Servers attached to this security group are listed.",
+				ValidateFunc: validationUUIDorUUIDWithLocality(),
+			},
+			"placement_group_id": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Description:  "Servers in this placement group are listed.",
+				ValidateFunc: validationUUIDorUUIDWithLocality(),
+			},
+			"image_id": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Description:  "Servers created from this image are listed.",
+				ValidateFunc: validationUUIDorUUIDWithLocality(),
+			},
+			"public_ip": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Description:  "Servers with this public IP address are listed.",
+				ValidateFunc: validation.IsIPAddress,
+			},
 			"servers": {
 				Type:     schema.TypeList,
 				Computed: true,
@@ -134,7 +183,13 @@ func dataSourceScalewayInstanceServers() *schema.Resource {
 					},
 				},
 			},
-			"zone":            zoneSchema(),
+			"zone": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				Computed:         true,
+				Description:      "The zone of the servers, or all to list the servers of every zone",
+				ValidateDiagFunc: validateStringInSliceWithWarning(append(allZones(), instanceServersAllZones), "zone"),
+			},
 			"organization_id": organizationIDSchema(),
 			"project_id":      projectIDSchema(),
 		},
@@ -142,16 +197,42 @@ func dataSourceScalewayInstanceServers() *schema.Resource {
 }
 
 func dataSourceScalewayInstanceServersRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	instanceAPI, zone, err := instanceAPIWithZone(d, meta)
-	if err != nil {
-		return diag.FromErr(err)
-	}
-	res, err := instanceAPI.ListServers(&instance.ListServersRequest{
-		Zone:    zone,
+	instanceAPI := instance.NewAPI(meta.(*Meta).scwClient)
+
+	req := &instance.ListServersRequest{
 		Name:    expandStringPtr(d.Get("name")),
 		Project: expandStringPtr(d.Get("project_id")),
 		Tags:    expandStrings(d.Get("tags")),
-	}, scw.WithContext(ctx))
+	}
+	if commercialType, ok := d.GetOk("type"); ok {
+		req.CommercialType = expandStringPtr(commercialType)
+	}
+	if rawState, ok := d.GetOk("state"); ok {
+		state, err := serverStateExpand(rawState.(string))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		req.State = &state
+	}
+	if privateNetworkID, ok := d.GetOk("private_network_id"); ok {
+		req.PrivateNetwork = expandStringPtr(expandID(privateNetworkID))
+	}
+
+	// The zone all lists the servers of every zone of the instance API, each with its own zone
+	opts := []scw.RequestOption{scw.WithAllPages(), scw.WithContext(ctx)}
+	id := instanceServersAllZones
+	if d.Get("zone").(string) == instanceServersAllZones {
+		opts = append(opts, scw.WithZones(instanceAPI.Zones()...))
+	} else {
+		zone, err := extractZone(d, meta.(*Meta))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		req.Zone = zone
+		id = zone.String()
+	}
+
+	res, err := instanceAPI.ListServers(req, opts...)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -160,6 +241,10 @@ func dataSourceScalewayInstanceServersRead(ctx context.Context, d *schema.Resour
 
 	servers := []interface{}(nil)
 	for _, server := range res.Servers {
+		if !instanceServerMatches(d, server) {
+			continue
+		}
+
 		rawServer := make(map[string]interface{})
 		rawServer["id"] = newZonedID(server.Zone, server.ID).String()
 		if server.PublicIP != nil {
@@ -177,7 +262,7 @@ func dataSourceScalewayInstanceServersRead(ctx context.Context, d *schema.Resour
 			continue
 		}
 		rawServer["state"] = state
-		rawServer["zone"] = string(zone)
+		rawServer["zone"] = server.Zone.String()
 		rawServer["name"] = server.Name
 		rawServer["boot_type"] = server.BootType
 		rawServer["bootscript_id"] = server.Bootscript.ID
@@ -185,7 +270,7 @@ func dataSourceScalewayInstanceServersRead(ctx context.Context, d *schema.Resour
 		if len(server.Tags) > 0 {
 			rawServer["tags"] = server.Tags
 		}
-		rawServer["security_group_id"] = newZonedID(zone, server.SecurityGroup.ID).String()
+		rawServer["security_group_id"] = newZonedID(server.Zone, server.SecurityGroup.ID).String()
 		rawServer["enable_ipv6"] = server.EnableIPv6
 		rawServer["enable_dynamic_ip"] = server.DynamicIPRequired
 		rawServer["routed_ip_enabled"] = server.RoutedIPEnabled
@@ -195,7 +280,7 @@ func dataSourceScalewayInstanceServersRead(ctx context.Context, d *schema.Resour
 			rawServer["image"] = server.Image.ID
 		}
 		if server.PlacementGroup != nil {
-			rawServer["placement_group_id"] = newZonedID(zone, server.PlacementGroup.ID).String()
+			rawServer["placement_group_id"] = newZonedID(server.Zone, server.PlacementGroup.ID).String()
 			rawServer["placement_group_policy_respected"] = server.PlacementGroup.PolicyRespected
 		}
 		if server.IPv6 != nil {
@@ -216,8 +301,33 @@ func dataSourceScalewayInstanceServersRead(ctx context.Context, d *schema.Resour
 		return diags
 	}
 
-	d.SetId(zone.String())
+	d.SetId(id)
 	_ = d.Set("servers", servers)
+	_ = d.Set("zone", id)
 
 	return nil
 }
+
+// instanceServerMatches returns whether a server matches the filters of the data source not supported by the API
+func instanceServerMatches(d *schema.ResourceData, server *instance.Server) bool {
+	if securityGroupID, ok := d.GetOk("security_group_id"); ok && (server.SecurityGroup == nil || server.SecurityGroup.ID != expandID(securityGroupID)) {
+		return false
+	}
+	if placementGroupID, ok := d.GetOk("placement_group_id"); ok && (server.PlacementGroup == nil || server.PlacementGroup.ID != expandID(placementGroupID)) {
+		return false
+	}
+	if imageID, ok := d.GetOk("image_id"); ok && (server.Image == nil || server.Image.ID != expandID(imageID)) {
+		return false
+	}
+	if publicIP, ok := d.GetOk("public_ip"); ok {
+		matches := server.PublicIP != nil && server.PublicIP.Address.String() == publicIP.(string)
+		for _, ip := range server.PublicIPs {
+			matches = matches || ip.Address.String() == publicIP.(string)
+		}
+		if !matches {
+			return false
+		}
+	}
+
+	return true
+}
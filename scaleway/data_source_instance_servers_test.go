@@ -10,9 +10,9 @@ func TestAccScalewayDataSourceInstanceServers_Basic(t *testing.T) {
 	tt := NewTestTools(t)
 	defer tt.Cleanup()
 	resource.ParallelTest(t, resource.TestCase{
-		PreCheck:          func() { testAccPreCheck(t) },
-		ProviderFactories: tt.ProviderFactories,
-		CheckDestroy:      testAccCheckScalewayInstanceServerDestroy(tt),
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: tt.ProviderFactories,
+		CheckDestroy:             testAccCheckScalewayInstanceServerDestroy(tt),
 		Steps: []resource.TestStep{
 			{
 				Config: `
@@ -86,3 +86,102 @@ func TestAccScalewayDataSourceInstanceServers_Basic(t *testing.T) {
 		},
 	})
 }
+
+func TestAccScalewayDataSourceInstanceServers_Filters(t *testing.T) {
+	tt := NewTestToolsWithFakeAPI(t)
+	defer tt.Cleanup()
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: tt.ProviderFactories,
+		CheckDestroy:             testAccCheckScalewayInstanceServerDestroy(tt),
+		Steps: []resource.TestStep{
+			{
+				Config: `
+					resource "scaleway_instance_security_group" "main" {
+						zone = "fr-par-2"
+					}
+
+					resource "scaleway_instance_server" "par1" {
+						name  = "tf-server-datasource-filters"
+						image = "ubuntu_jammy"
+						type  = "DEV1-S"
+					}
+
+					resource "scaleway_instance_server" "par2" {
+						name              = "tf-server-datasource-filters"
+						zone              = "fr-par-2"
+						image             = "ubuntu_jammy"
+						type              = "DEV1-M"
+						state             = "stopped"
+						security_group_id = scaleway_instance_security_group.main.id
+					}`,
+			},
+			{
+				Config: `
+					resource "scaleway_instance_security_group" "main" {
+						zone = "fr-par-2"
+					}
+
+					resource "scaleway_instance_server" "par1" {
+						name  = "tf-server-datasource-filters"
+						image = "ubuntu_jammy"
+						type  = "DEV1-S"
+					}
+
+					resource "scaleway_instance_server" "par2" {
+						name              = "tf-server-datasource-filters"
+						zone              = "fr-par-2"
+						image             = "ubuntu_jammy"
+						type              = "DEV1-M"
+						state             = "stopped"
+						security_group_id = scaleway_instance_security_group.main.id
+					}
+
+					data "scaleway_instance_servers" "all" {
+						name = "tf-server-datasource-filters"
+						zone = "all"
+					}
+
+					data "scaleway_instance_servers" "by_type" {
+						name = "tf-server-datasource-filters"
+						type = "DEV1-M"
+						zone = "all"
+					}
+
+					data "scaleway_instance_servers" "by_state" {
+						name  = "tf-server-datasource-filters"
+						state = "started"
+						zone  = "all"
+					}
+
+					data "scaleway_instance_servers" "by_security_group" {
+						security_group_id = scaleway_instance_security_group.main.id
+						zone              = "fr-par-2"
+					}
+
+					data "scaleway_marketplace_image" "ubuntu_jammy" {
+						label = "ubuntu_jammy"
+					}
+
+					data "scaleway_instance_servers" "by_image" {
+						name     = "tf-server-datasource-filters"
+						image_id = data.scaleway_marketplace_image.ubuntu_jammy.id
+					}
+				`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.scaleway_instance_servers.all", "id", "all"),
+					resource.TestCheckResourceAttr("data.scaleway_instance_servers.all", "servers.#", "2"),
+					resource.TestCheckResourceAttr("data.scaleway_instance_servers.all", "servers.0.zone", "fr-par-1"),
+					resource.TestCheckResourceAttr("data.scaleway_instance_servers.all", "servers.1.zone", "fr-par-2"),
+					resource.TestCheckResourceAttr("data.scaleway_instance_servers.by_type", "servers.#", "1"),
+					resource.TestCheckResourceAttrPair("data.scaleway_instance_servers.by_type", "servers.0.id", "scaleway_instance_server.par2", "id"),
+					resource.TestCheckResourceAttr("data.scaleway_instance_servers.by_state", "servers.#", "1"),
+					resource.TestCheckResourceAttrPair("data.scaleway_instance_servers.by_state", "servers.0.id", "scaleway_instance_server.par1", "id"),
+					resource.TestCheckResourceAttr("data.scaleway_instance_servers.by_security_group", "servers.#", "1"),
+					resource.TestCheckResourceAttrPair("data.scaleway_instance_servers.by_security_group", "servers.0.id", "scaleway_instance_server.par2", "id"),
+					resource.TestCheckResourceAttr("data.scaleway_instance_servers.by_image", "servers.#", "1"),
+					resource.TestCheckResourceAttrPair("data.scaleway_instance_servers.by_image", "servers.0.id", "scaleway_instance_server.par1", "id"),
+				),
+			},
+		},
+	})
+}
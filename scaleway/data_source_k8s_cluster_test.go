@@ -13,8 +13,8 @@ func TestAccScalewayDataSourceK8SCluster_Basic(t *testing.T) {
 	clusterName := "tf-cluster"
 	version := testAccScalewayK8SClusterGetLatestK8SVersion(tt)
 	resource.ParallelTest(t, resource.TestCase{
-		PreCheck:          func() { testAccPreCheck(t) },
-		ProviderFactories: tt.ProviderFactories,
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: tt.ProviderFactories,
 		CheckDestroy: resource.ComposeTestCheckFunc(
 			testAccCheckScalewayK8SPoolDestroy(tt, "scaleway_k8s_pool.default"),
 			testAccCheckScalewayK8SClusterDestroy(tt),
@@ -3,10 +3,10 @@ package scaleway
 import (
 	"context"
 	"fmt"
-	"log"
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
@@ -46,7 +46,7 @@ func dataSourceScalewayObjectStorageRead(ctx context.Context, d *schema.Resource
 		Bucket: aws.String(bucket),
 	}
 
-	log.Printf("[DEBUG] Reading Object Storage bucket: %s", input)
+	tflog.Debug(ctx, fmt.Sprintf("reading Object Storage bucket: %s", input))
 	_, err = s3Client.HeadBucketWithContext(ctx, input)
 
 	if err != nil {
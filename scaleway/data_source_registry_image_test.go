@@ -16,9 +16,9 @@ func TestAccScalewayDataSourceRegistryImage_Basic(t *testing.T) {
 
 	t.Skip("It is difficult to test this datasource as we cannot create registry images with Terraform.")
 	resource.ParallelTest(t, resource.TestCase{
-		PreCheck:          func() { testAccPreCheck(t) },
-		ProviderFactories: tt.ProviderFactories,
-		CheckDestroy:      testAccCheckScalewayRegistryNamespaceDestroy(tt),
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: tt.ProviderFactories,
+		CheckDestroy:             testAccCheckScalewayRegistryNamespaceDestroy(tt),
 		Steps: []resource.TestStep{
 			{
 				Config: `
@@ -12,9 +12,9 @@ func TestAccScalewayDataSourceVPCPrivateNetwork_Basic(t *testing.T) {
 	defer tt.Cleanup()
 	pnName := "TestAccScalewayDataSourceVPCPrivateNetwork_Basic"
 	resource.ParallelTest(t, resource.TestCase{
-		PreCheck:          func() { testAccPreCheck(t) },
-		ProviderFactories: tt.ProviderFactories,
-		CheckDestroy:      testAccCheckScalewayVPCPrivateNetworkDestroy(tt),
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: tt.ProviderFactories,
+		CheckDestroy:             testAccCheckScalewayVPCPrivateNetworkDestroy(tt),
 		Steps: []resource.TestStep{
 			{
 				Config: fmt.Sprintf(`
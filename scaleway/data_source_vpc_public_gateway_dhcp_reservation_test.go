@@ -12,9 +12,9 @@ func TestAccScalewayDataSourceVPCPublicGatewayDHCPReservation_Basic(t *testing.T
 	defer tt.Cleanup()
 	pnName := "TestAccScalewayDataSourceVPCPublicGatewayDHCPReservation_Basic"
 	resource.ParallelTest(t, resource.TestCase{
-		PreCheck:          func() { testAccPreCheck(t) },
-		ProviderFactories: tt.ProviderFactories,
-		CheckDestroy:      testAccCheckScalewayVPCPublicGatewayDHCPDestroy(tt),
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: tt.ProviderFactories,
+		CheckDestroy:             testAccCheckScalewayVPCPublicGatewayDHCPDestroy(tt),
 
 		Steps: []resource.TestStep{
 			{
@@ -116,9 +116,9 @@ func TestAccScalewayDataSourceVPCPublicGatewayDHCPReservation_Static(t *testing.
 	defer tt.Cleanup()
 	pnName := "TestAccScalewayDataSourceVPCPublicGatewayDHCPReservation_Static"
 	resource.ParallelTest(t, resource.TestCase{
-		PreCheck:          func() { testAccPreCheck(t) },
-		ProviderFactories: tt.ProviderFactories,
-		CheckDestroy:      testAccCheckScalewayVPCPublicGatewayDHCPDestroy(tt),
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: tt.ProviderFactories,
+		CheckDestroy:             testAccCheckScalewayVPCPublicGatewayDHCPDestroy(tt),
 
 		Steps: []resource.TestStep{
 			{
@@ -12,9 +12,9 @@ func TestAccScalewayDataSourceVPCPublicGateway_Basic(t *testing.T) {
 	defer tt.Cleanup()
 	pgName := "TestAccScalewayDataSourceVPCPublicGateway_Basic"
 	resource.ParallelTest(t, resource.TestCase{
-		PreCheck:          func() { testAccPreCheck(t) },
-		ProviderFactories: tt.ProviderFactories,
-		CheckDestroy:      testAccCheckScalewayVPCPublicGatewayDestroy(tt),
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: tt.ProviderFactories,
+		CheckDestroy:             testAccCheckScalewayVPCPublicGatewayDestroy(tt),
 		Steps: []resource.TestStep{
 			{
 				Config: fmt.Sprintf(`
@@ -0,0 +1,152 @@
+package scaleway
+
+import (
+	"crypto/tls"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// endpointsProducts maps the arguments of the provider endpoints block to the products found in the API path
+var endpointsProducts = map[string]string{
+	"account":       "account",
+	"apple_silicon": "apple-silicon",
+	"baremetal":     "baremetal",
+	"billing":       "billing",
+	"block":         "block",
+	"cockpit":       "cockpit",
+	"container":     "containers",
+	"documentdb":    "document-db",
+	"domain":        "domain",
+	"flexible_ip":   "flexible-ip",
+	"function":      "functions",
+	"iam":           "iam",
+	"instance":      "instance",
+	"iot":           "iot",
+	"ipam":          "ipam",
+	"job":           "serverless-jobs",
+	"k8s":           "k8s",
+	"lb":            "lb",
+	"marketplace":   "marketplace",
+	"mnq":           "mnq",
+	"rdb":           "rdb",
+	"redis":         "redis",
+	"registry":      "registry",
+	"secret":        "secret-manager",
+	"tem":           "transactional-email",
+	"vpc":           "vpc",
+	"vpcgw":         "vpc-gw",
+	"webhosting":    "webhosting",
+}
+
+// endpointsSchema returns the provider schema of the endpoints block
+func endpointsSchema() *schema.Schema {
+	endpoints := map[string]*schema.Schema{
+		"s3": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			Description:  "Endpoint of the Object Storage S3 API, requests are sent using path-style bucket addressing",
+			ValidateFunc: validation.IsURLWithHTTPorHTTPS,
+		},
+	}
+	for argument, product := range endpointsProducts {
+		endpoints[argument] = &schema.Schema{
+			Type:         schema.TypeString,
+			Optional:     true,
+			Description:  "Endpoint replacing the API URL for the requests of the " + product + " product",
+			ValidateFunc: validation.IsURLWithHTTPorHTTPS,
+		}
+	}
+
+	return &schema.Schema{
+		Type:        schema.TypeList,
+		Optional:    true,
+		MaxItems:    1,
+		Description: "Endpoints overriding the API URL of given products, useful to test against local mocks",
+		Elem: &schema.Resource{
+			Schema: endpoints,
+		},
+	}
+}
+
+type endpointsOptions struct {
+	// S3 is the endpoint of the Object Storage S3 API
+	S3 string
+	// Products contains the endpoints of the Scaleway API products, by product name as found in the API path
+	Products map[string]*url.URL
+}
+
+// expandProviderEndpoints returns the endpoints of the provider endpoints block
+func expandProviderEndpoints(d *schema.ResourceData) (*endpointsOptions, error) {
+	options := &endpointsOptions{
+		Products: map[string]*url.URL{},
+	}
+	if d == nil {
+		return options, nil
+	}
+
+	options.S3 = d.Get("endpoints.0.s3").(string)
+
+	for argument, product := range endpointsProducts {
+		rawEndpoint := d.Get("endpoints.0." + argument).(string)
+		if rawEndpoint == "" {
+			continue
+		}
+		endpoint, err := url.Parse(rawEndpoint)
+		if err != nil {
+			return nil, err
+		}
+		options.Products[product] = endpoint
+	}
+
+	return options, nil
+}
+
+// newInsecureTransport creates a http transport that does not verify the certificates of the servers
+func newInsecureTransport() http.RoundTripper {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = &tls.Config{InsecureSkipVerify: true} //nolint:gosec
+
+	return transport
+}
+
+// newEndpointsTransport creates a http transport that sends the requests made to the API host to the endpoint of their product.
+func newEndpointsTransport(defaultTransport http.RoundTripper, apiHost string, endpoints map[string]*url.URL) http.RoundTripper {
+	return &endpointsTransport{
+		transport: defaultTransport,
+		apiHost:   apiHost,
+		endpoints: endpoints,
+	}
+}
+
+type endpointsTransport struct {
+	transport http.RoundTripper
+	// apiHost is the host of the API URL used by the SDK
+	apiHost   string
+	endpoints map[string]*url.URL
+}
+
+// RoundTrip replaces the scheme and host of the request with the ones of the product endpoint.
+// The path of the endpoint is prepended to the path of the request.
+func (t *endpointsTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	if r.URL.Host != t.apiHost {
+		return t.transport.RoundTrip(r)
+	}
+
+	endpoint, exists := t.endpoints[apiPathProduct(r.URL.Path)]
+	if !exists {
+		return t.transport.RoundTrip(r)
+	}
+
+	r = r.Clone(r.Context())
+	r.URL.Scheme = endpoint.Scheme
+	r.URL.Host = endpoint.Host
+	r.URL.Path = strings.TrimSuffix(endpoint.Path, "/") + r.URL.Path
+	r.URL.RawPath = ""
+	r.Host = endpoint.Host
+
+	return t.transport.RoundTrip(r)
+}
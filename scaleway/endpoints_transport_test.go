@@ -0,0 +1,55 @@
+package scaleway
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEndpointsTransport(t *testing.T) {
+	var requestedPaths []string
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestedPaths = append(requestedPaths, r.URL.Path)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	mockURL, err := url.Parse(server.URL + "/mock/")
+	require.NoError(t, err)
+
+	transport := newEndpointsTransport(newInsecureTransport(), scalewayAPIHost, map[string]*url.URL{
+		"instance": mockURL,
+	})
+	client := &http.Client{Transport: transport}
+
+	testCases := []struct {
+		url          string
+		expectedPath string
+	}{
+		{
+			url:          "https://api.scaleway.com/instance/v1/zones/fr-par-1/servers",
+			expectedPath: "/mock/instance/v1/zones/fr-par-1/servers",
+		},
+		{
+			// Requests to other hosts are not rewritten
+			url:          server.URL + "/instance/v1/zones/fr-par-1/servers",
+			expectedPath: "/instance/v1/zones/fr-par-1/servers",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.url, func(t *testing.T) {
+			requestedPaths = nil
+			req, err := http.NewRequest(http.MethodGet, tc.url, nil)
+			require.NoError(t, err)
+			resp, err := client.Do(req)
+			require.NoError(t, err)
+			resp.Body.Close()
+			assert.Equal(t, []string{tc.expectedPath}, requestedPaths)
+		})
+	}
+}
@@ -0,0 +1,173 @@
+package scaleway
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	iam "github.com/scaleway/scaleway-sdk-go/api/iam/v1alpha1"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+)
+
+// ephemeralIamAPIKeyPrivateKey is the key of the private data storing the API key to delete on close
+const ephemeralIamAPIKeyPrivateKey = "api_key"
+
+var (
+	_ ephemeral.EphemeralResource              = (*ephemeralIamAPIKey)(nil)
+	_ ephemeral.EphemeralResourceWithConfigure = (*ephemeralIamAPIKey)(nil)
+	_ ephemeral.EphemeralResourceWithClose     = (*ephemeralIamAPIKey)(nil)
+)
+
+// ephemeralIamAPIKey creates a short-lived API key that is deleted once terraform does not need it anymore
+type ephemeralIamAPIKey struct {
+	frameworkMeta
+}
+
+type ephemeralIamAPIKeyModel struct {
+	ApplicationID    types.String `tfsdk:"application_id"`
+	UserID           types.String `tfsdk:"user_id"`
+	Description      types.String `tfsdk:"description"`
+	DefaultProjectID types.String `tfsdk:"default_project_id"`
+	ExpiresAt        types.String `tfsdk:"expires_at"`
+	Profile          types.String `tfsdk:"profile"`
+	AccessKey        types.String `tfsdk:"access_key"`
+	SecretKey        types.String `tfsdk:"secret_key"`
+}
+
+func newEphemeralIamAPIKey() ephemeral.EphemeralResource {
+	return &ephemeralIamAPIKey{}
+}
+
+func (r *ephemeralIamAPIKey) Metadata(_ context.Context, req ephemeral.MetadataRequest, resp *ephemeral.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_iam_api_key"
+}
+
+func (r *ephemeralIamAPIKey) Schema(_ context.Context, _ ephemeral.SchemaRequest, resp *ephemeral.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Create an API key that is deleted once terraform does not need it anymore, its secret key is never stored in the state",
+		Attributes: map[string]schema.Attribute{
+			"application_id": schema.StringAttribute{
+				Optional:    true,
+				Description: "ID of the application attached to the api key",
+				Validators: []validator.String{
+					stringvalidator.ExactlyOneOf(path.MatchRoot("application_id"), path.MatchRoot("user_id")),
+				},
+			},
+			"user_id": schema.StringAttribute{
+				Optional:    true,
+				Description: "ID of the user attached to the api key",
+			},
+			"description": schema.StringAttribute{
+				Optional:    true,
+				Description: "The description of the iam api key",
+			},
+			"default_project_id": schema.StringAttribute{
+				Optional:    true,
+				Description: "The default project ID to use with object storage",
+			},
+			"expires_at": schema.StringAttribute{
+				Optional:    true,
+				Description: "The date and time of the expiration of the iam api key, in RFC 3339 format",
+			},
+			"profile": ephemeralProfileSchema(),
+			"access_key": schema.StringAttribute{
+				Computed:    true,
+				Description: "The access key of the iam api key",
+			},
+			"secret_key": schema.StringAttribute{
+				Computed:    true,
+				Sensitive:   true,
+				Description: "The secret Key of the iam api key",
+			},
+		},
+	}
+}
+
+func (r *ephemeralIamAPIKey) Configure(_ context.Context, req ephemeral.ConfigureRequest, resp *ephemeral.ConfigureResponse) {
+	r.configure(req.ProviderData, &resp.Diagnostics)
+}
+
+func (r *ephemeralIamAPIKey) Open(ctx context.Context, req ephemeral.OpenRequest, resp *ephemeral.OpenResponse) {
+	var data ephemeralIamAPIKeyModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if expiresAt := data.ExpiresAt.ValueString(); expiresAt != "" {
+		if _, err := time.Parse(time.RFC3339, expiresAt); err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("expires_at"), "Invalid expiration date", err.Error())
+			return
+		}
+	}
+
+	meta, err := r.forProfile(ctx, data.Profile)
+	if err != nil {
+		resp.Diagnostics.AddError("Cannot load profile", err.Error())
+		return
+	}
+
+	res, err := iamAPI(meta).CreateAPIKey(&iam.CreateAPIKeyRequest{
+		ApplicationID:    data.ApplicationID.ValueStringPointer(),
+		UserID:           data.UserID.ValueStringPointer(),
+		ExpiresAt:        expandTimePtr(data.ExpiresAt.ValueString()),
+		DefaultProjectID: data.DefaultProjectID.ValueStringPointer(),
+		Description:      data.Description.ValueString(),
+	}, scw.WithContext(ctx))
+	if err != nil {
+		resp.Diagnostics.AddError("Cannot create iam api key", err.Error())
+		return
+	}
+
+	// The key is deleted on close, with the credentials of the same profile
+	privateData, err := json.Marshal(ephemeralIamAPIKeyPrivate{AccessKey: res.AccessKey, Profile: data.Profile.ValueString()})
+	if err != nil {
+		resp.Diagnostics.AddError("Cannot store access key", err.Error())
+		return
+	}
+	resp.Diagnostics.Append(resp.Private.SetKey(ctx, ephemeralIamAPIKeyPrivateKey, privateData)...)
+
+	data.AccessKey = types.StringValue(res.AccessKey)
+	data.SecretKey = types.StringPointerValue(res.SecretKey)
+
+	resp.Diagnostics.Append(resp.Result.Set(ctx, &data)...)
+}
+
+// ephemeralIamAPIKeyPrivate is the private data of an ephemeral API key
+type ephemeralIamAPIKeyPrivate struct {
+	AccessKey string `json:"access_key"`
+	Profile   string `json:"profile"`
+}
+
+func (r *ephemeralIamAPIKey) Close(ctx context.Context, req ephemeral.CloseRequest, resp *ephemeral.CloseResponse) {
+	rawPrivateData, diags := req.Private.GetKey(ctx, ephemeralIamAPIKeyPrivateKey)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() || rawPrivateData == nil {
+		return
+	}
+
+	var privateData ephemeralIamAPIKeyPrivate
+	if err := json.Unmarshal(rawPrivateData, &privateData); err != nil {
+		resp.Diagnostics.AddError("Cannot read access key", err.Error())
+		return
+	}
+
+	meta, err := r.forProfile(ctx, types.StringValue(privateData.Profile))
+	if err != nil {
+		resp.Diagnostics.AddError("Cannot load profile", err.Error())
+		return
+	}
+
+	err = iamAPI(meta).DeleteAPIKey(&iam.DeleteAPIKeyRequest{
+		AccessKey: privateData.AccessKey,
+	}, scw.WithContext(ctx))
+	if err != nil && !is404Error(err) {
+		resp.Diagnostics.AddError("Cannot delete iam api key", err.Error())
+	}
+}
@@ -0,0 +1,44 @@
+package scaleway
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	iam "github.com/scaleway/scaleway-sdk-go/api/iam/v1alpha1"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEphemeralIamAPIKey_Basic(t *testing.T) {
+	tt := NewTestToolsWithFakeAPI(t)
+	defer tt.Cleanup()
+
+	api := iamAPI(tt.Meta)
+	application, err := api.CreateApplication(&iam.CreateApplicationRequest{
+		Name: "tf_tests_ephemeral_api_key",
+	})
+	require.NoError(t, err)
+
+	attributes, private := openEphemeralResource(t, tt, "scaleway_iam_api_key", map[string]tftypes.Value{
+		"application_id": tftypes.NewValue(tftypes.String, application.ID),
+		"description":    tftypes.NewValue(tftypes.String, "an ephemeral description"),
+	})
+
+	accessKey := stringAttribute(t, attributes, "access_key")
+	assert.NotEmpty(t, stringAttribute(t, attributes, "secret_key"))
+	assert.Equal(t, application.ID, stringAttribute(t, attributes, "application_id"))
+
+	apiKey, err := api.GetAPIKey(&iam.GetAPIKeyRequest{AccessKey: accessKey})
+	require.NoError(t, err)
+	assert.Equal(t, scw.StringPtr(application.ID), apiKey.ApplicationID)
+	assert.Equal(t, "an ephemeral description", apiKey.Description)
+
+	closeEphemeralResource(t, tt, "scaleway_iam_api_key", private)
+
+	_, err = api.GetAPIKey(&iam.GetAPIKeyRequest{AccessKey: accessKey})
+	assert.True(t, is404Error(err), "api key should be deleted on close, got %v", err)
+
+	// Closing twice does not fail when the api key is already deleted
+	closeEphemeralResource(t, tt, "scaleway_iam_api_key", private)
+}
@@ -0,0 +1,112 @@
+package scaleway
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/scaleway/scaleway-sdk-go/api/k8s/v1"
+)
+
+var (
+	_ ephemeral.EphemeralResource              = (*ephemeralK8SKubeconfig)(nil)
+	_ ephemeral.EphemeralResourceWithConfigure = (*ephemeralK8SKubeconfig)(nil)
+)
+
+// ephemeralK8SKubeconfig gets the kubeconfig of a Kubernetes cluster without storing it in the state
+type ephemeralK8SKubeconfig struct {
+	frameworkMeta
+}
+
+type ephemeralK8SKubeconfigModel struct {
+	ClusterID            types.String `tfsdk:"cluster_id"`
+	Region               types.String `tfsdk:"region"`
+	Profile              types.String `tfsdk:"profile"`
+	ConfigFile           types.String `tfsdk:"config_file"`
+	Host                 types.String `tfsdk:"host"`
+	ClusterCACertificate types.String `tfsdk:"cluster_ca_certificate"`
+	Token                types.String `tfsdk:"token"`
+}
+
+func newEphemeralK8SKubeconfig() ephemeral.EphemeralResource {
+	return &ephemeralK8SKubeconfig{}
+}
+
+func (r *ephemeralK8SKubeconfig) Metadata(_ context.Context, req ephemeral.MetadataRequest, resp *ephemeral.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_k8s_kubeconfig"
+}
+
+func (r *ephemeralK8SKubeconfig) Schema(_ context.Context, _ ephemeral.SchemaRequest, resp *ephemeral.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Get the kubeconfig of a Kubernetes cluster without storing it in the state",
+		Attributes: map[string]schema.Attribute{
+			"cluster_id": schema.StringAttribute{
+				Required:    true,
+				Description: "The ID of the Kubernetes cluster",
+			},
+			"region":  ephemeralRegionSchema(),
+			"profile": ephemeralProfileSchema(),
+			"config_file": schema.StringAttribute{
+				Computed:    true,
+				Sensitive:   true,
+				Description: "The whole kubeconfig file",
+			},
+			"host": schema.StringAttribute{
+				Computed:    true,
+				Description: "The kubernetes master URL",
+			},
+			"cluster_ca_certificate": schema.StringAttribute{
+				Computed:    true,
+				Description: "The kubernetes cluster CA certificate",
+			},
+			"token": schema.StringAttribute{
+				Computed:    true,
+				Sensitive:   true,
+				Description: "The kubernetes cluster admin token",
+			},
+		},
+	}
+}
+
+func (r *ephemeralK8SKubeconfig) Configure(_ context.Context, req ephemeral.ConfigureRequest, resp *ephemeral.ConfigureResponse) {
+	r.configure(req.ProviderData, &resp.Diagnostics)
+}
+
+func (r *ephemeralK8SKubeconfig) Open(ctx context.Context, req ephemeral.OpenRequest, resp *ephemeral.OpenResponse) {
+	var data ephemeralK8SKubeconfigModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	meta, err := r.forProfile(ctx, data.Profile)
+	if err != nil {
+		resp.Diagnostics.AddError("Cannot load profile", err.Error())
+		return
+	}
+
+	clusterID := expandRegionalID(data.ClusterID.ValueString())
+	if data.Region.ValueString() == "" && clusterID.Region != "" {
+		data.Region = types.StringValue(clusterID.Region.String())
+	}
+	region, err := frameworkRegion(meta, data.Region)
+	if err != nil {
+		resp.Diagnostics.AddError("Cannot get region", err.Error())
+		return
+	}
+
+	kubeconfig, err := flattenKubeconfig(ctx, k8s.NewAPI(meta.scwClient), region, clusterID.ID)
+	if err != nil {
+		resp.Diagnostics.AddError("Cannot read kubeconfig", err.Error())
+		return
+	}
+
+	data.Region = types.StringValue(region.String())
+	data.ConfigFile = types.StringValue(kubeconfig["config_file"].(string))
+	data.Host = types.StringValue(kubeconfig["host"].(string))
+	data.ClusterCACertificate = types.StringValue(kubeconfig["cluster_ca_certificate"].(string))
+	data.Token = types.StringValue(kubeconfig["token"].(string))
+
+	resp.Diagnostics.Append(resp.Result.Set(ctx, &data)...)
+}
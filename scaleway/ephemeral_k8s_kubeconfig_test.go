@@ -0,0 +1,37 @@
+package scaleway
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	k8s "github.com/scaleway/scaleway-sdk-go/api/k8s/v1"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/fakeapi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEphemeralK8SKubeconfig_Basic(t *testing.T) {
+	tt := NewTestToolsWithFakeAPI(t)
+	defer tt.Cleanup()
+
+	cluster, err := k8s.NewAPI(tt.Meta.scwClient).CreateCluster(&k8s.CreateClusterRequest{
+		Region:    scw.RegionFrPar,
+		ProjectID: scw.StringPtr(fakeapi.DefaultProjectID),
+		Name:      "tf-tests-ephemeral-kubeconfig",
+		Version:   "1.31.2",
+		Cni:       k8s.CNICilium,
+	})
+	require.NoError(t, err)
+
+	attributes, _ := openEphemeralResource(t, tt, "scaleway_k8s_kubeconfig", map[string]tftypes.Value{
+		"cluster_id": tftypes.NewValue(tftypes.String, newRegionalIDString(scw.RegionFrPar, cluster.ID)),
+	})
+
+	assert.Equal(t, scw.RegionFrPar.String(), stringAttribute(t, attributes, "region"))
+	assert.Equal(t, cluster.ClusterURL, stringAttribute(t, attributes, "host"))
+	assert.Equal(t, "fake-token-"+cluster.ID, stringAttribute(t, attributes, "token"))
+	assert.Equal(t, "ZmFrZS1jZXJ0aWZpY2F0ZS1hdXRob3JpdHk=", stringAttribute(t, attributes, "cluster_ca_certificate"))
+	assert.True(t, strings.Contains(stringAttribute(t, attributes, "config_file"), "current-context: admin@tf-tests-ephemeral-kubeconfig"))
+}
@@ -0,0 +1,143 @@
+package scaleway
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	secret "github.com/scaleway/scaleway-sdk-go/api/secret/v1alpha1"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+)
+
+var (
+	_ ephemeral.EphemeralResource              = (*ephemeralSecretVersion)(nil)
+	_ ephemeral.EphemeralResourceWithConfigure = (*ephemeralSecretVersion)(nil)
+)
+
+// ephemeralSecretVersion accesses the payload of a secret version without storing it in the state
+type ephemeralSecretVersion struct {
+	frameworkMeta
+}
+
+type ephemeralSecretVersionModel struct {
+	SecretID   types.String `tfsdk:"secret_id"`
+	SecretName types.String `tfsdk:"secret_name"`
+	ProjectID  types.String `tfsdk:"project_id"`
+	Revision   types.String `tfsdk:"revision"`
+	Region     types.String `tfsdk:"region"`
+	Profile    types.String `tfsdk:"profile"`
+	Data       types.String `tfsdk:"data"`
+}
+
+func newEphemeralSecretVersion() ephemeral.EphemeralResource {
+	return &ephemeralSecretVersion{}
+}
+
+func (r *ephemeralSecretVersion) Metadata(_ context.Context, req ephemeral.MetadataRequest, resp *ephemeral.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_secret_version"
+}
+
+func (r *ephemeralSecretVersion) Schema(_ context.Context, _ ephemeral.SchemaRequest, resp *ephemeral.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Access the payload of a secret version without storing it in the state",
+		Attributes: map[string]schema.Attribute{
+			"secret_id": schema.StringAttribute{
+				Optional:    true,
+				Computed:    true,
+				Description: "The ID of the secret",
+				Validators: []validator.String{
+					stringvalidator.ExactlyOneOf(path.MatchRoot("secret_id"), path.MatchRoot("secret_name")),
+				},
+			},
+			"secret_name": schema.StringAttribute{
+				Optional:    true,
+				Description: "The name of the secret",
+			},
+			"project_id": schema.StringAttribute{
+				Optional:    true,
+				Description: "The ID of the project of the secret, used with secret_name",
+			},
+			"revision": schema.StringAttribute{
+				Optional:    true,
+				Computed:    true,
+				Description: "The revision of the secret version, a number or latest. Defaults to latest",
+			},
+			"region":  ephemeralRegionSchema(),
+			"profile": ephemeralProfileSchema(),
+			"data": schema.StringAttribute{
+				Computed:    true,
+				Sensitive:   true,
+				Description: "The payload of the secret version, encoded in base64",
+			},
+		},
+	}
+}
+
+func (r *ephemeralSecretVersion) Configure(_ context.Context, req ephemeral.ConfigureRequest, resp *ephemeral.ConfigureResponse) {
+	r.configure(req.ProviderData, &resp.Diagnostics)
+}
+
+func (r *ephemeralSecretVersion) Open(ctx context.Context, req ephemeral.OpenRequest, resp *ephemeral.OpenResponse) {
+	var data ephemeralSecretVersionModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	meta, err := r.forProfile(ctx, data.Profile)
+	if err != nil {
+		resp.Diagnostics.AddError("Cannot load profile", err.Error())
+		return
+	}
+
+	secretID := expandRegionalID(data.SecretID.ValueString())
+	if data.Region.ValueString() == "" && secretID.Region != "" {
+		data.Region = types.StringValue(secretID.Region.String())
+	}
+	region, err := frameworkRegion(meta, data.Region)
+	if err != nil {
+		resp.Diagnostics.AddError("Cannot get region", err.Error())
+		return
+	}
+
+	revision := data.Revision.ValueString()
+	if revision == "" {
+		revision = "latest"
+	}
+
+	api := secret.NewAPI(meta.scwClient)
+
+	var res *secret.AccessSecretVersionResponse
+	if secretID.ID != "" {
+		res, err = api.AccessSecretVersion(&secret.AccessSecretVersionRequest{
+			Region:   region,
+			SecretID: secretID.ID,
+			Revision: revision,
+		}, scw.WithContext(ctx))
+	} else {
+		res, err = api.AccessSecretVersionByName(&secret.AccessSecretVersionByNameRequest{
+			Region:     region,
+			SecretName: data.SecretName.ValueString(),
+			Revision:   revision,
+			ProjectID:  data.ProjectID.ValueStringPointer(),
+		}, scw.WithContext(ctx))
+	}
+	if err != nil {
+		resp.Diagnostics.AddError("Cannot access secret version", fmt.Sprintf("Cannot access revision %s of the secret: %s", revision, err))
+		return
+	}
+
+	data.SecretID = types.StringValue(newRegionalIDString(region, res.SecretID))
+	data.Revision = types.StringValue(strconv.FormatUint(uint64(res.Revision), 10))
+	data.Region = types.StringValue(region.String())
+	data.Data = types.StringValue(base64.StdEncoding.EncodeToString(res.Data))
+
+	resp.Diagnostics.Append(resp.Result.Set(ctx, &data)...)
+}
@@ -0,0 +1,51 @@
+package scaleway
+
+import (
+	"encoding/base64"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	secret "github.com/scaleway/scaleway-sdk-go/api/secret/v1alpha1"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/fakeapi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEphemeralSecretVersion_Basic(t *testing.T) {
+	tt := NewTestToolsWithFakeAPI(t)
+	defer tt.Cleanup()
+
+	api := secret.NewAPI(tt.Meta.scwClient)
+	s, err := api.CreateSecret(&secret.CreateSecretRequest{
+		Region:    scw.RegionFrPar,
+		ProjectID: fakeapi.DefaultProjectID,
+		Name:      "tf-tests-ephemeral-secret",
+	})
+	require.NoError(t, err)
+	for _, data := range []string{"first data", "second data"} {
+		_, err = api.CreateSecretVersion(&secret.CreateSecretVersionRequest{
+			Region:   scw.RegionFrPar,
+			SecretID: s.ID,
+			Data:     []byte(data),
+		})
+		require.NoError(t, err)
+	}
+
+	// The latest revision is accessed by default
+	attributes, _ := openEphemeralResource(t, tt, "scaleway_secret_version", map[string]tftypes.Value{
+		"secret_id": tftypes.NewValue(tftypes.String, newRegionalIDString(scw.RegionFrPar, s.ID)),
+	})
+	assert.Equal(t, "2", stringAttribute(t, attributes, "revision"))
+	assert.Equal(t, scw.RegionFrPar.String(), stringAttribute(t, attributes, "region"))
+	assert.Equal(t, base64.StdEncoding.EncodeToString([]byte("second data")), stringAttribute(t, attributes, "data"))
+
+	// A secret can be accessed by its name
+	attributes, _ = openEphemeralResource(t, tt, "scaleway_secret_version", map[string]tftypes.Value{
+		"secret_name": tftypes.NewValue(tftypes.String, "tf-tests-ephemeral-secret"),
+		"revision":    tftypes.NewValue(tftypes.String, "1"),
+	})
+	assert.Equal(t, newRegionalIDString(scw.RegionFrPar, s.ID), stringAttribute(t, attributes, "secret_id"))
+	assert.Equal(t, "1", stringAttribute(t, attributes, "revision"))
+	assert.Equal(t, base64.StdEncoding.EncodeToString([]byte("first data")), stringAttribute(t, attributes, "data"))
+}
@@ -0,0 +1,102 @@
+package scaleway
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+)
+
+var _ function.Function = (*buildZonedIDFunction)(nil)
+
+// buildZonedIDFunction builds a zoned ID such as fr-par-1/11111111-1111-1111-1111-111111111111
+type buildZonedIDFunction struct{}
+
+func newBuildZonedIDFunction() function.Function {
+	return &buildZonedIDFunction{}
+}
+
+func (f *buildZonedIDFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "build_zoned_id"
+}
+
+func (f *buildZonedIDFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:     "Build a zoned ID",
+		Description: "Returns the ID formatted as {zone}/{id}, as expected by the arguments of zoned resources. An ID that is already zoned is replaced.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "zone",
+				Description: "The zone of the resource",
+			},
+			function.StringParameter{
+				Name:        "id",
+				Description: "The ID of the resource",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *buildZonedIDFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var rawZone, id string
+	resp.Error = req.Arguments.Get(ctx, &rawZone, &id)
+	if resp.Error != nil {
+		return
+	}
+
+	zone, err := scw.ParseZone(rawZone)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+
+	resp.Error = resp.Result.Set(ctx, newZonedIDString(zone, expandID(id)))
+}
+
+var _ function.Function = (*buildRegionalIDFunction)(nil)
+
+// buildRegionalIDFunction builds a regional ID such as fr-par/11111111-1111-1111-1111-111111111111
+type buildRegionalIDFunction struct{}
+
+func newBuildRegionalIDFunction() function.Function {
+	return &buildRegionalIDFunction{}
+}
+
+func (f *buildRegionalIDFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "build_regional_id"
+}
+
+func (f *buildRegionalIDFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:     "Build a regional ID",
+		Description: "Returns the ID formatted as {region}/{id}, as expected by the arguments of regional resources. An ID that is already regional is replaced.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "region",
+				Description: "The region of the resource",
+			},
+			function.StringParameter{
+				Name:        "id",
+				Description: "The ID of the resource",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *buildRegionalIDFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var rawRegion, id string
+	resp.Error = req.Arguments.Get(ctx, &rawRegion, &id)
+	if resp.Error != nil {
+		return
+	}
+
+	region, err := scw.ParseRegion(rawRegion)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+
+	resp.Error = resp.Result.Set(ctx, newRegionalIDString(region, expandID(id)))
+}
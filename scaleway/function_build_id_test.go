@@ -0,0 +1,68 @@
+package scaleway
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuildIDFunctions(t *testing.T) {
+	testCases := []struct {
+		name      string
+		function  func() function.Function
+		locality  string
+		id        string
+		expected  string
+		expectErr bool
+	}{
+		{
+			name:     "zoned id",
+			function: newBuildZonedIDFunction,
+			locality: "fr-par-1",
+			id:       "11111111-1111-1111-1111-111111111111",
+			expected: "fr-par-1/11111111-1111-1111-1111-111111111111",
+		},
+		{
+			name:     "zoned id from another zone",
+			function: newBuildZonedIDFunction,
+			locality: "nl-ams-1",
+			id:       "fr-par-1/11111111-1111-1111-1111-111111111111",
+			expected: "nl-ams-1/11111111-1111-1111-1111-111111111111",
+		},
+		{
+			name:      "zoned id with region",
+			function:  newBuildZonedIDFunction,
+			locality:  "fr-par",
+			id:        "11111111-1111-1111-1111-111111111111",
+			expectErr: true,
+		},
+		{
+			name:     "regional id",
+			function: newBuildRegionalIDFunction,
+			locality: "pl-waw",
+			id:       "11111111-1111-1111-1111-111111111111",
+			expected: "pl-waw/11111111-1111-1111-1111-111111111111",
+		},
+		{
+			name:      "regional id with zone",
+			function:  newBuildRegionalIDFunction,
+			locality:  "pl-waw-1",
+			id:        "11111111-1111-1111-1111-111111111111",
+			expectErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			result, err := runFunction(t, tc.function(), types.StringUnknown(), types.StringValue(tc.locality), types.StringValue(tc.id))
+			if tc.expectErr {
+				assert.NotNil(t, err)
+				return
+			}
+			assert.Nil(t, err)
+			assert.Equal(t, types.StringValue(tc.expected), result)
+		})
+	}
+}
@@ -0,0 +1,162 @@
+package scaleway
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+	"github.com/scaleway/scaleway-sdk-go/validation"
+)
+
+// localizedIDModel is the object returned by the parse_id and parse_nested_id functions
+type localizedIDModel struct {
+	Locality types.String `tfsdk:"locality"`
+	Zone     types.String `tfsdk:"zone"`
+	Region   types.String `tfsdk:"region"`
+	ParentID types.String `tfsdk:"parent_id"`
+	ID       types.String `tfsdk:"id"`
+}
+
+var localizedIDAttributeTypes = map[string]attr.Type{
+	"locality":  types.StringType,
+	"zone":      types.StringType,
+	"region":    types.StringType,
+	"parent_id": types.StringType,
+	"id":        types.StringType,
+}
+
+// newLocalizedIDModel returns the model of an ID, its zone is null if the locality is a region
+func newLocalizedIDModel(locality, parentID, id string) (localizedIDModel, error) {
+	model := localizedIDModel{
+		Locality: types.StringValue(locality),
+		Zone:     types.StringNull(),
+		ParentID: types.StringNull(),
+		ID:       types.StringValue(id),
+	}
+	if parentID != "" {
+		model.ParentID = types.StringValue(parentID)
+	}
+
+	if validation.IsZone(locality) {
+		region, err := scw.Zone(locality).Region()
+		if err != nil {
+			return model, err
+		}
+		model.Zone = types.StringValue(locality)
+		model.Region = types.StringValue(region.String())
+
+		return model, nil
+	}
+
+	region, err := scw.ParseRegion(locality)
+	if err != nil {
+		return model, err
+	}
+	model.Region = types.StringValue(region.String())
+
+	return model, nil
+}
+
+var _ function.Function = (*parseIDFunction)(nil)
+
+// parseIDFunction parses a zoned or regional ID such as fr-par-1/11111111-1111-1111-1111-111111111111
+type parseIDFunction struct{}
+
+func newParseIDFunction() function.Function {
+	return &parseIDFunction{}
+}
+
+func (f *parseIDFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "parse_id"
+}
+
+func (f *parseIDFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:     "Parse a zoned or regional ID",
+		Description: "Parses an ID formatted as {zone}/{id} or {region}/{id} and returns its locality, zone, region and ID. The zone is null for regional IDs and the parent_id is always null.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "id",
+				Description: "The zoned or regional ID to parse",
+			},
+		},
+		Return: function.ObjectReturn{
+			AttributeTypes: localizedIDAttributeTypes,
+		},
+	}
+}
+
+func (f *parseIDFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var localizedID string
+	resp.Error = req.Arguments.Get(ctx, &localizedID)
+	if resp.Error != nil {
+		return
+	}
+
+	locality, id, err := parseLocalizedID(localizedID)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+
+	model, err := newLocalizedIDModel(locality, "", id)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+
+	resp.Error = resp.Result.Set(ctx, model)
+}
+
+var _ function.Function = (*parseNestedIDFunction)(nil)
+
+// parseNestedIDFunction parses a nested ID such as fr-par-1/{server_id}/{private_nic_id}
+type parseNestedIDFunction struct{}
+
+func newParseNestedIDFunction() function.Function {
+	return &parseNestedIDFunction{}
+}
+
+func (f *parseNestedIDFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "parse_nested_id"
+}
+
+func (f *parseNestedIDFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:     "Parse a nested ID",
+		Description: "Parses an ID formatted as {locality}/{parent_id}/{id} and returns its locality, zone, region, parent ID and ID. The zone is null for regional IDs.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "id",
+				Description: "The nested ID to parse",
+			},
+		},
+		Return: function.ObjectReturn{
+			AttributeTypes: localizedIDAttributeTypes,
+		},
+	}
+}
+
+func (f *parseNestedIDFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var localizedID string
+	resp.Error = req.Arguments.Get(ctx, &localizedID)
+	if resp.Error != nil {
+		return
+	}
+
+	locality, parentID, id, err := parseLocalizedNestedID(localizedID)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+
+	model, err := newLocalizedIDModel(locality, parentID, id)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+
+	resp.Error = resp.Result.Set(ctx, model)
+}
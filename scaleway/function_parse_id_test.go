@@ -0,0 +1,87 @@
+package scaleway
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseIDFunction(t *testing.T) {
+	testCases := []struct {
+		name      string
+		function  func() function.Function
+		id        string
+		expected  map[string]attr.Value
+		expectErr bool
+	}{
+		{
+			name:     "zoned id",
+			function: newParseIDFunction,
+			id:       "fr-par-1/11111111-1111-1111-1111-111111111111",
+			expected: map[string]attr.Value{
+				"locality":  types.StringValue("fr-par-1"),
+				"zone":      types.StringValue("fr-par-1"),
+				"region":    types.StringValue("fr-par"),
+				"parent_id": types.StringNull(),
+				"id":        types.StringValue("11111111-1111-1111-1111-111111111111"),
+			},
+		},
+		{
+			name:     "regional id",
+			function: newParseIDFunction,
+			id:       "nl-ams/11111111-1111-1111-1111-111111111111",
+			expected: map[string]attr.Value{
+				"locality":  types.StringValue("nl-ams"),
+				"zone":      types.StringNull(),
+				"region":    types.StringValue("nl-ams"),
+				"parent_id": types.StringNull(),
+				"id":        types.StringValue("11111111-1111-1111-1111-111111111111"),
+			},
+		},
+		{
+			name:      "id without locality",
+			function:  newParseIDFunction,
+			id:        "11111111-1111-1111-1111-111111111111",
+			expectErr: true,
+		},
+		{
+			name:      "invalid locality",
+			function:  newParseIDFunction,
+			id:        "par/11111111-1111-1111-1111-111111111111",
+			expectErr: true,
+		},
+		{
+			name:     "nested id",
+			function: newParseNestedIDFunction,
+			id:       "fr-par-2/11111111-1111-1111-1111-111111111111/22222222-2222-2222-2222-222222222222",
+			expected: map[string]attr.Value{
+				"locality":  types.StringValue("fr-par-2"),
+				"zone":      types.StringValue("fr-par-2"),
+				"region":    types.StringValue("fr-par"),
+				"parent_id": types.StringValue("11111111-1111-1111-1111-111111111111"),
+				"id":        types.StringValue("22222222-2222-2222-2222-222222222222"),
+			},
+		},
+		{
+			name:      "nested id without parent",
+			function:  newParseNestedIDFunction,
+			id:        "fr-par-2/11111111-1111-1111-1111-111111111111",
+			expectErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			result, err := runFunction(t, tc.function(), types.ObjectUnknown(localizedIDAttributeTypes), types.StringValue(tc.id))
+			if tc.expectErr {
+				assert.NotNil(t, err)
+				return
+			}
+			assert.Nil(t, err)
+			assert.Equal(t, types.ObjectValueMust(localizedIDAttributeTypes, tc.expected), result)
+		})
+	}
+}
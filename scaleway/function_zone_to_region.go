@@ -0,0 +1,56 @@
+package scaleway
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+)
+
+var _ function.Function = (*zoneToRegionFunction)(nil)
+
+// zoneToRegionFunction returns the region of a zone, such as fr-par for fr-par-1
+type zoneToRegionFunction struct{}
+
+func newZoneToRegionFunction() function.Function {
+	return &zoneToRegionFunction{}
+}
+
+func (f *zoneToRegionFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "zone_to_region"
+}
+
+func (f *zoneToRegionFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:     "Get the region of a zone",
+		Description: "Returns the region of the given zone, such as fr-par for fr-par-1.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "zone",
+				Description: "The zone to get the region of",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *zoneToRegionFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var rawZone string
+	resp.Error = req.Arguments.Get(ctx, &rawZone)
+	if resp.Error != nil {
+		return
+	}
+
+	zone, err := scw.ParseZone(rawZone)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+	region, err := zone.Region()
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+
+	resp.Error = resp.Result.Set(ctx, region.String())
+}
@@ -0,0 +1,41 @@
+package scaleway
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestZoneToRegionFunction(t *testing.T) {
+	testCases := []struct {
+		zone      string
+		expected  string
+		expectErr bool
+	}{
+		{
+			zone:     "fr-par-1",
+			expected: "fr-par",
+		},
+		{
+			zone:     "pl-waw-3",
+			expected: "pl-waw",
+		},
+		{
+			zone:      "fr-par",
+			expectErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.zone, func(t *testing.T) {
+			result, err := runFunction(t, newZoneToRegionFunction(), types.StringUnknown(), types.StringValue(tc.zone))
+			if tc.expectErr {
+				assert.NotNil(t, err)
+				return
+			}
+			assert.Nil(t, err)
+			assert.Equal(t, types.StringValue(tc.expected), result)
+		})
+	}
+}
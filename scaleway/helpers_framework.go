@@ -0,0 +1,66 @@
+package scaleway
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	ephemeralSchema "github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+)
+
+// frameworkMeta holds the provider meta of framework resources, it is set when the provider is configured
+type frameworkMeta struct {
+	meta *Meta
+}
+
+// configure sets the meta from the provider data, which is nil until the provider is configured
+func (m *frameworkMeta) configure(providerData interface{}, diags *diag.Diagnostics) {
+	if providerData == nil {
+		return
+	}
+
+	meta, ok := providerData.(*Meta)
+	if !ok {
+		diags.AddError("Unexpected provider data", fmt.Sprintf("Expected *Meta, got %T", providerData))
+		return
+	}
+	m.meta = meta
+}
+
+// forProfile returns the meta of the profile set on the resource, or the provider meta if there is none
+func (m *frameworkMeta) forProfile(ctx context.Context, profile types.String) (*Meta, error) {
+	return m.meta.forProfile(ctx, profile.ValueString())
+}
+
+// frameworkRegion returns the region of a framework resource, the default one of the meta if the region is not set
+func frameworkRegion(meta *Meta, region types.String) (scw.Region, error) {
+	if region.ValueString() != "" {
+		return scw.ParseRegion(region.ValueString())
+	}
+
+	defaultRegion, exists := meta.scwClient.GetDefaultRegion()
+	if !exists {
+		return "", ErrRegionNotFound
+	}
+
+	return defaultRegion, nil
+}
+
+// ephemeralRegionSchema returns the schema of the region attribute of ephemeral resources
+func ephemeralRegionSchema() ephemeralSchema.StringAttribute {
+	return ephemeralSchema.StringAttribute{
+		Optional:    true,
+		Computed:    true,
+		Description: "The region of the resource, the default region of the provider if not set",
+	}
+}
+
+// ephemeralProfileSchema returns the schema of the profile attribute of ephemeral resources
+func ephemeralProfileSchema() ephemeralSchema.StringAttribute {
+	return ephemeralSchema.StringAttribute{
+		Optional:    true,
+		Description: "The profile of the scw config file used to open the resource, overriding the credentials and defaults of the provider",
+	}
+}
@@ -560,6 +560,32 @@ func waitForInstanceImage(ctx context.Context, api *instance.API, zone scw.Zone,
 	return image, err
 }
 
+// instanceTaskImageID returns the ID of the image created by a backup task, its result is of the form /images/<id>
+func instanceTaskImageID(task *instance.Task) string {
+	if task == nil {
+		return ""
+	}
+	return task.HrefResult[strings.LastIndex(task.HrefResult, "/")+1:]
+}
+
+// instanceImageVolumes returns the snapshots of an image, root volume first
+func instanceImageVolumes(image *instance.Image) []*instance.VolumeSummary {
+	volumes := []*instance.VolumeSummary(nil)
+	if image.RootVolume != nil {
+		volumes = append(volumes, image.RootVolume)
+	}
+	for _, volume := range orderVolumes(image.ExtraVolumes) {
+		volumes = append(volumes, &instance.VolumeSummary{
+			ID:         volume.ID,
+			Name:       volume.Name,
+			Size:       volume.Size,
+			VolumeType: volume.VolumeType,
+		})
+	}
+
+	return volumes
+}
+
 func getSnapshotsFromIds(ctx context.Context, snapIDs []interface{}, instanceAPI *instance.API) ([]*instance.GetSnapshotResponse, error) {
 	snapResponses := []*instance.GetSnapshotResponse(nil)
 	for _, snapID := range snapIDs {
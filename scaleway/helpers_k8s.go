@@ -1,13 +1,23 @@
 package scaleway
 
 import (
+	"bytes"
 	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
+	"net/http"
+	"net/url"
 	"strconv"
 	"strings"
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/scaleway/scaleway-sdk-go/api/k8s/v1"
 	"github.com/scaleway/scaleway-sdk-go/scw"
 )
@@ -18,6 +28,12 @@ const (
 	defaultK8SRetryInterval  = 5 * time.Second
 )
 
+const (
+	k8sTaintEffectNoSchedule       = "NoSchedule"
+	k8sTaintEffectPreferNoSchedule = "PreferNoSchedule"
+	k8sTaintEffectNoExecute        = "NoExecute"
+)
+
 func k8sAPIWithRegion(d *schema.ResourceData, m interface{}) (*k8s.API, scw.Region, error) {
 	meta := m.(*Meta)
 	k8sAPI := k8s.NewAPI(meta.scwClient)
@@ -258,6 +274,59 @@ func flattenKubeletArgs(args map[string]string) map[string]interface{} {
 	return kubeletArgs
 }
 
+// k8sTaintSchema is the schema of the taints of the nodes of a pool
+func k8sTaintSchema() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"key": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The key of the taint",
+			},
+			"value": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The value of the taint",
+			},
+			"effect": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      k8sTaintEffectNoSchedule,
+				Description:  "The effect of the taint on the pods which do not tolerate it",
+				ValidateFunc: validation.StringInSlice([]string{k8sTaintEffectNoSchedule, k8sTaintEffectPreferNoSchedule, k8sTaintEffectNoExecute}, false),
+			},
+		},
+	}
+}
+
+func expandK8STaints(raw interface{}) []*k8sTaint {
+	rawTaints := raw.(*schema.Set).List()
+	taints := make([]*k8sTaint, 0, len(rawTaints))
+	for _, rawTaint := range rawTaints {
+		taint := rawTaint.(map[string]interface{})
+		taints = append(taints, &k8sTaint{
+			Key:    taint["key"].(string),
+			Value:  taint["value"].(string),
+			Effect: taint["effect"].(string),
+		})
+	}
+
+	return taints
+}
+
+func flattenK8STaints(taints []*k8sTaint) []map[string]interface{} {
+	res := make([]map[string]interface{}, 0, len(taints))
+	for _, taint := range taints {
+		res = append(res, map[string]interface{}{
+			"key":    taint.Key,
+			"value":  taint.Value,
+			"effect": taint.Effect,
+		})
+	}
+
+	return res
+}
+
 func flattenKubeconfig(ctx context.Context, k8sAPI *k8s.API, region scw.Region, clusterID string) (map[string]interface{}, error) {
 	kubeconfig, err := k8sAPI.GetClusterKubeConfig(&k8s.GetClusterKubeConfigRequest{
 		Region:    region,
@@ -311,3 +380,450 @@ func migrateToPrivateNetworkCluster(ctx context.Context, d *schema.ResourceData,
 	}
 	return nil
 }
+
+// k8sACLAPI requests the ACL endpoints of the Kubernetes API, which are not part of the vendored SDK yet.
+// Its methods mirror the ones of the SDK so it can be replaced by k8s.API once the SDK is updated.
+type k8sACLAPI struct {
+	client *scw.Client
+}
+
+func newK8SACLAPI(client *scw.Client) *k8sACLAPI {
+	return &k8sACLAPI{client: client}
+}
+
+// k8sACLRule is a rule allowing an IP range, or the Scaleway ranges, to reach the control plane of a cluster
+type k8sACLRule struct {
+	ID             string     `json:"id,omitempty"`
+	IP             *scw.IPNet `json:"ip,omitempty"`
+	ScalewayRanges *bool      `json:"scaleway_ranges,omitempty"`
+	Description    string     `json:"description"`
+}
+
+type k8sListClusterACLRulesResponse struct {
+	TotalCount uint64        `json:"total_count"`
+	Rules      []*k8sACLRule `json:"rules"`
+}
+
+// UnsafeGetTotalCount is used by the SDK client to fetch all the pages
+func (r *k8sListClusterACLRulesResponse) UnsafeGetTotalCount() uint64 {
+	return r.TotalCount
+}
+
+// UnsafeAppend is used by the SDK client to fetch all the pages
+func (r *k8sListClusterACLRulesResponse) UnsafeAppend(res interface{}) (uint64, error) {
+	results, ok := res.(*k8sListClusterACLRulesResponse)
+	if !ok {
+		return 0, fmt.Errorf("%T type cannot be appended to type %T", res, r)
+	}
+
+	r.Rules = append(r.Rules, results.Rules...)
+	r.TotalCount += uint64(len(results.Rules))
+	return uint64(len(results.Rules)), nil
+}
+
+type k8sSetClusterACLRulesResponse struct {
+	Rules []*k8sACLRule `json:"rules"`
+}
+
+func (api *k8sACLAPI) ListClusterACLRules(region scw.Region, clusterID string, opts ...scw.RequestOption) (*k8sListClusterACLRulesResponse, error) {
+	if clusterID == "" {
+		return nil, errors.New("field ClusterID cannot be empty in request")
+	}
+
+	var resp k8sListClusterACLRulesResponse
+	err := api.client.Do(&scw.ScalewayRequest{
+		Method: "GET",
+		Path:   "/k8s/v1/regions/" + region.String() + "/clusters/" + clusterID + "/acls",
+		Query:  url.Values{},
+	}, &resp, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// SetClusterACLRules replaces all the rules of the cluster, an empty list denies all the IPs
+func (api *k8sACLAPI) SetClusterACLRules(region scw.Region, clusterID string, rules []*k8sACLRule, opts ...scw.RequestOption) (*k8sSetClusterACLRulesResponse, error) {
+	if clusterID == "" {
+		return nil, errors.New("field ClusterID cannot be empty in request")
+	}
+	if rules == nil {
+		rules = []*k8sACLRule{}
+	}
+
+	req := &scw.ScalewayRequest{
+		Method: "PUT",
+		Path:   "/k8s/v1/regions/" + region.String() + "/clusters/" + clusterID + "/acls",
+	}
+	err := req.SetBody(map[string]interface{}{"acls": rules})
+	if err != nil {
+		return nil, err
+	}
+
+	var resp k8sSetClusterACLRulesResponse
+	err = api.client.Do(req, &resp, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// k8sPoolAPI requests the pool endpoints of the Kubernetes API handling the labels and taints of the nodes, which are not part of the vendored SDK yet.
+// Its methods mirror the ones of the SDK so it can be replaced by k8s.API once the SDK is updated.
+type k8sPoolAPI struct {
+	client *scw.Client
+}
+
+func newK8SPoolAPI(client *scw.Client) *k8sPoolAPI {
+	return &k8sPoolAPI{client: client}
+}
+
+// k8sTaint is a Kubernetes taint of the nodes of a pool
+type k8sTaint struct {
+	Key    string `json:"key"`
+	Value  string `json:"value"`
+	Effect string `json:"effect"`
+}
+
+// k8sPool is a pool with the labels and taints of its nodes
+type k8sPool struct {
+	k8s.Pool
+	// Labels are applied on the nodes and reconciled
+	Labels map[string]string `json:"labels"`
+	// Taints are applied on the nodes and reconciled
+	Taints []*k8sTaint `json:"taints"`
+	// StartupTaints are applied on the nodes when they are created, and not reconciled afterwards
+	StartupTaints []*k8sTaint `json:"startup_taints"`
+}
+
+type k8sCreatePoolRequest struct {
+	*k8s.CreatePoolRequest
+	Labels        map[string]string `json:"labels,omitempty"`
+	Taints        []*k8sTaint       `json:"taints,omitempty"`
+	StartupTaints []*k8sTaint       `json:"startup_taints,omitempty"`
+}
+
+func (api *k8sPoolAPI) CreatePool(req *k8sCreatePoolRequest, opts ...scw.RequestOption) (*k8sPool, error) {
+	if req.Region == "" {
+		req.Region, _ = api.client.GetDefaultRegion()
+	}
+	if req.Zone == "" {
+		req.Zone, _ = api.client.GetDefaultZone()
+	}
+	if req.ClusterID == "" {
+		return nil, errors.New("field ClusterID cannot be empty in request")
+	}
+
+	scwReq := &scw.ScalewayRequest{
+		Method: "POST",
+		Path:   "/k8s/v1/regions/" + req.Region.String() + "/clusters/" + req.ClusterID + "/pools",
+	}
+	err := scwReq.SetBody(req)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp k8sPool
+	err = api.client.Do(scwReq, &resp, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+func (api *k8sPoolAPI) GetPool(region scw.Region, poolID string, opts ...scw.RequestOption) (*k8sPool, error) {
+	if poolID == "" {
+		return nil, errors.New("field PoolID cannot be empty in request")
+	}
+
+	var resp k8sPool
+	err := api.client.Do(&scw.ScalewayRequest{
+		Method: "GET",
+		Path:   "/k8s/v1/regions/" + region.String() + "/pools/" + poolID,
+	}, &resp, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// SetPoolLabels replaces the labels set on the nodes of the pool, the other labels of the nodes are left
+func (api *k8sPoolAPI) SetPoolLabels(region scw.Region, poolID string, labels map[string]string, opts ...scw.RequestOption) (*k8sPool, error) {
+	if labels == nil {
+		labels = map[string]string{}
+	}
+
+	return api.setPoolNodeConfig(region, poolID, "set-labels", map[string]interface{}{"labels": labels}, opts...)
+}
+
+// SetPoolTaints replaces the taints set on the nodes of the pool
+func (api *k8sPoolAPI) SetPoolTaints(region scw.Region, poolID string, taints []*k8sTaint, opts ...scw.RequestOption) (*k8sPool, error) {
+	if taints == nil {
+		taints = []*k8sTaint{}
+	}
+
+	return api.setPoolNodeConfig(region, poolID, "set-taints", map[string]interface{}{"taints": taints}, opts...)
+}
+
+// SetPoolStartupTaints replaces the taints set on the new nodes of the pool, the existing nodes are left
+func (api *k8sPoolAPI) SetPoolStartupTaints(region scw.Region, poolID string, taints []*k8sTaint, opts ...scw.RequestOption) (*k8sPool, error) {
+	if taints == nil {
+		taints = []*k8sTaint{}
+	}
+
+	return api.setPoolNodeConfig(region, poolID, "set-startup-taints", map[string]interface{}{"startup_taints": taints}, opts...)
+}
+
+func (api *k8sPoolAPI) setPoolNodeConfig(region scw.Region, poolID string, action string, body map[string]interface{}, opts ...scw.RequestOption) (*k8sPool, error) {
+	if poolID == "" {
+		return nil, errors.New("field PoolID cannot be empty in request")
+	}
+
+	req := &scw.ScalewayRequest{
+		Method: "PUT",
+		Path:   "/k8s/v1/regions/" + region.String() + "/pools/" + poolID + "/" + action,
+	}
+	err := req.SetBody(body)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp k8sPool
+	err = api.client.Do(req, &resp, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+var errK8SNodeNotDrained = errors.New("node is not drained")
+
+// k8sKubeClient requests the API server of a cluster, with the credentials of its kubeconfig.
+// It only implements the few calls needed to drain nodes, so the provider does not depend on client-go.
+type k8sKubeClient struct {
+	httpClient *http.Client
+	host       string
+	token      string
+}
+
+func newK8SKubeClient(ctx context.Context, meta *Meta, k8sAPI *k8s.API, region scw.Region, clusterID string) (*k8sKubeClient, error) {
+	kubeconfig, err := flattenKubeconfig(ctx, k8sAPI, region, clusterID)
+	if err != nil {
+		return nil, err
+	}
+
+	// The http client given to the provider, e.g. to record and replay cassettes, is used as is.
+	// Otherwise, the certificate authority of the cluster is trusted.
+	httpClient := meta.httpClient
+	if meta.config == nil || meta.config.httpClient == nil {
+		httpClient, err = newK8SKubeHTTPClient(kubeconfig["cluster_ca_certificate"].(string))
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return &k8sKubeClient{
+		httpClient: httpClient,
+		host:       strings.TrimSuffix(kubeconfig["host"].(string), "/"),
+		token:      kubeconfig["token"].(string),
+	}, nil
+}
+
+func newK8SKubeHTTPClient(caCertificate string) (*http.Client, error) {
+	caPEM, err := base64.StdEncoding.DecodeString(caCertificate)
+	if err != nil {
+		return nil, fmt.Errorf("invalid certificate authority of the cluster: %w", err)
+	}
+	certPool := x509.NewCertPool()
+	if !certPool.AppendCertsFromPEM(caPEM) {
+		return nil, errors.New("invalid certificate authority of the cluster: no PEM certificate found")
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = &tls.Config{
+		RootCAs:    certPool,
+		MinVersion: tls.VersionTLS12,
+	}
+
+	return &http.Client{Transport: newRetryableTransport(newLoggingTransport(transport))}, nil
+}
+
+// k8sKubeError is an error returned by the API server of a cluster
+type k8sKubeError struct {
+	StatusCode int
+	Message    string
+}
+
+func (e *k8sKubeError) Error() string {
+	return fmt.Sprintf("kubernetes API error %d: %s", e.StatusCode, e.Message)
+}
+
+func isK8SKubeError(err error, statusCode int) bool {
+	var kubeErr *k8sKubeError
+	return errors.As(err, &kubeErr) && kubeErr.StatusCode == statusCode
+}
+
+func (c *k8sKubeClient) do(ctx context.Context, method string, path string, query url.Values, contentType string, body interface{}, res interface{}) error {
+	var reqBody io.Reader
+	if body != nil {
+		rawBody, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reqBody = bytes.NewReader(rawBody)
+	}
+
+	reqURL := c.host + path
+	if len(query) > 0 {
+		reqURL += "?" + query.Encode()
+	}
+	req, err := http.NewRequestWithContext(ctx, method, reqURL, reqBody)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+c.token)
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", contentType)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	rawResp, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode >= 400 {
+		status := struct {
+			Message string `json:"message"`
+		}{}
+		if json.Unmarshal(rawResp, &status) != nil || status.Message == "" {
+			status.Message = http.StatusText(resp.StatusCode)
+		}
+		return &k8sKubeError{StatusCode: resp.StatusCode, Message: status.Message}
+	}
+
+	if res == nil || len(rawResp) == 0 {
+		return nil
+	}
+	return json.Unmarshal(rawResp, res)
+}
+
+// k8sKubePod holds the fields of a pod needed to drain its node
+type k8sKubePod struct {
+	Metadata struct {
+		Name            string            `json:"name"`
+		Namespace       string            `json:"namespace"`
+		Annotations     map[string]string `json:"annotations"`
+		OwnerReferences []struct {
+			Kind string `json:"kind"`
+		} `json:"ownerReferences"`
+	} `json:"metadata"`
+	Status struct {
+		Phase string `json:"phase"`
+	} `json:"status"`
+}
+
+// evictable tells whether the pod must be evicted to drain its node, like kubectl drain --ignore-daemonsets does
+func (pod *k8sKubePod) evictable() bool {
+	if pod.Status.Phase == "Succeeded" || pod.Status.Phase == "Failed" {
+		return false
+	}
+	if _, isMirror := pod.Metadata.Annotations["kubernetes.io/config.mirror"]; isMirror {
+		return false
+	}
+	for _, owner := range pod.Metadata.OwnerReferences {
+		if owner.Kind == "DaemonSet" {
+			return false
+		}
+	}
+
+	return true
+}
+
+// cordonNode marks the node as unschedulable, so no new pod is scheduled on it
+func (c *k8sKubeClient) cordonNode(ctx context.Context, nodeName string) error {
+	return c.do(ctx, http.MethodPatch, "/api/v1/nodes/"+url.PathEscape(nodeName), nil, "application/merge-patch+json", map[string]interface{}{
+		"spec": map[string]interface{}{
+			"unschedulable": true,
+		},
+	}, nil)
+}
+
+func (c *k8sKubeClient) listNodePods(ctx context.Context, nodeName string) ([]*k8sKubePod, error) {
+	res := struct {
+		Items []*k8sKubePod `json:"items"`
+	}{}
+	err := c.do(ctx, http.MethodGet, "/api/v1/pods", url.Values{"fieldSelector": []string{"spec.nodeName=" + nodeName}}, "", nil, &res)
+	if err != nil {
+		return nil, err
+	}
+
+	return res.Items, nil
+}
+
+// evictPod evicts the pod through the eviction API, so the disruption budgets are respected
+func (c *k8sKubeClient) evictPod(ctx context.Context, pod *k8sKubePod) error {
+	return c.do(ctx, http.MethodPost, "/api/v1/namespaces/"+url.PathEscape(pod.Metadata.Namespace)+"/pods/"+url.PathEscape(pod.Metadata.Name)+"/eviction", nil, "application/json", map[string]interface{}{
+		"apiVersion": "policy/v1",
+		"kind":       "Eviction",
+		"metadata": map[string]interface{}{
+			"name":      pod.Metadata.Name,
+			"namespace": pod.Metadata.Namespace,
+		},
+	}, nil)
+}
+
+// drainNode cordons the node then evicts its pods until only the ones of daemon sets are left.
+// Evictions refused by a disruption budget are retried until the timeout.
+func (c *k8sKubeClient) drainNode(ctx context.Context, nodeName string, timeout time.Duration) error {
+	err := c.cordonNode(ctx, nodeName)
+	if err != nil {
+		return fmt.Errorf("failed to cordon node %s: %w", nodeName, err)
+	}
+
+	retryInterval := defaultK8SRetryInterval
+	if DefaultWaitRetryInterval != nil {
+		retryInterval = *DefaultWaitRetryInterval
+	}
+
+	_, err = retryWhen(ctx, &RetryWhenConfig[struct{}]{
+		Timeout:  timeout,
+		Interval: retryInterval,
+		Function: func() (struct{}, error) {
+			pods, err := c.listNodePods(ctx, nodeName)
+			if err != nil {
+				return struct{}{}, err
+			}
+
+			drained := true
+			for _, pod := range pods {
+				if !pod.evictable() {
+					continue
+				}
+				drained = false
+				err := c.evictPod(ctx, pod)
+				if err != nil && !isK8SKubeError(err, http.StatusNotFound) && !isK8SKubeError(err, http.StatusTooManyRequests) {
+					return struct{}{}, fmt.Errorf("failed to evict pod %s/%s: %w", pod.Metadata.Namespace, pod.Metadata.Name, err)
+				}
+			}
+			if !drained {
+				return struct{}{}, errK8SNodeNotDrained
+			}
+
+			return struct{}{}, nil
+		},
+	}, func(err error) bool {
+		return errors.Is(err, errK8SNodeNotDrained)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to drain node %s: %w", nodeName, err)
+	}
+
+	return nil
+}
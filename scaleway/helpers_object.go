@@ -39,12 +39,16 @@ const (
 	errCodeForbidden = "Forbidden"
 )
 
-func newS3Client(httpClient *http.Client, region, accessKey, secretKey string) (*s3.S3, error) {
+func newS3Client(httpClient *http.Client, endpoint, region, accessKey, secretKey string) (*s3.S3, error) {
 	config := &aws.Config{}
 	config.WithRegion(region)
 	config.WithCredentials(credentials.NewStaticCredentials(accessKey, secretKey, ""))
 	if ep := os.Getenv("SCW_S3_ENDPOINT"); ep != "" {
 		config.WithEndpoint(ep)
+	} else if endpoint != "" {
+		// Endpoints set in the provider are mostly local mocks that do not support virtual-hosted buckets
+		config.WithEndpoint(endpoint)
+		config.WithS3ForcePathStyle(true)
 	} else {
 		config.WithEndpoint("https://s3." + region + ".scw.cloud")
 	}
@@ -74,7 +78,7 @@ func newS3ClientFromMeta(meta *Meta, region string) (*s3.S3, error) {
 		region = defaultRegion.String()
 	}
 
-	return newS3Client(meta.httpClient, region, accessKey, secretKey)
+	return newS3Client(meta.httpClient, meta.s3Endpoint, region, accessKey, secretKey)
 }
 
 func s3ClientWithRegion(d *schema.ResourceData, m interface{}) (*s3.S3, scw.Region, error) {
@@ -90,7 +94,7 @@ func s3ClientWithRegion(d *schema.ResourceData, m interface{}) (*s3.S3, scw.Regi
 	}
 	secretKey, _ := meta.scwClient.GetSecretKey()
 
-	s3Client, err := newS3Client(meta.httpClient, region.String(), accessKey, secretKey)
+	s3Client, err := newS3Client(meta.httpClient, meta.s3Endpoint, region.String(), accessKey, secretKey)
 	if err != nil {
 		return nil, "", err
 	}
@@ -125,7 +129,7 @@ func s3ClientWithRegionAndName(d *schema.ResourceData, m interface{}, id string)
 		}
 	}
 
-	s3Client, err := newS3Client(meta.httpClient, region.String(), accessKey, secretKey)
+	s3Client, err := newS3Client(meta.httpClient, meta.s3Endpoint, region.String(), accessKey, secretKey)
 	if err != nil {
 		return nil, "", "", err
 	}
@@ -146,7 +150,7 @@ func s3ClientWithRegionAndNestedName(d *schema.ResourceData, m interface{}, name
 	}
 	secretKey, _ := meta.scwClient.GetSecretKey()
 
-	s3Client, err := newS3Client(meta.httpClient, region.String(), accessKey, secretKey)
+	s3Client, err := newS3Client(meta.httpClient, meta.s3Endpoint, region.String(), accessKey, secretKey)
 	if err != nil {
 		return nil, "", "", "", err
 	}
@@ -167,7 +171,7 @@ func s3ClientWithRegionWithNameACL(d *schema.ResourceData, m interface{}, name s
 	}
 	secretKey, _ := meta.scwClient.GetSecretKey()
 
-	s3Client, err := newS3Client(meta.httpClient, region, accessKey, secretKey)
+	s3Client, err := newS3Client(meta.httpClient, meta.s3Endpoint, region, accessKey, secretKey)
 	if err != nil {
 		return nil, "", "", "", err
 	}
@@ -183,7 +187,7 @@ func s3ClientForceRegion(d *schema.ResourceData, m interface{}, region string) (
 	}
 	secretKey, _ := meta.scwClient.GetSecretKey()
 
-	s3Client, err := newS3Client(meta.httpClient, region, accessKey, secretKey)
+	s3Client, err := newS3Client(meta.httpClient, meta.s3Endpoint, region, accessKey, secretKey)
 	if err != nil {
 		return nil, err
 	}
@@ -317,7 +321,7 @@ func expandBucketCORS(ctx context.Context, rawCors []interface{}, bucket string)
 	return rules
 }
 
-func deleteS3ObjectVersion(conn *s3.S3, bucketName string, key string, versionID string, force bool) error {
+func deleteS3ObjectVersion(ctx context.Context, conn *s3.S3, bucketName string, key string, versionID string, force bool) error {
 	input := &s3.DeleteObjectInput{
 		Bucket: scw.StringPtr(bucketName),
 		Key:    scw.StringPtr(key),
@@ -329,14 +333,14 @@ func deleteS3ObjectVersion(conn *s3.S3, bucketName string, key string, versionID
 		input.BypassGovernanceRetention = scw.BoolPtr(force)
 	}
 
-	_, err := conn.DeleteObject(input)
+	_, err := conn.DeleteObjectWithContext(ctx, input)
 	return err
 }
 
 // removeS3ObjectVersionLegalHold remove legal hold from an ObjectVersion if it is on
 // returns true if legal hold was removed
-func removeS3ObjectVersionLegalHold(conn *s3.S3, bucketName string, objectVersion *s3.ObjectVersion) (bool, error) {
-	objectHead, err := conn.HeadObject(&s3.HeadObjectInput{
+func removeS3ObjectVersionLegalHold(ctx context.Context, conn *s3.S3, bucketName string, objectVersion *s3.ObjectVersion) (bool, error) {
+	objectHead, err := conn.HeadObjectWithContext(ctx, &s3.HeadObjectInput{
 		Bucket:    scw.StringPtr(bucketName),
 		Key:       objectVersion.Key,
 		VersionId: objectVersion.VersionId,
@@ -348,7 +352,7 @@ func removeS3ObjectVersionLegalHold(conn *s3.S3, bucketName string, objectVersio
 	if aws.StringValue(objectHead.ObjectLockLegalHoldStatus) != s3.ObjectLockLegalHoldStatusOn {
 		return false, nil
 	}
-	_, err = conn.PutObjectLegalHold(&s3.PutObjectLegalHoldInput{
+	_, err = conn.PutObjectLegalHoldWithContext(ctx, &s3.PutObjectLegalHoldInput{
 		Bucket:    scw.StringPtr(bucketName),
 		Key:       objectVersion.Key,
 		VersionId: objectVersion.VersionId,
@@ -363,8 +367,9 @@ func removeS3ObjectVersionLegalHold(conn *s3.S3, bucketName string, objectVersio
 	return true, nil
 }
 
+// deleteS3ObjectVersions deletes all the object versions and delete markers of a bucket.
+// Deletions are run concurrently while the next pages are listed, and stopped on the first error.
 func deleteS3ObjectVersions(ctx context.Context, conn *s3.S3, bucketName string, force bool) error {
-	var globalErr error
 	listInput := &s3.ListObjectVersionsInput{
 		Bucket: scw.StringPtr(bucketName),
 	}
@@ -374,83 +379,80 @@ func deleteS3ObjectVersions(ctx context.Context, conn *s3.S3, bucketName string,
 		deletionWorkers = maxObjectVersionDeletionWorkers
 	}
 
+	pool := internal.NewWorkerPool[string](ctx, deletionWorkers, internal.WithStopOnError())
 	listErr := conn.ListObjectVersionsPagesWithContext(ctx, listInput, func(page *s3.ListObjectVersionsOutput, lastPage bool) bool {
-		pool := internal.NewWorkerPool(deletionWorkers)
-
 		for _, objectVersion := range page.Versions {
 			objectVersion := objectVersion
 
-			pool.AddTask(func() error {
+			added := pool.AddTask(func(ctx context.Context) (string, error) {
 				objectKey := aws.StringValue(objectVersion.Key)
 				objectVersionID := aws.StringValue(objectVersion.VersionId)
-				err := deleteS3ObjectVersion(conn, bucketName, objectKey, objectVersionID, force)
+				err := deleteS3ObjectVersion(ctx, conn, bucketName, objectKey, objectVersionID, force)
 
 				if isS3Err(err, ErrCodeAccessDenied, "") && force {
-					legalHoldRemoved, errLegal := removeS3ObjectVersionLegalHold(conn, bucketName, objectVersion)
+					legalHoldRemoved, errLegal := removeS3ObjectVersionLegalHold(ctx, conn, bucketName, objectVersion)
 					if errLegal != nil {
-						return fmt.Errorf("failed to remove legal hold: %s", errLegal)
+						return "", fmt.Errorf("failed to remove legal hold: %s", errLegal)
 					}
 
 					if legalHoldRemoved {
-						err = deleteS3ObjectVersion(conn, bucketName, objectKey, objectVersionID, force)
+						err = deleteS3ObjectVersion(ctx, conn, bucketName, objectKey, objectVersionID, force)
 					}
 				}
 
 				if err != nil {
-					return fmt.Errorf("failed to delete S3 object: %s", err)
+					return "", fmt.Errorf("failed to delete S3 object: %s", err)
 				}
 
-				return nil
+				return objectKey, nil
 			})
-		}
-
-		errs := pool.CloseAndWait()
-		if len(errs) > 0 {
-			globalErr = multierror.Append(nil, errs...)
-			return false
+			if !added {
+				return false
+			}
 		}
 
 		return true
 	})
-	if listErr != nil {
-		return fmt.Errorf("error listing S3 objects: %s", globalErr)
+	deletedObjects, errs := pool.CloseAndWait()
+	if len(errs) > 0 {
+		return multierror.Append(nil, errs...)
 	}
-	if globalErr != nil {
-		return globalErr
+	if listErr != nil {
+		return fmt.Errorf("error listing S3 objects: %s", listErr)
 	}
+	tflog.Debug(ctx, fmt.Sprintf("S3 bucket: %s, deleted %d object versions", bucketName, len(deletedObjects)))
 
+	// Delete markers are listed once all versions are deleted
+	pool = internal.NewWorkerPool[string](ctx, deletionWorkers, internal.WithStopOnError())
 	listErr = conn.ListObjectVersionsPagesWithContext(ctx, listInput, func(page *s3.ListObjectVersionsOutput, lastPage bool) bool {
-		pool := internal.NewWorkerPool(deletionWorkers)
-
 		for _, deleteMarkerEntry := range page.DeleteMarkers {
 			deleteMarkerEntry := deleteMarkerEntry
 
-			pool.AddTask(func() error {
+			added := pool.AddTask(func(ctx context.Context) (string, error) {
 				deleteMarkerKey := aws.StringValue(deleteMarkerEntry.Key)
 				deleteMarkerVersionsID := aws.StringValue(deleteMarkerEntry.VersionId)
-				err := deleteS3ObjectVersion(conn, bucketName, deleteMarkerKey, deleteMarkerVersionsID, force)
+				err := deleteS3ObjectVersion(ctx, conn, bucketName, deleteMarkerKey, deleteMarkerVersionsID, force)
 				if err != nil {
-					return fmt.Errorf("failed to delete S3 object delete marker: %s", err)
+					return "", fmt.Errorf("failed to delete S3 object delete marker: %s", err)
 				}
 
-				return nil
+				return deleteMarkerKey, nil
 			})
-		}
-
-		errs := pool.CloseAndWait()
-		if len(errs) > 0 {
-			globalErr = multierror.Append(nil, errs...)
-			return false
+			if !added {
+				return false
+			}
 		}
 
 		return true
 	})
-	if listErr != nil {
-		return fmt.Errorf("error listing S3 objects for delete markers: %s", globalErr)
+	deletedMarkers, errs := pool.CloseAndWait()
+	if len(errs) > 0 {
+		return multierror.Append(nil, errs...)
 	}
-	if globalErr != nil {
-		return globalErr
+	if listErr != nil {
+		return fmt.Errorf("error listing S3 objects for delete markers: %s", listErr)
 	}
+	tflog.Debug(ctx, fmt.Sprintf("S3 bucket: %s, deleted %d delete markers", bucketName, len(deletedMarkers)))
 
 	return nil
 }
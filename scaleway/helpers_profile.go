@@ -0,0 +1,81 @@
+package scaleway
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// profileSchema returns the schema of the profile attribute added to every resource and data source
+func profileSchema(forceNew bool) *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeString,
+		Optional:    true,
+		ForceNew:    forceNew,
+		Description: "The profile of the scw config file used to manage the resource, overriding the credentials and defaults of the provider",
+	}
+}
+
+// withProfile gives the meta of the profile set on the resource to a CRUD function
+func withProfile(f func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics) func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics {
+	return func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+		meta, err := m.(*Meta).forProfile(ctx, d.Get("profile").(string))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		return f(ctx, d, meta)
+	}
+}
+
+// withProfileCustomizeDiff gives the meta of the profile set on the resource to a CustomizeDiff function
+func withProfileCustomizeDiff(f schema.CustomizeDiffFunc) schema.CustomizeDiffFunc {
+	return func(ctx context.Context, diff *schema.ResourceDiff, m interface{}) error {
+		meta, err := m.(*Meta).forProfile(ctx, diff.Get("profile").(string))
+		if err != nil {
+			return err
+		}
+
+		return f(ctx, diff, meta)
+	}
+}
+
+// addResourceProfiles adds the profile attribute to every resource and data source of the provider.
+// It allows a single provider configuration to manage resources in several projects or organizations
+// using the profiles defined in the scw config file.
+func addResourceProfiles(provider *schema.Provider) {
+	for _, resource := range provider.ResourcesMap {
+		if _, exists := resource.Schema["profile"]; exists {
+			continue
+		}
+		// Resources without update must recreate themselves when the profile changes
+		resource.Schema["profile"] = profileSchema(resource.UpdateContext == nil)
+
+		if resource.CreateContext != nil {
+			resource.CreateContext = withProfile(resource.CreateContext)
+		}
+		if resource.ReadContext != nil {
+			resource.ReadContext = withProfile(resource.ReadContext)
+		}
+		if resource.UpdateContext != nil {
+			resource.UpdateContext = withProfile(resource.UpdateContext)
+		}
+		if resource.DeleteContext != nil {
+			resource.DeleteContext = withProfile(resource.DeleteContext)
+		}
+		if resource.CustomizeDiff != nil {
+			resource.CustomizeDiff = withProfileCustomizeDiff(resource.CustomizeDiff)
+		}
+	}
+	for _, dataSource := range provider.DataSourcesMap {
+		if _, exists := dataSource.Schema["profile"]; exists {
+			continue
+		}
+		dataSource.Schema["profile"] = profileSchema(false)
+
+		if dataSource.ReadContext != nil {
+			dataSource.ReadContext = withProfile(dataSource.ReadContext)
+		}
+	}
+}
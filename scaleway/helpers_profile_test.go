@@ -0,0 +1,130 @@
+package scaleway
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/scaleway/scaleway-sdk-go/api/instance/v1"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMetaForProfile(t *testing.T) {
+	prodAPIRequests := 0
+	prodAPI := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		prodAPIRequests++
+		assert.Equal(t, "22222222-2222-2222-2222-222222222222", r.Header.Get("X-Auth-Token"))
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"servers": [], "total_count": 0}`))
+	}))
+	defer prodAPI.Close()
+
+	configPath := filepath.Join(t.TempDir(), "config.yaml")
+	err := os.WriteFile(configPath, []byte(`access_key: SCWXXXXXXXXXXXXXXXXX
+secret_key: 11111111-1111-1111-1111-111111111111
+default_project_id: 11111111-1111-1111-1111-111111111111
+default_zone: fr-par-1
+profiles:
+  prod:
+    access_key: SCWYYYYYYYYYYYYYYYYY
+    secret_key: 22222222-2222-2222-2222-222222222222
+    default_project_id: 22222222-2222-2222-2222-222222222222
+    default_zone: nl-ams-2
+    api_url: `+prodAPI.URL+`
+`), 0o600)
+	require.NoError(t, err)
+	t.Setenv(scw.ScwConfigPathEnv, configPath)
+
+	ctx := context.Background()
+	meta, err := buildMeta(ctx, &metaConfig{terraformVersion: "terraform-tests"})
+	require.NoError(t, err)
+
+	defaultMeta, err := meta.forProfile(ctx, "")
+	require.NoError(t, err)
+	assert.Same(t, meta, defaultMeta)
+
+	prodMeta, err := meta.forProfile(ctx, "prod")
+	require.NoError(t, err)
+	projectID, _ := prodMeta.scwClient.GetDefaultProjectID()
+	assert.Equal(t, "22222222-2222-2222-2222-222222222222", projectID)
+	zone, _ := prodMeta.scwClient.GetDefaultZone()
+	assert.Equal(t, scw.ZoneNlAms2, zone)
+	region, _ := prodMeta.scwClient.GetDefaultRegion()
+	assert.Equal(t, scw.RegionNlAms, region)
+	accessKey, _ := prodMeta.scwClient.GetAccessKey()
+	assert.Equal(t, "SCWYYYYYYYYYYYYYYYYY", accessKey)
+	// The requests of the profile are sent to its own api_url
+	assert.NotSame(t, meta.httpClient, prodMeta.httpClient)
+	_, err = instance.NewAPI(prodMeta.scwClient).ListServers(&instance.ListServersRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, 1, prodAPIRequests)
+
+	cachedProdMeta, err := meta.forProfile(ctx, "prod")
+	require.NoError(t, err)
+	assert.Same(t, prodMeta, cachedProdMeta)
+
+	_, err = meta.forProfile(ctx, "unknown")
+	require.Error(t, err)
+}
+
+func TestMetaForProfile_SharedHTTPClient(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "config.yaml")
+	err := os.WriteFile(configPath, []byte(`access_key: SCWXXXXXXXXXXXXXXXXX
+secret_key: 11111111-1111-1111-1111-111111111111
+default_zone: fr-par-1
+profiles:
+  prod:
+    secret_key: 22222222-2222-2222-2222-222222222222
+`), 0o600)
+	require.NoError(t, err)
+	t.Setenv(scw.ScwConfigPathEnv, configPath)
+
+	ctx := context.Background()
+	httpClient := &http.Client{}
+	meta, err := buildMeta(ctx, &metaConfig{terraformVersion: "terraform-tests", httpClient: httpClient})
+	require.NoError(t, err)
+
+	// An http client given to the provider, e.g. to replay cassettes, is used by every profile
+	prodMeta, err := meta.forProfile(ctx, "prod")
+	require.NoError(t, err)
+	assert.Same(t, httpClient, prodMeta.httpClient)
+}
+
+func TestMetaForProfile_SharedRateLimit(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "config.yaml")
+	err := os.WriteFile(configPath, []byte(`access_key: SCWXXXXXXXXXXXXXXXXX
+secret_key: 11111111-1111-1111-1111-111111111111
+default_zone: fr-par-1
+profiles:
+  prod:
+    secret_key: 22222222-2222-2222-2222-222222222222
+    api_url: https://api.prod.example.com
+`), 0o600)
+	require.NoError(t, err)
+	t.Setenv(scw.ScwConfigPathEnv, configPath)
+
+	providerSchema := schema.TestResourceDataRaw(t, Provider(DefaultProviderConfig())().Schema, map[string]interface{}{
+		"rate_limit": []interface{}{
+			map[string]interface{}{
+				"requests_per_second": 1.0,
+			},
+		},
+	})
+
+	ctx := context.Background()
+	meta, err := buildMeta(ctx, &metaConfig{terraformVersion: "terraform-tests", providerSchema: providerSchema})
+	require.NoError(t, err)
+	require.NotNil(t, meta.config.rateLimiter)
+
+	// The profile has its own endpoint but the token buckets of the provider
+	prodMeta, err := meta.forProfile(ctx, "prod")
+	require.NoError(t, err)
+	assert.NotSame(t, meta.httpClient, prodMeta.httpClient)
+	assert.Same(t, meta.config.rateLimiter, prodMeta.config.rateLimiter)
+}
@@ -0,0 +1,475 @@
+package scaleway
+
+import (
+	"context"
+	"reflect"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// defaultTagsSchema returns the provider schema of the default_tags block
+func defaultTagsSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeList,
+		Optional:    true,
+		MaxItems:    1,
+		Description: "Tags that are merged into the tags of every resource managed by the provider",
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"tags": {
+					Type:        schema.TypeList,
+					Optional:    true,
+					Description: "List of tags added to every taggable resource. Resources using map-style tags only get the tags formatted as key=value",
+					Elem: &schema.Schema{
+						Type: schema.TypeString,
+					},
+				},
+			},
+		},
+	}
+}
+
+// expandProviderDefaultTags returns the tags of the provider default_tags block
+func expandProviderDefaultTags(d *schema.ResourceData) []string {
+	if d == nil {
+		return nil
+	}
+	rawDefaultTags, ok := d.GetOk("default_tags.0.tags")
+	if !ok {
+		return nil
+	}
+
+	return expandStrings(rawDefaultTags)
+}
+
+// ignoreTagsSchema returns the provider schema of the ignore_tags block
+func ignoreTagsSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeList,
+		Optional:    true,
+		MaxItems:    1,
+		Description: "Tags that are ignored by the provider, they are neither shown in diffs nor removed on update",
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"keys": {
+					Type:        schema.TypeList,
+					Optional:    true,
+					Description: "List of exact tag keys to ignore",
+					Elem: &schema.Schema{
+						Type: schema.TypeString,
+					},
+				},
+				"key_prefixes": {
+					Type:        schema.TypeList,
+					Optional:    true,
+					Description: "List of tag key prefixes to ignore",
+					Elem: &schema.Schema{
+						Type: schema.TypeString,
+					},
+				},
+			},
+		},
+	}
+}
+
+// ignoreTagsConfig contains the tag keys and key prefixes ignored by the provider
+type ignoreTagsConfig struct {
+	keys        []string
+	keyPrefixes []string
+}
+
+// expandProviderIgnoreTags returns the configuration of the provider ignore_tags block
+func expandProviderIgnoreTags(d *schema.ResourceData) *ignoreTagsConfig {
+	if d == nil {
+		return nil
+	}
+	if _, ok := d.GetOk("ignore_tags.0"); !ok {
+		return nil
+	}
+
+	return &ignoreTagsConfig{
+		keys:        expandStringsOrEmpty(d.Get("ignore_tags.0.keys")),
+		keyPrefixes: expandStringsOrEmpty(d.Get("ignore_tags.0.key_prefixes")),
+	}
+}
+
+// isIgnoredKey returns true if the given tag key is ignored
+func (c *ignoreTagsConfig) isIgnoredKey(key string) bool {
+	if c == nil {
+		return false
+	}
+	if sliceContainsString(c.keys, key) {
+		return true
+	}
+	for _, prefix := range c.keyPrefixes {
+		if strings.HasPrefix(key, prefix) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// isIgnored returns true if the given list-style tag is ignored.
+// The key of a tag is the part before the first "=" or ":" as in "key=value" or "key:value".
+func (c *ignoreTagsConfig) isIgnored(tag string) bool {
+	if c == nil {
+		return false
+	}
+	key := tag
+	if index := strings.IndexAny(tag, "=:"); index != -1 {
+		key = tag[:index]
+	}
+
+	return c.isIgnoredKey(tag) || c.isIgnoredKey(key)
+}
+
+// removeIgnoredTags removes from tags the ignored tags that were not explicitly set in configTags
+func removeIgnoredTags(tags []string, configTags []string, ignoreTags *ignoreTagsConfig) []string {
+	filtered := []string{}
+	for _, tag := range tags {
+		if ignoreTags.isIgnored(tag) && !sliceContainsString(configTags, tag) {
+			continue
+		}
+		filtered = append(filtered, tag)
+	}
+
+	return filtered
+}
+
+// mergeIgnoredTags returns the given tags with the ignored tags of priorTags that are not already present.
+// Ignored tags keep their position in priorTags, so tags added by the API between configured tags do not show a diff.
+func mergeIgnoredTags(tags []string, priorTags []string, ignoreTags *ignoreTagsConfig) []string {
+	merged := append([]string{}, tags...)
+	for index, tag := range priorTags {
+		if !ignoreTags.isIgnored(tag) || sliceContainsString(merged, tag) {
+			continue
+		}
+		if index > len(merged) {
+			index = len(merged)
+		}
+		merged = append(merged[:index], append([]string{tag}, merged[index:]...)...)
+	}
+
+	return merged
+}
+
+// removeIgnoredTagsMap removes from tags the ignored tags that were not explicitly set in configTags
+func removeIgnoredTagsMap(tags map[string]string, configTags map[string]string, ignoreTags *ignoreTagsConfig) map[string]string {
+	filtered := map[string]string{}
+	for key, value := range tags {
+		if _, isConfigured := configTags[key]; ignoreTags.isIgnoredKey(key) && !isConfigured {
+			continue
+		}
+		filtered[key] = value
+	}
+
+	return filtered
+}
+
+// mergeIgnoredTagsMap returns the given tags with the ignored tags of priorTags whose key is not already set
+func mergeIgnoredTagsMap(tags map[string]string, priorTags map[string]string, ignoreTags *ignoreTagsConfig) map[string]string {
+	merged := map[string]string{}
+	for key, value := range priorTags {
+		if ignoreTags.isIgnoredKey(key) {
+			merged[key] = value
+		}
+	}
+	for key, value := range tags {
+		merged[key] = value
+	}
+
+	return merged
+}
+
+// mergeDefaultTags returns the given tags followed by the default tags that are not already present
+func mergeDefaultTags(tags []string, defaultTags []string) []string {
+	merged := append([]string{}, tags...)
+	for _, tag := range defaultTags {
+		if !sliceContainsString(merged, tag) {
+			merged = append(merged, tag)
+		}
+	}
+
+	return merged
+}
+
+// removeDefaultTags removes from tags the default tags that were not explicitly set in configTags
+func removeDefaultTags(tags []string, configTags []string, defaultTags []string) []string {
+	filtered := []string{}
+	for _, tag := range tags {
+		if sliceContainsString(defaultTags, tag) && !sliceContainsString(configTags, tag) {
+			continue
+		}
+		filtered = append(filtered, tag)
+	}
+
+	return filtered
+}
+
+// expandDefaultTagsMap converts the key=value default tags to a map, other tags are ignored
+func expandDefaultTagsMap(defaultTags []string) map[string]string {
+	tags := map[string]string{}
+	for _, tag := range defaultTags {
+		key, value, found := strings.Cut(tag, "=")
+		if !found || key == "" {
+			continue
+		}
+		tags[key] = value
+	}
+
+	return tags
+}
+
+// mergeDefaultTagsMap returns the given tags with the default tags whose key is not already set
+func mergeDefaultTagsMap(tags map[string]string, defaultTags []string) map[string]string {
+	merged := map[string]string{}
+	for key, value := range expandDefaultTagsMap(defaultTags) {
+		merged[key] = value
+	}
+	for key, value := range tags {
+		merged[key] = value
+	}
+
+	return merged
+}
+
+// removeDefaultTagsMap removes from tags the default tags that were not explicitly set in configTags
+func removeDefaultTagsMap(tags map[string]string, configTags map[string]string, defaultTags []string) map[string]string {
+	defaultTagsMap := expandDefaultTagsMap(defaultTags)
+	filtered := map[string]string{}
+	for key, value := range tags {
+		if defaultValue, isDefault := defaultTagsMap[key]; isDefault && defaultValue == value {
+			if _, isConfigured := configTags[key]; !isConfigured {
+				continue
+			}
+		}
+		filtered[key] = value
+	}
+
+	return filtered
+}
+
+// resourceTags handles the tags_all attribute of a resource with list or map-style tags
+type resourceTags struct {
+	isMap bool
+}
+
+// expandList converts a terraform list of tags, nil elements are converted to empty strings
+func (r resourceTags) expandList(tags interface{}) []string {
+	list, _ := tags.([]interface{})
+	stringList := make([]string, 0, len(list))
+	for _, tag := range list {
+		tagString, _ := tag.(string)
+		stringList = append(stringList, tagString)
+	}
+
+	return stringList
+}
+
+// expandMap converts a terraform map of tags
+func (r resourceTags) expandMap(tags interface{}) map[string]string {
+	rawMap, _ := tags.(map[string]interface{})
+	stringMap := make(map[string]string, len(rawMap))
+	for key, value := range rawMap {
+		valueString, _ := value.(string)
+		stringMap[key] = valueString
+	}
+
+	return stringMap
+}
+
+// merge returns the tags with the provider default tags and the ignored tags found in priorTagsAll
+func (r resourceTags) merge(tags interface{}, priorTagsAll interface{}, meta *Meta) interface{} {
+	if r.isMap {
+		merged := mergeDefaultTagsMap(r.expandMap(tags), meta.defaultTags)
+		return flattenMap(mergeIgnoredTagsMap(merged, r.expandMap(priorTagsAll), meta.ignoreTags))
+	}
+
+	merged := mergeDefaultTags(r.expandList(tags), meta.defaultTags)
+	return flattenSliceString(mergeIgnoredTags(merged, r.expandList(priorTagsAll), meta.ignoreTags))
+}
+
+// remove returns the tags without the provider default tags and ignored tags that are not part of configTags
+func (r resourceTags) remove(tags interface{}, configTags interface{}, meta *Meta) interface{} {
+	if r.isMap {
+		filtered := removeDefaultTagsMap(r.expandMap(tags), r.expandMap(configTags), meta.defaultTags)
+		return flattenMap(removeIgnoredTagsMap(filtered, r.expandMap(configTags), meta.ignoreTags))
+	}
+
+	filtered := removeDefaultTags(r.expandList(tags), r.expandList(configTags), meta.defaultTags)
+	return flattenSliceString(removeIgnoredTags(filtered, r.expandList(configTags), meta.ignoreTags))
+}
+
+// equal compares two tags values
+func (r resourceTags) equal(tags1 interface{}, tags2 interface{}) bool {
+	if r.isMap {
+		return reflect.DeepEqual(r.expandMap(tags1), r.expandMap(tags2))
+	}
+
+	return reflect.DeepEqual(r.expandList(tags1), r.expandList(tags2))
+}
+
+// customizeDiff computes tags_all from the planned tags
+func (r resourceTags) customizeDiff(_ context.Context, diff *schema.ResourceDiff, m interface{}) error {
+	if !diff.NewValueKnown("tags") {
+		return diff.SetNewComputed("tags_all")
+	}
+
+	priorTagsAll, _ := diff.GetChange("tags_all")
+	tagsAll := r.merge(diff.Get("tags"), priorTagsAll, m.(*Meta))
+	if r.equal(tagsAll, diff.Get("tags_all")) {
+		return nil
+	}
+
+	return diff.SetNew("tags_all", tagsAll)
+}
+
+// setState sets tags_all to the tags read from the API and removes the inherited default tags and ignored tags from tags
+func (r resourceTags) setState(d *schema.ResourceData, configTags interface{}, meta *Meta) {
+	if d.Id() == "" {
+		return
+	}
+
+	tags := d.Get("tags")
+	_ = d.Set("tags_all", tags)
+	_ = d.Set("tags", r.remove(tags, configTags, meta))
+}
+
+// wrapCreate sends the merged tags to the API on creation
+func (r resourceTags) wrapCreate(create schema.CreateContextFunc) schema.CreateContextFunc {
+	return func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+		meta := m.(*Meta)
+		configTags := d.Get("tags")
+		priorTagsAll, _ := d.GetChange("tags_all")
+		_ = d.Set("tags", r.merge(configTags, priorTagsAll, meta))
+
+		diags := create(ctx, d, m)
+		r.setState(d, configTags, meta)
+
+		return diags
+	}
+}
+
+// wrapUpdate sends the merged tags to the API on update, ignored tags already set on the resource are kept
+func (r resourceTags) wrapUpdate(update schema.UpdateContextFunc) schema.UpdateContextFunc {
+	return func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+		meta := m.(*Meta)
+		configTags := d.Get("tags")
+		priorTagsAll, _ := d.GetChange("tags_all")
+		_ = d.Set("tags", r.merge(configTags, priorTagsAll, meta))
+
+		diags := update(ctx, d, m)
+		r.setState(d, configTags, meta)
+
+		return diags
+	}
+}
+
+// wrapRead keeps the default tags and ignored tags out of the tags attribute
+func (r resourceTags) wrapRead(read schema.ReadContextFunc) schema.ReadContextFunc {
+	return func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+		priorTags := d.Get("tags")
+
+		diags := read(ctx, d, m)
+		r.setState(d, priorTags, m.(*Meta))
+
+		return diags
+	}
+}
+
+// addResourceTagsAll adds the tags_all attribute to a resource and wraps its CRUD functions
+// so the provider default tags are merged into the resource tags.
+func addResourceTagsAll(resource *schema.Resource) {
+	tagsSchema, exist := resource.Schema["tags"]
+	if !exist || !tagsSchema.Optional || resource.Schema["tags_all"] != nil {
+		return
+	}
+	if resource.CreateContext == nil || resource.ReadContext == nil || resource.UpdateContext == nil {
+		return
+	}
+
+	var tags resourceTags
+	switch tagsSchema.Type {
+	case schema.TypeMap:
+		tags.isMap = true
+	case schema.TypeList:
+		elem, isSchema := tagsSchema.Elem.(*schema.Schema)
+		if !isSchema || elem.Type != schema.TypeString {
+			return
+		}
+	default:
+		return
+	}
+
+	resource.Schema["tags_all"] = &schema.Schema{
+		Type:        tagsSchema.Type,
+		Computed:    true,
+		Description: "All the tags of the resource, including the ones inherited from the provider default_tags and the ignored ones",
+		Elem: &schema.Schema{
+			Type: schema.TypeString,
+		},
+	}
+
+	if resource.CustomizeDiff != nil {
+		customizeDiff := resource.CustomizeDiff
+		resource.CustomizeDiff = func(ctx context.Context, diff *schema.ResourceDiff, m interface{}) error {
+			if err := customizeDiff(ctx, diff, m); err != nil {
+				return err
+			}
+			return tags.customizeDiff(ctx, diff, m)
+		}
+	} else {
+		resource.CustomizeDiff = tags.customizeDiff
+	}
+
+	resource.CreateContext = tags.wrapCreate(resource.CreateContext)
+	resource.ReadContext = tags.wrapRead(resource.ReadContext)
+	resource.UpdateContext = tags.wrapUpdate(resource.UpdateContext)
+}
+
+// addDataSourceIgnoreTags wraps the read function of a data source so the ignored tags are removed from its tags
+func addDataSourceIgnoreTags(dataSource *schema.Resource) {
+	tagsSchema, exist := dataSource.Schema["tags"]
+	// Tags that are only used as a filter are left untouched
+	if !exist || !tagsSchema.Computed || dataSource.ReadContext == nil {
+		return
+	}
+
+	tags := resourceTags{isMap: tagsSchema.Type == schema.TypeMap}
+	if !tags.isMap {
+		elem, isSchema := tagsSchema.Elem.(*schema.Schema)
+		if tagsSchema.Type != schema.TypeList || !isSchema || elem.Type != schema.TypeString {
+			return
+		}
+	}
+
+	read := dataSource.ReadContext
+	dataSource.ReadContext = func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+		diags := read(ctx, d, m)
+		meta := m.(*Meta)
+		if d.Id() == "" || meta.ignoreTags == nil {
+			return diags
+		}
+
+		if tags.isMap {
+			_ = d.Set("tags", flattenMap(removeIgnoredTagsMap(tags.expandMap(d.Get("tags")), nil, meta.ignoreTags)))
+		} else {
+			_ = d.Set("tags", flattenSliceString(removeIgnoredTags(tags.expandList(d.Get("tags")), nil, meta.ignoreTags)))
+		}
+
+		return diags
+	}
+}
+
+// addProviderTags adds provider level tags support to all resources and data sources of the provider
+func addProviderTags(provider *schema.Provider) {
+	for _, resource := range provider.ResourcesMap {
+		addResourceTagsAll(resource)
+	}
+	for _, dataSource := range provider.DataSourcesMap {
+		addDataSourceIgnoreTags(dataSource)
+	}
+}
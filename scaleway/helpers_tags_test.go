@@ -0,0 +1,224 @@
+package scaleway
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMergeDefaultTags(t *testing.T) {
+	testCases := []struct {
+		name        string
+		tags        []string
+		defaultTags []string
+		expected    []string
+	}{
+		{
+			name:        "no default tags",
+			tags:        []string{"foo", "bar"},
+			defaultTags: nil,
+			expected:    []string{"foo", "bar"},
+		},
+		{
+			name:        "no tags",
+			tags:        nil,
+			defaultTags: []string{"team=infra"},
+			expected:    []string{"team=infra"},
+		},
+		{
+			name:        "default tags appended",
+			tags:        []string{"foo"},
+			defaultTags: []string{"team=infra", "env=prod"},
+			expected:    []string{"foo", "team=infra", "env=prod"},
+		},
+		{
+			name:        "duplicated tag",
+			tags:        []string{"env=prod", "foo"},
+			defaultTags: []string{"team=infra", "env=prod"},
+			expected:    []string{"env=prod", "foo", "team=infra"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, mergeDefaultTags(tc.tags, tc.defaultTags))
+		})
+	}
+}
+
+func TestRemoveDefaultTags(t *testing.T) {
+	testCases := []struct {
+		name        string
+		tags        []string
+		configTags  []string
+		defaultTags []string
+		expected    []string
+	}{
+		{
+			name:        "default tags removed",
+			tags:        []string{"foo", "team=infra", "env=prod"},
+			configTags:  []string{"foo"},
+			defaultTags: []string{"team=infra", "env=prod"},
+			expected:    []string{"foo"},
+		},
+		{
+			name:        "configured default tag kept",
+			tags:        []string{"env=prod", "foo", "team=infra"},
+			configTags:  []string{"env=prod", "foo"},
+			defaultTags: []string{"team=infra", "env=prod"},
+			expected:    []string{"env=prod", "foo"},
+		},
+		{
+			name:        "external tag kept",
+			tags:        []string{"foo", "external", "team=infra"},
+			configTags:  []string{"foo"},
+			defaultTags: []string{"team=infra"},
+			expected:    []string{"foo", "external"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, removeDefaultTags(tc.tags, tc.configTags, tc.defaultTags))
+		})
+	}
+}
+
+func TestMergeDefaultTagsMap(t *testing.T) {
+	defaultTags := []string{"team=infra", "env=prod", "not-a-key-value"}
+
+	merged := mergeDefaultTagsMap(map[string]string{"env": "dev", "foo": "bar"}, defaultTags)
+	assert.Equal(t, map[string]string{"team": "infra", "env": "dev", "foo": "bar"}, merged)
+
+	removed := removeDefaultTagsMap(merged, map[string]string{"env": "dev", "foo": "bar"}, defaultTags)
+	assert.Equal(t, map[string]string{"env": "dev", "foo": "bar"}, removed)
+
+	removed = removeDefaultTagsMap(map[string]string{"team": "infra", "env": "prod"}, map[string]string{"env": "prod"}, defaultTags)
+	assert.Equal(t, map[string]string{"env": "prod"}, removed)
+}
+
+func TestProviderTagsAll(t *testing.T) {
+	provider := Provider(DefaultProviderConfig())()
+	require.NoError(t, provider.InternalValidate())
+
+	for _, resourceName := range []string{"scaleway_instance_server", "scaleway_k8s_cluster", "scaleway_rdb_instance", "scaleway_object_bucket"} {
+		resource := provider.ResourcesMap[resourceName]
+		require.Contains(t, resource.Schema, "tags_all", resourceName)
+		assert.Equal(t, resource.Schema["tags"].Type, resource.Schema["tags_all"].Type, resourceName)
+		assert.True(t, resource.Schema["tags_all"].Computed, resourceName)
+	}
+}
+
+func TestResourceTagsAllWrapper(t *testing.T) {
+	var apiTags []string
+	testResource := &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"tags": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+		},
+		ReadContext: func(_ context.Context, d *schema.ResourceData, _ interface{}) diag.Diagnostics {
+			_ = d.Set("tags", apiTags)
+			return nil
+		},
+		UpdateContext: func(_ context.Context, _ *schema.ResourceData, _ interface{}) diag.Diagnostics {
+			return nil
+		},
+		DeleteContext: func(_ context.Context, _ *schema.ResourceData, _ interface{}) diag.Diagnostics {
+			return nil
+		},
+	}
+	testResource.CreateContext = func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+		apiTags = expandStrings(d.Get("tags"))
+		d.SetId("id")
+		return testResource.ReadContext(ctx, d, m)
+	}
+	addResourceTagsAll(testResource)
+	require.Contains(t, testResource.Schema, "tags_all")
+
+	meta := &Meta{defaultTags: []string{"team=infra"}}
+	d := schema.TestResourceDataRaw(t, testResource.Schema, map[string]interface{}{
+		"tags": []interface{}{"foo"},
+	})
+
+	diags := testResource.CreateContext(context.Background(), d, meta)
+	require.False(t, diags.HasError())
+	assert.Equal(t, []string{"foo", "team=infra"}, apiTags)
+	assert.Equal(t, []interface{}{"foo"}, d.Get("tags"))
+	assert.Equal(t, []interface{}{"foo", "team=infra"}, d.Get("tags_all"))
+
+	diags = testResource.ReadContext(context.Background(), d, meta)
+	require.False(t, diags.HasError())
+	assert.Equal(t, []interface{}{"foo"}, d.Get("tags"))
+	assert.Equal(t, []interface{}{"foo", "team=infra"}, d.Get("tags_all"))
+
+	// Tags added by external tooling are ignored
+	meta.ignoreTags = &ignoreTagsConfig{keyPrefixes: []string{"scanner"}}
+	apiTags = append(apiTags, "scanner=ok")
+
+	diags = testResource.ReadContext(context.Background(), d, meta)
+	require.False(t, diags.HasError())
+	assert.Equal(t, []interface{}{"foo"}, d.Get("tags"))
+	assert.Equal(t, []interface{}{"foo", "team=infra", "scanner=ok"}, d.Get("tags_all"))
+}
+
+func TestIgnoreTagsConfig(t *testing.T) {
+	ignoreTags := &ignoreTagsConfig{
+		keys:        []string{"scanned", "owner"},
+		keyPrefixes: []string{"kapsule"},
+	}
+
+	testCases := []struct {
+		tag      string
+		expected bool
+	}{
+		{tag: "scanned", expected: true},
+		{tag: "owner=security", expected: true},
+		{tag: "owner:security", expected: true},
+		{tag: "owners", expected: false},
+		{tag: "kapsule-node", expected: true},
+		{tag: "kapsule=cluster", expected: true},
+		{tag: "team=infra", expected: false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.tag, func(t *testing.T) {
+			assert.Equal(t, tc.expected, ignoreTags.isIgnored(tc.tag))
+		})
+	}
+
+	var nilIgnoreTags *ignoreTagsConfig
+	assert.False(t, nilIgnoreTags.isIgnored("scanned"))
+}
+
+func TestIgnoredTags(t *testing.T) {
+	ignoreTags := &ignoreTagsConfig{
+		keys:        []string{"scanned"},
+		keyPrefixes: []string{"kapsule"},
+	}
+
+	tags := []string{"foo", "scanned=true", "kapsule-node", "bar"}
+	assert.Equal(t, []string{"foo", "bar"}, removeIgnoredTags(tags, nil, ignoreTags))
+	assert.Equal(t, []string{"foo", "scanned=true", "bar"}, removeIgnoredTags(tags, []string{"scanned=true"}, ignoreTags))
+	assert.Equal(t, []string{"foo", "scanned=true", "kapsule-node", "baz"}, mergeIgnoredTags([]string{"foo", "baz"}, tags, ignoreTags))
+
+	// An ignored tag returned by the API between configured tags keeps its position
+	meta := &Meta{ignoreTags: ignoreTags}
+	r := resourceTags{}
+	priorTagsAll := []interface{}{"foo", "scanned=true", "bar"}
+	tagsAll := r.merge([]interface{}{"foo", "bar"}, priorTagsAll, meta)
+	assert.True(t, r.equal(priorTagsAll, tagsAll), tagsAll)
+	assert.Equal(t, []interface{}{"foo", "bar"}, r.remove(priorTagsAll, []interface{}{"foo", "bar"}, meta))
+
+	tagsMap := map[string]string{"foo": "bar", "scanned": "true", "kapsule-node": "1"}
+	assert.Equal(t, map[string]string{"foo": "bar"}, removeIgnoredTagsMap(tagsMap, nil, ignoreTags))
+	assert.Equal(t, map[string]string{"foo": "baz", "scanned": "true", "kapsule-node": "1"}, mergeIgnoredTagsMap(map[string]string{"foo": "baz"}, tagsMap, ignoreTags))
+}
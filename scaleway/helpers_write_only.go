@@ -0,0 +1,52 @@
+package scaleway
+
+import (
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// getWriteOnlyString returns the value of a write-only string attribute.
+// Write-only attributes are never stored in the plan nor the state, they are only available in the raw config.
+func getWriteOnlyString(d *schema.ResourceData, key string) (string, diag.Diagnostics) {
+	value, diags := d.GetRawConfigAt(cty.GetAttrPath(key))
+	if diags.HasError() {
+		return "", diags
+	}
+
+	if value.IsNull() || !value.IsKnown() || !value.Type().Equals(cty.String) {
+		return "", nil
+	}
+
+	return value.AsString(), nil
+}
+
+// expandPassword returns the password of a resource, set either with the password attribute or with its password_wo write-only variant
+func expandPassword(d *schema.ResourceData) (string, diag.Diagnostics) {
+	if password, ok := d.GetOk("password"); ok {
+		return password.(string), nil
+	}
+
+	return getWriteOnlyString(d, "password_wo")
+}
+
+// hasPasswordChange returns true if the password or the version of its write-only variant changed
+func hasPasswordChange(d *schema.ResourceData) bool {
+	return d.HasChanges("password", "password_wo_version")
+}
+
+// expandUpdatedPassword returns the password to send when updating a resource, nil if it must not be updated.
+// Removing password from the config or bumping password_wo_version without setting password_wo gives no new password,
+// the current one is kept rather than replaced by an empty one.
+func expandUpdatedPassword(d *schema.ResourceData) (*string, diag.Diagnostics) {
+	if !hasPasswordChange(d) {
+		return nil, nil
+	}
+
+	password, diags := expandPassword(d)
+	if diags.HasError() || password == "" {
+		return nil, diags
+	}
+
+	return &password, nil
+}
@@ -0,0 +1,175 @@
+package scaleway
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/scaleway/scaleway-sdk-go/api/rdb/v1"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteOnlyPassword_RdbUser(t *testing.T) {
+	tt := NewTestToolsWithFakeAPI(t)
+	defer tt.Cleanup()
+	instanceID := testWriteOnlyCreateRdbInstance(t, tt)
+
+	userConfig := func(password string, version int) map[string]tftypes.Value {
+		return map[string]tftypes.Value{
+			"instance_id":         tftypes.NewValue(tftypes.String, newRegionalIDString(scw.RegionFrPar, instanceID)),
+			"name":                tftypes.NewValue(tftypes.String, "foo"),
+			"password_wo":         tftypes.NewValue(tftypes.String, password),
+			"password_wo_version": tftypes.NewValue(tftypes.Number, version),
+		}
+	}
+
+	// The user is created with the write-only password
+	state := applyResourceConfig(t, tt, "scaleway_rdb_user", tftypes.Value{}, userConfig("Wr1te-0nly#1", 1))
+	testWriteOnlyCheckRdbPassword(t, tt, instanceID, "foo", "Wr1te-0nly#1")
+	testWriteOnlyCheckNotInState(t, state, "Wr1te-0nly#1")
+
+	// Bumping the version rotates the password
+	state = applyResourceConfig(t, tt, "scaleway_rdb_user", state, userConfig("Wr1te-0nly#2", 2))
+	testWriteOnlyCheckRdbPassword(t, tt, instanceID, "foo", "Wr1te-0nly#2")
+	testWriteOnlyCheckNotInState(t, state, "Wr1te-0nly#2")
+
+	// Changing the write-only password without bumping the version does not update it
+	state = applyResourceConfig(t, tt, "scaleway_rdb_user", state, userConfig("Wr1te-0nly#3", 2))
+	testWriteOnlyCheckRdbPassword(t, tt, instanceID, "foo", "Wr1te-0nly#2")
+	testWriteOnlyCheckNotInState(t, state, "Wr1te-0nly#3")
+}
+
+func TestWriteOnlyPassword_RdbUserFromPassword(t *testing.T) {
+	tt := NewTestToolsWithFakeAPI(t)
+	defer tt.Cleanup()
+	instanceID := testWriteOnlyCreateRdbInstance(t, tt)
+
+	// The user is created with a password stored in the state
+	state := applyResourceConfig(t, tt, "scaleway_rdb_user", tftypes.Value{}, map[string]tftypes.Value{
+		"instance_id": tftypes.NewValue(tftypes.String, newRegionalIDString(scw.RegionFrPar, instanceID)),
+		"name":        tftypes.NewValue(tftypes.String, "foo"),
+		"password":    tftypes.NewValue(tftypes.String, "Cl34r-P4ss#"),
+		"is_admin":    tftypes.NewValue(tftypes.Bool, true),
+	})
+	testWriteOnlyCheckRdbPassword(t, tt, instanceID, "foo", "Cl34r-P4ss#")
+
+	// Switching to the write-only password sets it rather than an empty one, and removes the password from the state
+	state = applyResourceConfig(t, tt, "scaleway_rdb_user", state, map[string]tftypes.Value{
+		"instance_id":         tftypes.NewValue(tftypes.String, newRegionalIDString(scw.RegionFrPar, instanceID)),
+		"name":                tftypes.NewValue(tftypes.String, "foo"),
+		"password_wo":         tftypes.NewValue(tftypes.String, "Wr1te-0nly#1"),
+		"password_wo_version": tftypes.NewValue(tftypes.Number, 1),
+		"is_admin":            tftypes.NewValue(tftypes.Bool, false),
+	})
+	testWriteOnlyCheckRdbPassword(t, tt, instanceID, "foo", "Wr1te-0nly#1")
+	testWriteOnlyCheckNotInState(t, state, "Wr1te-0nly#1")
+	testWriteOnlyCheckNotInState(t, state, "Cl34r-P4ss#")
+}
+
+func TestWriteOnlyPassword_RdbInstance(t *testing.T) {
+	tt := NewTestToolsWithFakeAPI(t)
+	defer tt.Cleanup()
+
+	instanceConfig := func(passwords map[string]tftypes.Value) map[string]tftypes.Value {
+		config := map[string]tftypes.Value{
+			"name":      tftypes.NewValue(tftypes.String, "test-rdb-write-only"),
+			"node_type": tftypes.NewValue(tftypes.String, "db-dev-s"),
+			"engine":    tftypes.NewValue(tftypes.String, "PostgreSQL-15"),
+			"user_name": tftypes.NewValue(tftypes.String, "admin"),
+		}
+		for key, value := range passwords {
+			config[key] = value
+		}
+
+		return config
+	}
+
+	state := applyResourceConfig(t, tt, "scaleway_rdb_instance", tftypes.Value{}, instanceConfig(map[string]tftypes.Value{
+		"password": tftypes.NewValue(tftypes.String, "Cl34r-P4ss#"),
+	}))
+	instanceID := expandRegionalID(testWriteOnlyStringAttribute(t, state, "id")).ID
+	testWriteOnlyCheckRdbPassword(t, tt, instanceID, "admin", "Cl34r-P4ss#")
+
+	// Removing the password from the config keeps the current one rather than setting an empty one
+	state = applyResourceConfig(t, tt, "scaleway_rdb_instance", state, instanceConfig(nil))
+	testWriteOnlyCheckRdbPassword(t, tt, instanceID, "admin", "Cl34r-P4ss#")
+
+	// Switching to the write-only password sets it
+	state = applyResourceConfig(t, tt, "scaleway_rdb_instance", state, instanceConfig(map[string]tftypes.Value{
+		"password_wo":         tftypes.NewValue(tftypes.String, "Wr1te-0nly#1"),
+		"password_wo_version": tftypes.NewValue(tftypes.Number, 1),
+	}))
+	testWriteOnlyCheckRdbPassword(t, tt, instanceID, "admin", "Wr1te-0nly#1")
+	testWriteOnlyCheckNotInState(t, state, "Wr1te-0nly#1")
+
+	// Bumping the version rotates the password
+	state = applyResourceConfig(t, tt, "scaleway_rdb_instance", state, instanceConfig(map[string]tftypes.Value{
+		"password_wo":         tftypes.NewValue(tftypes.String, "Wr1te-0nly#2"),
+		"password_wo_version": tftypes.NewValue(tftypes.Number, 2),
+	}))
+	testWriteOnlyCheckRdbPassword(t, tt, instanceID, "admin", "Wr1te-0nly#2")
+	testWriteOnlyCheckNotInState(t, state, "Wr1te-0nly#2")
+}
+
+// testWriteOnlyCreateRdbInstance creates a ready database instance and returns its ID
+func testWriteOnlyCreateRdbInstance(t *testing.T, tt *TestTools) string {
+	t.Helper()
+
+	api := rdb.NewAPI(tt.Meta.scwClient)
+	instance, err := api.CreateInstance(&rdb.CreateInstanceRequest{
+		Region:   scw.RegionFrPar,
+		Name:     "test-rdb-write-only",
+		Engine:   "PostgreSQL-15",
+		NodeType: "DB-DEV-S",
+		UserName: "admin",
+		Password: "Adm1n-P4ss#",
+	})
+	require.NoError(t, err)
+
+	retryInterval := time.Duration(0)
+	_, err = api.WaitForInstance(&rdb.WaitForInstanceRequest{
+		Region:        scw.RegionFrPar,
+		InstanceID:    instance.ID,
+		RetryInterval: &retryInterval,
+	})
+	require.NoError(t, err)
+
+	return instance.ID
+}
+
+// testWriteOnlyCheckRdbPassword checks the password the fake API holds for a user of a database instance
+func testWriteOnlyCheckRdbPassword(t *testing.T, tt *TestTools, instanceID string, userName string, expected string) {
+	t.Helper()
+
+	password, exists := tt.FakeAPI.Password(instanceID, userName)
+	require.True(t, exists)
+	assert.Equal(t, expected, password)
+}
+
+// testWriteOnlyCheckNotInState checks that no attribute of a state holds the given value
+func testWriteOnlyCheckNotInState(t *testing.T, state tftypes.Value, value string) {
+	t.Helper()
+
+	err := tftypes.Walk(state, func(path *tftypes.AttributePath, v tftypes.Value) (bool, error) {
+		if v.Type().Is(tftypes.String) && v.IsKnown() && !v.IsNull() {
+			var s string
+			require.NoError(t, v.As(&s))
+			assert.NotEqual(t, value, s, "%s is stored in the state", path)
+		}
+
+		return true, nil
+	})
+	require.NoError(t, err)
+}
+
+// testWriteOnlyStringAttribute returns a string attribute of a state
+func testWriteOnlyStringAttribute(t *testing.T, state tftypes.Value, name string) string {
+	t.Helper()
+
+	attributes := map[string]tftypes.Value{}
+	require.NoError(t, state.As(&attributes))
+
+	return stringAttribute(t, attributes, name)
+}
@@ -1,46 +1,122 @@
 package scaleway
 
 import (
-	"log"
+	"context"
+	"fmt"
 
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-log/tfsdklog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/logging"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	sdkLogger "github.com/scaleway/scaleway-sdk-go/logger"
 )
 
 // logger is the implementation of the SDK Logger interface for this terraform plugin.
+// The SDK logger is global to the process and its methods take no context, so it cannot use the context of an operation.
+// Its logs are written with tflog to a provider root logger created like the one of each RPC, without the fields of a resource:
+// the requests of an operation are logged with the fields of its context by loggingTransport.
 //
 // cf. https://godoc.org/github.com/scaleway/scaleway-sdk-go/logger#Logger
-type logger struct{}
+type logger struct {
+	// ctx only holds the tflog provider root logger, it is never cancelled
+	ctx context.Context //nolint:containedctx
+}
 
 // l is the global logger singleton
-var l = logger{}
+var l = &logger{
+	ctx: tflog.SetField(tfsdklog.NewRootProviderLogger(context.Background(),
+		tfsdklog.WithLogName("scaleway"),
+		tfsdklog.WithLevelFromEnv("TF_LOG_PROVIDER", "scaleway"),
+		tfsdklog.WithStderrFromInit(),
+		// The location of the logs is the call site in the SDK, behind the functions of its logger package
+		tfsdklog.WithAdditionalLocationOffset(3),
+	), logFieldLogger, "scaleway-sdk-go"),
+}
+
+func (l *logger) log(level string, format string, args ...interface{}) {
+	message := fmt.Sprintf(format, args...)
+	switch level {
+	case "ERROR":
+		tflog.Error(l.ctx, message)
+	case "WARN":
+		tflog.Warn(l.ctx, message)
+	case "INFO":
+		tflog.Info(l.ctx, message)
+	default:
+		tflog.Debug(l.ctx, message)
+	}
+}
 
 // Debugf logs to the DEBUG log. Arguments are handled in the manner of fmt.Printf.
-func (l logger) Debugf(format string, args ...interface{}) {
-	log.Printf("[DEBUG] "+format, args...)
+func (l *logger) Debugf(format string, args ...interface{}) {
+	l.log("DEBUG", format, args...)
 }
 
 // Infof logs to the INFO log. Arguments are handled in the manner of fmt.Printf.
-func (l logger) Infof(format string, args ...interface{}) {
-	log.Printf("[INFO] "+format, args...)
+func (l *logger) Infof(format string, args ...interface{}) {
+	l.log("INFO", format, args...)
 }
 
 // Warningf logs to the WARNING log. Arguments are handled in the manner of fmt.Printf.
-func (l logger) Warningf(format string, args ...interface{}) {
-	log.Printf("[WARN] "+format, args...)
+func (l *logger) Warningf(format string, args ...interface{}) {
+	l.log("WARN", format, args...)
 }
 
 // Errorf logs to the ERROR log. Arguments are handled in the manner of fmt.Printf.
-func (l logger) Errorf(format string, args ...interface{}) {
-	log.Printf("[ERROR] "+format, args...)
+func (l *logger) Errorf(format string, args ...interface{}) {
+	l.log("ERROR", format, args...)
 }
 
 // Printf logs to the DEBUG log. Arguments are handled in the manner of fmt.Printf.
-func (l logger) Printf(format string, args ...interface{}) {
-	l.Debugf(format, args...)
+func (l *logger) Printf(format string, args ...interface{}) {
+	l.log("DEBUG", format, args...)
 }
 
 // ShouldLog allow the SDK to log only in DEBUG or TRACE levels.
-func (l logger) ShouldLog(_ sdkLogger.LogLevel) bool {
+func (l *logger) ShouldLog(_ sdkLogger.LogLevel) bool {
 	return logging.IsDebugOrHigher()
 }
+
+// Log fields added to the logs of resources and data sources
+const (
+	logFieldResourceType = "scw_resource_type"
+	logFieldResourceID   = "scw_resource_id"
+	logFieldLogger       = "scw_logger"
+)
+
+// withLogFields adds the resource type and the resource ID to the log fields of the context given to a CRUD function
+func withLogFields(resourceType string, f func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics) func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics {
+	return func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+		ctx = tflog.SetField(ctx, logFieldResourceType, resourceType)
+		if d.Id() != "" {
+			ctx = tflog.SetField(ctx, logFieldResourceID, d.Id())
+		}
+
+		return f(ctx, d, m)
+	}
+}
+
+// addLogFields adds the resource type and ID to the logs of every resource and data source of the provider.
+// Terraform does not send the resource address to providers, the type and ID are used to identify the resource instead.
+func addLogFields(provider *schema.Provider) {
+	for resourceType, resource := range provider.ResourcesMap {
+		if resource.CreateContext != nil {
+			resource.CreateContext = withLogFields(resourceType, resource.CreateContext)
+		}
+		if resource.ReadContext != nil {
+			resource.ReadContext = withLogFields(resourceType, resource.ReadContext)
+		}
+		if resource.UpdateContext != nil {
+			resource.UpdateContext = withLogFields(resourceType, resource.UpdateContext)
+		}
+		if resource.DeleteContext != nil {
+			resource.DeleteContext = withLogFields(resourceType, resource.DeleteContext)
+		}
+	}
+	for dataSourceType, dataSource := range provider.DataSourcesMap {
+		if dataSource.ReadContext != nil {
+			dataSource.ReadContext = withLogFields(dataSourceType, dataSource.ReadContext)
+		}
+	}
+}
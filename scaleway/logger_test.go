@@ -0,0 +1,28 @@
+package scaleway
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-log/tflogtest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLogger(t *testing.T) {
+	var output bytes.Buffer
+	scwLogger := &logger{ctx: tflog.SetField(tflogtest.RootLogger(context.Background(), &output), logFieldLogger, "scaleway-sdk-go")}
+
+	scwLogger.Warningf("%s is deprecated", "SCW_TLS_VERIFY")
+	scwLogger.Printf("creating %s request on %s", "GET", "https://api.scaleway.com/instance/v1/zones/fr-par-1/servers")
+
+	entries, err := tflogtest.MultilineJSONDecode(&output)
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+	assert.Equal(t, "warn", entries[0]["@level"])
+	assert.Equal(t, "SCW_TLS_VERIFY is deprecated", entries[0]["@message"])
+	assert.Equal(t, "debug", entries[1]["@level"])
+	assert.Equal(t, "scaleway-sdk-go", entries[1][logFieldLogger])
+}
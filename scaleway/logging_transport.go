@@ -0,0 +1,81 @@
+package scaleway
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// redactedHeaders contains the HTTP headers whose values are never logged
+var redactedHeaders = []string{
+	"X-Auth-Token",
+	"Authorization",
+	"X-Amz-Security-Token",
+	"Cookie",
+	"Set-Cookie",
+}
+
+// redactHeaders returns the headers as a map with the values of sensitive headers redacted
+func redactHeaders(headers http.Header) map[string]string {
+	redacted := make(map[string]string, len(headers))
+	for key, values := range headers {
+		value := strings.Join(values, ", ")
+		for _, redactedHeader := range redactedHeaders {
+			if strings.EqualFold(key, redactedHeader) {
+				value = "[REDACTED]"
+				break
+			}
+		}
+		redacted[key] = value
+	}
+
+	return redacted
+}
+
+// responseRequestID returns the ID given by the API to a request, it can be used when contacting the support
+func responseRequestID(resp *http.Response) string {
+	if requestID := resp.Header.Get("X-Request-Id"); requestID != "" {
+		return requestID
+	}
+
+	return resp.Header.Get("X-Amz-Request-Id")
+}
+
+// newLoggingTransport creates a http transport that logs every request with tflog.
+// Logs use the context of the request so they carry the fields of the resource that made it.
+func newLoggingTransport(defaultTransport http.RoundTripper) http.RoundTripper {
+	return &loggingTransport{transport: defaultTransport}
+}
+
+type loggingTransport struct {
+	transport http.RoundTripper
+}
+
+// RoundTrip sends the request and logs its method, path, status, latency and request ID.
+func (t *loggingTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	ctx := r.Context()
+	fields := map[string]interface{}{
+		"http_method":          r.Method,
+		"http_host":            r.URL.Host,
+		"http_path":            r.URL.Path,
+		"http_request_headers": redactHeaders(r.Header),
+	}
+
+	start := time.Now()
+	resp, err := t.transport.RoundTrip(r)
+	fields["http_latency_ms"] = time.Since(start).Milliseconds()
+
+	if err != nil {
+		fields["error"] = err.Error()
+		tflog.Debug(ctx, "Scaleway API request failed", fields)
+		return resp, err
+	}
+
+	fields["http_status"] = resp.StatusCode
+	fields["scw_request_id"] = responseRequestID(resp)
+	tflog.Debug(ctx, "Scaleway API request", fields)
+
+	return resp, nil
+}
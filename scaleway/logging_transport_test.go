@@ -0,0 +1,63 @@
+package scaleway
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-log/tflogtest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRedactHeaders(t *testing.T) {
+	headers := http.Header{}
+	headers.Set("X-Auth-Token", "11111111-1111-1111-1111-111111111111")
+	headers.Set("Authorization", "AWS4-HMAC-SHA256 Credential=SCWXXXXXXXXXXXXXXXXX")
+	headers.Set("Content-Type", "application/json")
+
+	assert.Equal(t, map[string]string{
+		"X-Auth-Token":  "[REDACTED]",
+		"Authorization": "[REDACTED]",
+		"Content-Type":  "application/json",
+	}, redactHeaders(headers))
+}
+
+func TestLoggingTransport(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("X-Request-Id", "22222222-2222-2222-2222-222222222222")
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	var output bytes.Buffer
+	ctx := tflogtest.RootLogger(context.Background(), &output)
+	ctx = tflog.SetField(ctx, logFieldResourceType, "scaleway_instance_server")
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, server.URL+"/instance/v1/zones/fr-par-1/servers", nil)
+	require.NoError(t, err)
+	req.Header.Set("X-Auth-Token", "11111111-1111-1111-1111-111111111111")
+
+	client := &http.Client{Transport: newLoggingTransport(http.DefaultTransport)}
+	resp, err := client.Do(req)
+	require.NoError(t, err)
+	resp.Body.Close()
+
+	assert.NotContains(t, output.String(), "11111111-1111-1111-1111-111111111111")
+	entries, err := tflogtest.MultilineJSONDecode(&output)
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+
+	entry := entries[0]
+	assert.Equal(t, "Scaleway API request", entry["@message"])
+	assert.Equal(t, "scaleway_instance_server", entry[logFieldResourceType])
+	assert.Equal(t, http.MethodGet, entry["http_method"])
+	assert.Equal(t, "/instance/v1/zones/fr-par-1/servers", entry["http_path"])
+	assert.Equal(t, float64(http.StatusNotFound), entry["http_status"])
+	assert.Equal(t, "22222222-2222-2222-2222-222222222222", entry["scw_request_id"])
+	assert.Contains(t, entry, "http_latency_ms")
+	assert.Equal(t, "[REDACTED]", entry["http_request_headers"].(map[string]interface{})["X-Auth-Token"])
+}
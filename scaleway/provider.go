@@ -4,12 +4,15 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"net/url"
 	"os"
+	"sync"
 
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/plugin"
+	sdkLogger "github.com/scaleway/scaleway-sdk-go/logger"
 	"github.com/scaleway/scaleway-sdk-go/scw"
 )
 
@@ -74,13 +77,23 @@ func Provider(config *ProviderConfig) plugin.ProviderFunc {
 					Description:  "The Scaleway organization ID.",
 					ValidateFunc: validationUUID(),
 				},
-				"region": regionSchema(),
-				"zone":   zoneSchema(),
+				"region": providerAttributeSchema(regionSchema(), "The Scaleway default region to use."),
+				"zone":   providerAttributeSchema(zoneSchema(), "The Scaleway default zone to use."),
 				"api_url": {
 					Type:        schema.TypeString,
 					Optional:    true,
 					Description: "The Scaleway API URL to use.",
 				},
+				"endpoints": endpointsSchema(),
+				"insecure_skip_verify": {
+					Type:        schema.TypeBool,
+					Optional:    true,
+					Description: "Skip the verification of the TLS certificates of the API, useful to test against local mocks",
+				},
+				"default_tags": defaultTagsSchema(),
+				"ignore_tags":  ignoreTagsSchema(),
+				"retry":        retrySchema(),
+				"rate_limit":   rateLimitSchema(),
 			},
 
 			ResourcesMap: map[string]*schema.Resource{
@@ -125,8 +138,11 @@ func Provider(config *ProviderConfig) plugin.ProviderFunc {
 				"scaleway_instance_ip_reverse_dns":             resourceScalewayInstanceIPReverseDNS(),
 				"scaleway_instance_volume":                     resourceScalewayInstanceVolume(),
 				"scaleway_instance_security_group":             resourceScalewayInstanceSecurityGroup(),
+				"scaleway_instance_security_group_rule":        resourceScalewayInstanceSecurityGroupRule(),
 				"scaleway_instance_security_group_rules":       resourceScalewayInstanceSecurityGroupRules(),
 				"scaleway_instance_server":                     resourceScalewayInstanceServer(),
+				"scaleway_instance_server_action":              resourceScalewayInstanceServerAction(),
+				"scaleway_instance_server_backup":              resourceScalewayInstanceServerBackup(),
 				"scaleway_instance_snapshot":                   resourceScalewayInstanceSnapshot(),
 				"scaleway_iam_ssh_key":                         resourceScalewayIamSSKKey(),
 				"scaleway_instance_placement_group":            resourceScalewayInstancePlacementGroup(),
@@ -137,6 +153,7 @@ func Provider(config *ProviderConfig) plugin.ProviderFunc {
 				"scaleway_iot_network":                         resourceScalewayIotNetwork(),
 				"scaleway_ipam_ip":                             resourceScalewayIPAMIP(),
 				"scaleway_job_definition":                      resourceScalewayJobDefinition(),
+				"scaleway_k8s_acl":                             resourceScalewayK8SACL(),
 				"scaleway_k8s_cluster":                         resourceScalewayK8SCluster(),
 				"scaleway_k8s_pool":                            resourceScalewayK8SPool(),
 				"scaleway_lb":                                  resourceScalewayLb(),
@@ -216,12 +233,14 @@ func Provider(config *ProviderConfig) plugin.ProviderFunc {
 				"scaleway_iam_group":                           dataSourceScalewayIamGroup(),
 				"scaleway_iam_ssh_key":                         dataSourceScalewayIamSSHKey(),
 				"scaleway_iam_user":                            dataSourceScalewayIamUser(),
+				"scaleway_instance_cloudinit_config":           dataSourceScalewayInstanceCloudInitConfig(),
 				"scaleway_instance_ip":                         dataSourceScalewayInstanceIP(),
 				"scaleway_instance_placement_group":            dataSourceScalewayInstancePlacementGroup(),
 				"scaleway_instance_private_nic":                dataSourceScalewayInstancePrivateNIC(),
 				"scaleway_instance_security_group":             dataSourceScalewayInstanceSecurityGroup(),
 				"scaleway_instance_server":                     dataSourceScalewayInstanceServer(),
 				"scaleway_instance_servers":                    dataSourceScalewayInstanceServers(),
+				"scaleway_instance_server_types":               dataSourceScalewayInstanceServerTypes(),
 				"scaleway_instance_image":                      dataSourceScalewayInstanceImage(),
 				"scaleway_instance_volume":                     dataSourceScalewayInstanceVolume(),
 				"scaleway_instance_snapshot":                   dataSourceScalewayInstanceSnapshot(),
@@ -274,6 +293,10 @@ func Provider(config *ProviderConfig) plugin.ProviderFunc {
 		}
 
 		addBetaResources(p)
+		addProviderTags(p)
+		addResourceProfiles(p)
+		addLogFields(p)
+		sdkLogger.SetLogger(l)
 
 		p.ConfigureContextFunc = func(ctx context.Context, data *schema.ResourceData) (interface{}, diag.Diagnostics) {
 			terraformVersion := p.TerraformVersion
@@ -297,6 +320,16 @@ func Provider(config *ProviderConfig) plugin.ProviderFunc {
 	}
 }
 
+// providerAttributeSchema returns the schema of a resource attribute as a provider attribute with the given description.
+// Provider attributes are neither computed nor force new, the framework provider schema must be the same as this one.
+func providerAttributeSchema(s *schema.Schema, description string) *schema.Schema {
+	s.Computed = false
+	s.ForceNew = false
+	s.Description = description
+
+	return s
+}
+
 // Meta contains config and SDK clients used by resources.
 //
 // This meta value is passed into all resources.
@@ -307,6 +340,51 @@ type Meta struct {
 	// or it can be a http.Client used to record and replay cassettes which is useful
 	// to replay recorded interactions with APIs locally
 	httpClient *http.Client
+	// defaultTags are merged into the tags of every taggable resource
+	defaultTags []string
+	// ignoreTags are the tags that are neither shown in diffs nor removed on update
+	ignoreTags *ignoreTagsConfig
+	// s3Endpoint overrides the endpoint of the Object Storage S3 API
+	s3Endpoint string
+
+	// config is the configuration used to build this meta, it is reused to build the meta of other profiles
+	config *metaConfig
+	// profileMetas contains the meta of the profiles set on resources, by profile name
+	profileMetas   map[string]*Meta
+	profileMetasMu sync.Mutex
+}
+
+// forProfile returns the meta to use for the given profile of the scw config file.
+// Metas are built on first use. Their http client is built like the one of the provider, from the api_url and insecure option of the profile,
+// so the requests of a profile are sent to its own endpoint, but the rate limit of the provider is shared by all profiles.
+// An http client given to the provider, e.g. in tests, is shared by all profiles.
+func (m *Meta) forProfile(ctx context.Context, profileName string) (*Meta, error) {
+	if profileName == "" {
+		return m, nil
+	}
+	if m.config == nil {
+		return nil, fmt.Errorf("cannot use profile %s: provider is not configured from a scw config", profileName)
+	}
+
+	m.profileMetasMu.Lock()
+	defer m.profileMetasMu.Unlock()
+
+	if profileMeta, exists := m.profileMetas[profileName]; exists {
+		return profileMeta, nil
+	}
+
+	config := *m.config
+	config.profileName = profileName
+	profileMeta, err := buildMeta(ctx, &config)
+	if err != nil {
+		return nil, err
+	}
+	if m.profileMetas == nil {
+		m.profileMetas = map[string]*Meta{}
+	}
+	m.profileMetas[profileName] = profileMeta
+
+	return profileMeta, nil
 }
 
 type metaConfig struct {
@@ -318,6 +396,10 @@ type metaConfig struct {
 	forceAccessKey      string
 	forceSecretKey      string
 	httpClient          *http.Client
+	// profileName is the name of a profile of the scw config file overriding the provider credentials and defaults
+	profileName string
+	// rateLimiter contains the token buckets of the provider, it is built with the provider meta and shared by every profile
+	rateLimiter *rateLimiter
 }
 
 // providerConfigure creates the Meta object containing the SDK client.
@@ -329,6 +411,13 @@ func buildMeta(ctx context.Context, config *metaConfig) (*Meta, error) {
 	if err != nil {
 		return nil, err
 	}
+	if config.profileName != "" {
+		namedProfile, err := loadNamedProfile(ctx, config.profileName)
+		if err != nil {
+			return nil, err
+		}
+		profile = scw.MergeProfiles(profile, namedProfile)
+	}
 	if config.forceZone != "" {
 		region, err := config.forceZone.Region()
 		if err != nil {
@@ -360,7 +449,42 @@ func buildMeta(ctx context.Context, config *metaConfig) (*Meta, error) {
 		scw.WithProfile(profile),
 	}
 
-	httpClient := &http.Client{Transport: newRetryableTransport(http.DefaultTransport)}
+	retryOptions, err := expandProviderRetry(config.providerSchema)
+	if err != nil {
+		return nil, err
+	}
+	endpoints, err := expandProviderEndpoints(config.providerSchema)
+	if err != nil {
+		return nil, err
+	}
+	apiHost := scalewayAPIHost
+	if profile.APIURL != nil && *profile.APIURL != "" {
+		apiURL, err := url.Parse(*profile.APIURL)
+		if err != nil {
+			return nil, err
+		}
+		apiHost = apiURL.Host
+	}
+
+	transport := http.DefaultTransport
+	insecure := profile.Insecure != nil && *profile.Insecure
+	if config.providerSchema != nil && config.providerSchema.Get("insecure_skip_verify").(bool) {
+		insecure = true
+	}
+	if insecure {
+		transport = newInsecureTransport()
+	}
+	transport = newLoggingTransport(transport)
+	transport = newEndpointsTransport(transport, apiHost, endpoints.Products)
+	if config.rateLimiter == nil {
+		if rateLimitOptions := expandProviderRateLimit(config.providerSchema); rateLimitOptions != nil {
+			config.rateLimiter = newRateLimiter(*rateLimitOptions)
+		}
+	}
+	if config.rateLimiter != nil {
+		transport = newRateLimitedTransport(transport, config.rateLimiter)
+	}
+	httpClient := &http.Client{Transport: newRetryableTransportWithOptions(transport, retryOptions)}
 	if config.httpClient != nil {
 		httpClient = config.httpClient
 	}
@@ -372,8 +496,12 @@ func buildMeta(ctx context.Context, config *metaConfig) (*Meta, error) {
 	}
 
 	return &Meta{
-		scwClient:  scwClient,
-		httpClient: httpClient,
+		scwClient:   scwClient,
+		httpClient:  httpClient,
+		defaultTags: expandProviderDefaultTags(config.providerSchema),
+		ignoreTags:  expandProviderIgnoreTags(config.providerSchema),
+		s3Endpoint:  endpoints.S3,
+		config:      config,
 	}, nil
 }
 
@@ -432,8 +560,31 @@ func loadProfile(ctx context.Context, d *schema.ResourceData) (*scw.Profile, err
 
 	profile := scw.MergeProfiles(defaultZoneProfile, activeProfile, providerProfile, envProfile)
 
-	// If profile have a defaultZone but no defaultRegion we set the defaultRegion
-	// to the one of the defaultZone
+	guessProfileRegion(ctx, profile)
+
+	return profile, nil
+}
+
+// loadNamedProfile returns a profile of the scw config file.
+// Unlike the provider profile, a missing profile is an error as it is explicitly set on a resource.
+func loadNamedProfile(ctx context.Context, profileName string) (*scw.Profile, error) {
+	config, err := scw.LoadConfig()
+	if err != nil {
+		return nil, fmt.Errorf("cannot load profile %s: %w", profileName, err)
+	}
+	profile, err := config.GetProfile(profileName)
+	if err != nil {
+		return nil, err
+	}
+
+	guessProfileRegion(ctx, profile)
+
+	return profile, nil
+}
+
+// guessProfileRegion sets the defaultRegion of a profile that has a defaultZone but no defaultRegion
+// to the region of the defaultZone
+func guessProfileRegion(ctx context.Context, profile *scw.Profile) {
 	if profile.DefaultZone != nil && *profile.DefaultZone != "" &&
 		(profile.DefaultRegion == nil || *profile.DefaultRegion == "") {
 		zone := scw.Zone(*profile.DefaultZone)
@@ -445,5 +596,4 @@ func loadProfile(ctx context.Context, d *schema.ResourceData) (*scw.Profile, err
 			tflog.Debug(ctx, fmt.Sprintf("cannot guess region: %s", err.Error()))
 		}
 	}
-	return profile, nil
 }
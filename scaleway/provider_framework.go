@@ -0,0 +1,200 @@
+package scaleway
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/provider"
+	providerSchema "github.com/hashicorp/terraform-plugin-framework/provider/schema"
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-mux/tf5to6server"
+	"github.com/hashicorp/terraform-plugin-mux/tf6muxserver"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// ProviderServerFactory returns a plugin protocol v6 server serving the SDK provider alongside the framework provider.
+// Resources can be migrated from one provider to the other without any change for users.
+func ProviderServerFactory(ctx context.Context, config *ProviderConfig) (func() tfprotov6.ProviderServer, error) {
+	sdkProvider := Provider(config)()
+
+	upgradedSdkServer, err := tf5to6server.UpgradeServer(ctx, sdkProvider.GRPCProvider)
+	if err != nil {
+		return nil, err
+	}
+
+	providers := []func() tfprotov6.ProviderServer{
+		// The SDK provider must be first as it is configured first and builds the meta used by the framework provider
+		func() tfprotov6.ProviderServer {
+			return upgradedSdkServer
+		},
+		providerserver.NewProtocol6(newFrameworkProvider(sdkProvider)),
+	}
+
+	muxServer, err := tf6muxserver.NewMuxServer(ctx, providers...)
+	if err != nil {
+		return nil, err
+	}
+
+	return muxServer.ProviderServer, nil
+}
+
+// frameworkProvider is the terraform-plugin-framework implementation of the provider.
+// It shares its schema and its meta with the SDK provider, new resources should be added here.
+type frameworkProvider struct {
+	sdkProvider *schema.Provider
+}
+
+var (
+	_ provider.Provider                       = (*frameworkProvider)(nil)
+	_ provider.ProviderWithFunctions          = (*frameworkProvider)(nil)
+	_ provider.ProviderWithEphemeralResources = (*frameworkProvider)(nil)
+)
+
+func newFrameworkProvider(sdkProvider *schema.Provider) provider.Provider {
+	return &frameworkProvider{
+		sdkProvider: sdkProvider,
+	}
+}
+
+func (p *frameworkProvider) Metadata(_ context.Context, _ provider.MetadataRequest, resp *provider.MetadataResponse) {
+	resp.TypeName = "scaleway"
+	resp.Version = version
+}
+
+func (p *frameworkProvider) Schema(_ context.Context, _ provider.SchemaRequest, resp *provider.SchemaResponse) {
+	attributes, blocks, diags := frameworkProviderSchemaFromSDK(p.sdkProvider.Schema)
+	resp.Diagnostics.Append(diags...)
+	resp.Schema = providerSchema.Schema{
+		Attributes: attributes,
+		Blocks:     blocks,
+	}
+}
+
+// Configure reuses the meta built by the SDK provider, configured before by the mux server.
+func (p *frameworkProvider) Configure(_ context.Context, _ provider.ConfigureRequest, resp *provider.ConfigureResponse) {
+	meta, ok := p.sdkProvider.Meta().(*Meta)
+	if !ok {
+		resp.Diagnostics.AddError("Unconfigured provider", "The SDK provider must be configured before the framework provider")
+		return
+	}
+
+	resp.ResourceData = meta
+	resp.DataSourceData = meta
+	resp.EphemeralResourceData = meta
+}
+
+func (p *frameworkProvider) Resources(_ context.Context) []func() resource.Resource {
+	return []func() resource.Resource{}
+}
+
+func (p *frameworkProvider) DataSources(_ context.Context) []func() datasource.DataSource {
+	return []func() datasource.DataSource{}
+}
+
+func (p *frameworkProvider) EphemeralResources(_ context.Context) []func() ephemeral.EphemeralResource {
+	return []func() ephemeral.EphemeralResource{
+		newEphemeralIamAPIKey,
+		newEphemeralK8SKubeconfig,
+		newEphemeralSecretVersion,
+	}
+}
+
+func (p *frameworkProvider) Functions(_ context.Context) []func() function.Function {
+	return []func() function.Function{
+		newParseIDFunction,
+		newParseNestedIDFunction,
+		newZoneToRegionFunction,
+		newBuildZonedIDFunction,
+		newBuildRegionalIDFunction,
+	}
+}
+
+// frameworkProviderSchemaFromSDK converts the SDK provider schema to the framework one.
+// Provider schemas must be identical for the mux server, the SDK schema is the only one to maintain.
+func frameworkProviderSchemaFromSDK(sdkSchema map[string]*schema.Schema) (map[string]providerSchema.Attribute, map[string]providerSchema.Block, diag.Diagnostics) {
+	attributes := map[string]providerSchema.Attribute{}
+	blocks := map[string]providerSchema.Block{}
+	var diags diag.Diagnostics
+
+	for name, s := range sdkSchema {
+		if elem, isResource := s.Elem.(*schema.Resource); isResource {
+			nestedAttributes, nestedBlocks, nestedDiags := frameworkProviderSchemaFromSDK(elem.Schema)
+			diags.Append(nestedDiags...)
+			nestedObject := providerSchema.NestedBlockObject{
+				Attributes: nestedAttributes,
+				Blocks:     nestedBlocks,
+			}
+			if s.Type == schema.TypeSet {
+				blocks[name] = providerSchema.SetNestedBlock{
+					NestedObject:       nestedObject,
+					Description:        s.Description,
+					DeprecationMessage: s.Deprecated,
+				}
+			} else {
+				blocks[name] = providerSchema.ListNestedBlock{
+					NestedObject:       nestedObject,
+					Description:        s.Description,
+					DeprecationMessage: s.Deprecated,
+				}
+			}
+			continue
+		}
+
+		attribute, err := frameworkProviderAttributeFromSDK(s)
+		if err != nil {
+			diags.AddError("Invalid provider schema", fmt.Sprintf("cannot convert provider attribute %s: %s", name, err))
+			continue
+		}
+		attributes[name] = attribute
+	}
+
+	return attributes, blocks, diags
+}
+
+func frameworkProviderAttributeFromSDK(s *schema.Schema) (providerSchema.Attribute, error) {
+	switch s.Type {
+	case schema.TypeBool:
+		return providerSchema.BoolAttribute{Required: s.Required, Optional: s.Optional, Sensitive: s.Sensitive, Description: s.Description, DeprecationMessage: s.Deprecated}, nil
+	case schema.TypeInt:
+		return providerSchema.Int64Attribute{Required: s.Required, Optional: s.Optional, Sensitive: s.Sensitive, Description: s.Description, DeprecationMessage: s.Deprecated}, nil
+	case schema.TypeFloat:
+		return providerSchema.Float64Attribute{Required: s.Required, Optional: s.Optional, Sensitive: s.Sensitive, Description: s.Description, DeprecationMessage: s.Deprecated}, nil
+	case schema.TypeList:
+		return providerSchema.ListAttribute{ElementType: frameworkElemTypeFromSDK(s.Elem), Required: s.Required, Optional: s.Optional, Sensitive: s.Sensitive, Description: s.Description, DeprecationMessage: s.Deprecated}, nil
+	case schema.TypeSet:
+		return providerSchema.SetAttribute{ElementType: frameworkElemTypeFromSDK(s.Elem), Required: s.Required, Optional: s.Optional, Sensitive: s.Sensitive, Description: s.Description, DeprecationMessage: s.Deprecated}, nil
+	case schema.TypeMap:
+		return providerSchema.MapAttribute{ElementType: frameworkElemTypeFromSDK(s.Elem), Required: s.Required, Optional: s.Optional, Sensitive: s.Sensitive, Description: s.Description, DeprecationMessage: s.Deprecated}, nil
+	case schema.TypeString:
+		return providerSchema.StringAttribute{Required: s.Required, Optional: s.Optional, Sensitive: s.Sensitive, Description: s.Description, DeprecationMessage: s.Deprecated}, nil
+	default:
+		return nil, fmt.Errorf("unsupported type %s", s.Type)
+	}
+}
+
+// frameworkElemTypeFromSDK returns the type of the elements of a SDK collection, strings by default like the SDK
+func frameworkElemTypeFromSDK(elem interface{}) attr.Type {
+	elemSchema, ok := elem.(*schema.Schema)
+	if !ok {
+		return types.StringType
+	}
+
+	switch elemSchema.Type {
+	case schema.TypeBool:
+		return types.BoolType
+	case schema.TypeInt:
+		return types.Int64Type
+	case schema.TypeFloat:
+		return types.Float64Type
+	default:
+		return types.StringType
+	}
+}
@@ -0,0 +1,244 @@
+package scaleway
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProviderServerFactory(t *testing.T) {
+	ctx := context.Background()
+
+	providerServer, err := ProviderServerFactory(ctx, DefaultProviderConfig())
+	require.NoError(t, err)
+
+	// The mux server returns an error if the schemas of the SDK and framework providers differ
+	resp, err := providerServer().GetProviderSchema(ctx, &tfprotov6.GetProviderSchemaRequest{})
+	require.NoError(t, err)
+	for _, diag := range resp.Diagnostics {
+		t.Log(diag.Summary, diag.Detail)
+	}
+	assert.Empty(t, resp.Diagnostics)
+	assert.Contains(t, resp.ResourceSchemas, "scaleway_instance_server")
+	assert.Contains(t, resp.DataSourceSchemas, "scaleway_instance_server")
+	assert.Contains(t, resp.Functions, "parse_id")
+	assert.Contains(t, resp.EphemeralResourceSchemas, "scaleway_secret_version")
+}
+
+func TestFrameworkProviderSchemaFromSDK(t *testing.T) {
+	attributes, blocks, diags := frameworkProviderSchemaFromSDK(Provider(DefaultProviderConfig())().Schema)
+	require.False(t, diags.HasError(), diags)
+	assert.Contains(t, attributes, "region")
+	assert.Contains(t, blocks, "rate_limit")
+
+	// An attribute type that cannot be converted is reported instead of crashing the provider
+	_, _, diags = frameworkProviderSchemaFromSDK(map[string]*schema.Schema{
+		"invalid": {Type: schema.TypeInvalid, Optional: true},
+	})
+	require.True(t, diags.HasError())
+	assert.Contains(t, diags[0].Detail(), "cannot convert provider attribute invalid")
+}
+
+// runFunction runs a provider function with the given arguments and returns its result
+func runFunction(t *testing.T, f function.Function, result attr.Value, arguments ...attr.Value) (attr.Value, *function.FuncError) {
+	t.Helper()
+
+	resp := &function.RunResponse{
+		Result: function.NewResultData(result),
+	}
+	f.Run(context.Background(), function.RunRequest{
+		Arguments: function.NewArgumentsData(arguments),
+	}, resp)
+
+	return resp.Result.Value(), resp.Error
+}
+
+// newConfiguredProviderServer returns a provider server configured with the meta of the test tools, as terraform
+// configures the provider before opening or closing ephemeral resources
+func newConfiguredProviderServer(t *testing.T, tt *TestTools) (tfprotov6.ProviderServer, *tfprotov6.GetProviderSchemaResponse) {
+	t.Helper()
+	ctx := context.Background()
+
+	providerServer, err := newProviderServer(ctx, tt.Meta)
+	require.NoError(t, err)
+
+	schemas, err := providerServer.GetProviderSchema(ctx, &tfprotov6.GetProviderSchemaRequest{})
+	require.NoError(t, err)
+	require.Empty(t, schemas.Diagnostics)
+
+	configureResp, err := providerServer.ConfigureProvider(ctx, &tfprotov6.ConfigureProviderRequest{
+		TerraformVersion: "terraform-tests",
+		Config:           dynamicValue(t, schemas.Provider, configValue(t, schemas.Provider, nil)),
+	})
+	require.NoError(t, err)
+	require.Empty(t, configureResp.Diagnostics)
+
+	return providerServer, schemas
+}
+
+// openEphemeralResource opens an ephemeral resource with the given configuration, the attributes missing from
+// the configuration are null.
+// It returns the attributes of the result and the private data to give back on close.
+func openEphemeralResource(t *testing.T, tt *TestTools, typeName string, config map[string]tftypes.Value) (map[string]tftypes.Value, []byte) {
+	t.Helper()
+
+	providerServer, schemas := newConfiguredProviderServer(t, tt)
+	require.Contains(t, schemas.EphemeralResourceSchemas, typeName)
+
+	resourceSchema := schemas.EphemeralResourceSchemas[typeName]
+	openResp, err := providerServer.OpenEphemeralResource(context.Background(), &tfprotov6.OpenEphemeralResourceRequest{
+		TypeName: typeName,
+		Config:   dynamicValue(t, resourceSchema, configValue(t, resourceSchema, config)),
+	})
+	require.NoError(t, err)
+	for _, diag := range openResp.Diagnostics {
+		t.Log(diag.Summary, diag.Detail)
+	}
+	require.Empty(t, openResp.Diagnostics)
+	require.NotNil(t, openResp.Result)
+
+	result, err := openResp.Result.Unmarshal(resourceSchema.ValueType())
+	require.NoError(t, err)
+	attributes := map[string]tftypes.Value{}
+	require.NoError(t, result.As(&attributes))
+
+	return attributes, openResp.Private
+}
+
+// closeEphemeralResource closes an ephemeral resource opened with the given private data
+func closeEphemeralResource(t *testing.T, tt *TestTools, typeName string, private []byte) {
+	t.Helper()
+
+	providerServer, _ := newConfiguredProviderServer(t, tt)
+	closeResp, err := providerServer.CloseEphemeralResource(context.Background(), &tfprotov6.CloseEphemeralResourceRequest{
+		TypeName: typeName,
+		Private:  private,
+	})
+	require.NoError(t, err)
+	for _, diag := range closeResp.Diagnostics {
+		t.Log(diag.Summary, diag.Detail)
+	}
+	require.Empty(t, closeResp.Diagnostics)
+}
+
+// applyResourceConfig plans and applies a configuration of a resource over its prior state, as terraform apply does.
+// The prior state of a resource to create is the zero value. The resource must be updated in place, it returns its new state.
+func applyResourceConfig(t *testing.T, tt *TestTools, typeName string, prior tftypes.Value, config map[string]tftypes.Value) tftypes.Value {
+	t.Helper()
+	ctx := context.Background()
+
+	providerServer, schemas := newConfiguredProviderServer(t, tt)
+	require.Contains(t, schemas.ResourceSchemas, typeName)
+	resourceSchema := schemas.ResourceSchemas[typeName]
+	if prior.IsNull() {
+		prior = tftypes.NewValue(resourceSchema.ValueType(), nil)
+	}
+
+	resourceConfig := configValue(t, resourceSchema, config)
+	configAttributes := map[string]tftypes.Value{}
+	require.NoError(t, resourceConfig.As(&configAttributes))
+	priorAttributes := map[string]tftypes.Value{}
+	if !prior.IsNull() {
+		require.NoError(t, prior.As(&priorAttributes))
+	}
+
+	// The proposed new state is the configuration completed with the computed attributes of the prior state
+	proposedAttributes := map[string]tftypes.Value{}
+	for name, value := range configAttributes {
+		proposedAttributes[name] = value
+	}
+	for _, attribute := range resourceSchema.Block.Attributes {
+		switch {
+		case attribute.WriteOnly:
+			proposedAttributes[attribute.Name] = tftypes.NewValue(attribute.Type, nil)
+		case attribute.Computed && configAttributes[attribute.Name].IsNull() && !prior.IsNull():
+			proposedAttributes[attribute.Name] = priorAttributes[attribute.Name]
+		}
+	}
+	proposed := tftypes.NewValue(resourceSchema.ValueType(), proposedAttributes)
+
+	planResp, err := providerServer.PlanResourceChange(ctx, &tfprotov6.PlanResourceChangeRequest{
+		TypeName:         typeName,
+		PriorState:       dynamicValue(t, resourceSchema, prior),
+		ProposedNewState: dynamicValue(t, resourceSchema, proposed),
+		Config:           dynamicValue(t, resourceSchema, resourceConfig),
+	})
+	require.NoError(t, err)
+	for _, diag := range planResp.Diagnostics {
+		t.Log(diag.Summary, diag.Detail)
+	}
+	require.Empty(t, planResp.Diagnostics)
+	if !prior.IsNull() {
+		require.Empty(t, planResp.RequiresReplace)
+	}
+
+	applyResp, err := providerServer.ApplyResourceChange(ctx, &tfprotov6.ApplyResourceChangeRequest{
+		TypeName:       typeName,
+		PriorState:     dynamicValue(t, resourceSchema, prior),
+		PlannedState:   planResp.PlannedState,
+		Config:         dynamicValue(t, resourceSchema, resourceConfig),
+		PlannedPrivate: planResp.PlannedPrivate,
+	})
+	require.NoError(t, err)
+	for _, diag := range applyResp.Diagnostics {
+		t.Log(diag.Summary, diag.Detail)
+	}
+	require.Empty(t, applyResp.Diagnostics)
+
+	state, err := applyResp.NewState.Unmarshal(resourceSchema.ValueType())
+	require.NoError(t, err)
+
+	return state
+}
+
+// configValue returns a configuration of the schema holding the given attributes,
+// other attributes are null and nested blocks are empty
+func configValue(t *testing.T, s *tfprotov6.Schema, attributes map[string]tftypes.Value) tftypes.Value {
+	t.Helper()
+
+	objectType := s.ValueType().(tftypes.Object)
+	values := map[string]tftypes.Value{}
+	for name, attributeType := range objectType.AttributeTypes {
+		values[name] = tftypes.NewValue(attributeType, nil)
+	}
+	for _, block := range s.Block.BlockTypes {
+		switch block.Nesting {
+		case tfprotov6.SchemaNestedBlockNestingModeList, tfprotov6.SchemaNestedBlockNestingModeSet:
+			values[block.TypeName] = tftypes.NewValue(block.ValueType(), []tftypes.Value{})
+		}
+	}
+	for name, value := range attributes {
+		require.Contains(t, values, name)
+		values[name] = value
+	}
+
+	return tftypes.NewValue(objectType, values)
+}
+
+// dynamicValue encodes a value of the schema to send it to the provider server
+func dynamicValue(t *testing.T, s *tfprotov6.Schema, value tftypes.Value) *tfprotov6.DynamicValue {
+	t.Helper()
+
+	dynamicValue, err := tfprotov6.NewDynamicValue(s.ValueType(), value)
+	require.NoError(t, err)
+
+	return &dynamicValue
+}
+
+// stringAttribute returns the string held by an attribute of an object value
+func stringAttribute(t *testing.T, attributes map[string]tftypes.Value, name string) string {
+	t.Helper()
+
+	var value string
+	require.Contains(t, attributes, name)
+	require.NoError(t, attributes[name].As(&value))
+
+	return value
+}
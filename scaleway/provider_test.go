@@ -2,29 +2,25 @@ package scaleway
 
 import (
 	"context"
-	"encoding/json"
-	"encoding/xml"
 	"flag"
 	"fmt"
-	"io"
 	"net/http"
-	"net/url"
 	"os"
 	"path/filepath"
-	"regexp"
-	"strings"
 	"testing"
 	"time"
 
 	"github.com/dnaeon/go-vcr/cassette"
 	"github.com/dnaeon/go-vcr/recorder"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
 	sdkacctest "github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	accountV3 "github.com/scaleway/scaleway-sdk-go/api/account/v3"
 	iam "github.com/scaleway/scaleway-sdk-go/api/iam/v1alpha1"
 	"github.com/scaleway/scaleway-sdk-go/scw"
-	"github.com/scaleway/scaleway-sdk-go/strcase"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/cassettes"
+	"github.com/scaleway/terraform-provider-scaleway/v2/internal/fakeapi"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -32,256 +28,13 @@ import (
 // UpdateCassettes will update all cassettes of a given test
 var UpdateCassettes = flag.Bool("cassettes", os.Getenv("TF_UPDATE_CASSETTES") == "true", "Record Cassettes")
 
-// QueryMatcherIgnore contains the list of query value that should be ignored when matching requests with cassettes
-var QueryMatcherIgnore = []string{
-	"organization_id",
-}
-
-// BodyMatcherIgnore contains the list of json body keys that should be ignored when matching requests with cassettes
-var BodyMatcherIgnore = []string{
-	"organization", // like organization_id but deprecated
-	"organization_id",
-	"project_id",
-	"project", // like project_id but should be deprecated
-}
-
-// SensitiveFields is a map with keys listing fields that should be anonymized
-// value will be set in place of its old value
-var SensitiveFields = map[string]interface{}{
-	"secret_key": "00000000-0000-0000-0000-000000000000",
-}
-
 func testAccPreCheck(_ *testing.T) {}
 
 // getTestFilePath returns a valid filename path based on the go test name and suffix. (Take care of non fs friendly char)
 func getTestFilePath(t *testing.T, suffix string) string {
 	t.Helper()
-	specialChars := regexp.MustCompile(`[\\?%*:|"<>. ]`)
-
-	// Replace nested tests separators.
-	fileName := strings.ReplaceAll(t.Name(), "/", "-")
-
-	fileName = strcase.ToBashArg(fileName)
 
-	// Replace special characters.
-	fileName = specialChars.ReplaceAllLiteralString(fileName, "") + suffix
-
-	// Remove prefix to simplify
-	fileName = strings.TrimPrefix(fileName, "test-acc-scaleway-")
-
-	return filepath.Join(".", "testdata", fileName)
-}
-
-func compareJSONFields(expected, actualI interface{}) bool {
-	switch actual := actualI.(type) {
-	case string:
-		if _, isString := expected.(string); !isString {
-			return false
-		}
-		return compareJSONFieldsStrings(expected.(string), actual)
-	default:
-		// Consider equality when not handled
-		return true
-	}
-}
-
-// compareJSONBodies compare two given maps that represent json bodies
-// returns true if both json are equivalent
-func compareJSONBodies(expected, actual map[string]interface{}) bool {
-	// Check for each key in actual requests
-	// Compare its value to cassette content if marshal-able to string
-	for key := range actual {
-		expectedValue, exists := expected[key]
-		if !exists {
-			// Actual request may contain a field that does not exist in cassette
-			// New fields can appear in requests with new api features
-			// We do not want to generate new cassettes for each new features
-			continue
-		}
-		if !compareJSONFields(expectedValue, actual[key]) {
-			return false
-		}
-	}
-
-	for key := range expected {
-		_, exists := actual[key]
-		if !exists && expected[key] != nil {
-			// Fails match if cassettes contains a field not in actual requests
-			// Fields should not disappear from requests unless a sdk breaking change
-			// We ignore if field is nil in cassette as it could be an old deprecated and unused field
-			return false
-		}
-	}
-	return true
-}
-
-// compareFormBodies compare two given url.Values
-// returns true if both url.Values are equivalent
-func compareFormBodies(expected, actual url.Values) bool {
-	// Check for each key in actual requests
-	// Compare its value to cassette content if marshal-able to string
-	for key := range actual {
-		expectedValue, exists := expected[key]
-		if !exists {
-			// Actual request may contain a field that does not exist in cassette
-			// New fields can appear in requests with new api features
-			// We do not want to generate new cassettes for each new features
-			continue
-		}
-		if !compareJSONFields(expectedValue, actual[key]) {
-			return false
-		}
-	}
-
-	for key := range expected {
-		_, exists := actual[key]
-		if !exists && expected[key] != nil {
-			// Fails match if cassettes contains a field not in actual requests
-			// Fields should not disappear from requests unless a sdk breaking change
-			// We ignore if field is nil in cassette as it could be an old deprecated and unused field
-			return false
-		}
-	}
-
-	return true
-}
-
-// cassetteMatcher is a custom matcher that will juste check equivalence of request bodies
-func cassetteBodyMatcher(actualRequest *http.Request, cassetteRequest cassette.Request) bool {
-	if actualRequest.Body == nil || actualRequest.ContentLength == 0 {
-		if cassetteRequest.Body == "" {
-			return true // Body match if both are empty
-		} else if _, isFile := actualRequest.Body.(*os.File); isFile {
-			return true // Body match if request is sending a file, maybe do more check here
-		}
-		return false
-	}
-
-	actualBody, err := actualRequest.GetBody()
-	if err != nil {
-		panic(fmt.Errorf("cassette body matcher: failed to copy actualRequest body: %w", err)) // lintignore: R009
-	}
-	actualRawBody, err := io.ReadAll(actualBody)
-	if err != nil {
-		panic(fmt.Errorf("cassette body matcher: failed to read actualRequest body: %w", err)) // lintignore: R009
-	}
-
-	// Try to match raw bodies if they are not JSON (ex: cloud-init config)
-	if string(actualRawBody) == cassetteRequest.Body {
-		return true
-	}
-
-	actualJSON := make(map[string]interface{})
-	cassetteJSON := make(map[string]interface{})
-
-	err = xml.Unmarshal(actualRawBody, new(interface{}))
-	if err == nil {
-		// match if content is xml
-		return true
-	}
-
-	if !json.Valid(actualRawBody) {
-		values, err := url.ParseQuery(string(actualRawBody))
-		if err != nil {
-			panic(fmt.Errorf("cassette body matcher: failed to parse body as url values: %w", err)) // lintignore: R009
-		}
-
-		// Remove keys that should be ignored during compare
-		for _, key := range BodyMatcherIgnore {
-			values.Del(key)
-		}
-
-		// Compare url values
-		return compareFormBodies(values, cassetteRequest.Form)
-	}
-
-	err = json.Unmarshal(actualRawBody, &actualJSON)
-	if err != nil {
-		panic(fmt.Errorf("cassette body matcher: failed to parse json body: %w", err)) // lintignore: R009
-	}
-
-	err = json.Unmarshal([]byte(cassetteRequest.Body), &cassetteJSON)
-	if err != nil {
-		// actualRequest contains JSON but cassette may not contain JSON, this doesn't match in this case
-		return false
-	}
-
-	// Remove keys that should be ignored during compare
-	for _, key := range BodyMatcherIgnore {
-		delete(actualJSON, key)
-		delete(cassetteJSON, key)
-	}
-
-	return compareJSONBodies(cassetteJSON, actualJSON)
-}
-
-// cassetteMatcher is a custom matcher that check equivalence of a played request against a recorded one
-// It compares method, path and query but will remove unwanted values from query
-func cassetteMatcher(actual *http.Request, expected cassette.Request) bool {
-	expectedURL, _ := url.Parse(expected.URL)
-	actualURL := actual.URL
-	actualURLValues := actualURL.Query()
-	expectedURLValues := expectedURL.Query()
-	for _, query := range QueryMatcherIgnore {
-		actualURLValues.Del(query)
-		expectedURLValues.Del(query)
-	}
-	actualURL.RawQuery = actualURLValues.Encode()
-	expectedURL.RawQuery = expectedURLValues.Encode()
-
-	// Specific handling of s3 URLs
-	// Url format is https://test-acc-scaleway-object-bucket-lifecycle-8445817190507446251.s3.fr-par.scw.cloud/?lifecycle=
-	if strings.HasSuffix(actualURL.Host, "scw.cloud") {
-		if !strings.HasSuffix(expectedURL.Host, "scw.cloud") {
-			return false
-		}
-		actualS3Host := strings.Split(actualURL.Host, ".")
-		expectedS3Host := strings.Split(expectedURL.Host, ".")
-
-		if len(actualS3Host) >= 5 && len(expectedS3Host) >= 5 {
-			// Host is bucket.s3.region.scw.cloud
-			// it could be a host without bucket name (ex: function upload)
-			actualBucket := actualS3Host[0]
-			expectedBucket := expectedS3Host[0]
-
-			// Remove random number at the end of the bucket name
-			if strings.Contains(actualBucket, "-") {
-				actualBucket = actualBucket[:strings.LastIndex(actualBucket, "-")]
-			}
-			if strings.Contains(expectedBucket, "-") {
-				expectedBucket = expectedBucket[:strings.LastIndex(expectedBucket, "-")]
-			}
-
-			if actualBucket != expectedBucket {
-				return false
-			}
-		}
-	}
-
-	return actual.Method == expected.Method &&
-		actual.URL.Path == expectedURL.Path &&
-		actualURL.RawQuery == expectedURL.RawQuery &&
-		cassetteBodyMatcher(actual, expected)
-}
-
-func cassetteSensitiveFieldsAnonymizer(i *cassette.Interaction) error {
-	var jsonBody map[string]interface{}
-	err := json.Unmarshal([]byte(i.Response.Body), &jsonBody)
-	if err != nil {
-		//nolint:nilerr
-		return nil
-	}
-	for key, value := range SensitiveFields {
-		if _, ok := jsonBody[key]; ok {
-			jsonBody[key] = value
-		}
-	}
-	anonymizedBody, err := json.Marshal(jsonBody)
-	if err != nil {
-		return fmt.Errorf("failed to marshal anonymized body: %w", err)
-	}
-	i.Response.Body = string(anonymizedBody)
-	return nil
+	return filepath.Join(".", "testdata", cassettes.FileName(t.Name())+suffix)
 }
 
 // getHTTPRecoder creates a new httpClient that records all HTTP requests in a cassette.
@@ -304,19 +57,19 @@ func getHTTPRecoder(t *testing.T, update bool) (client *http.Client, cleanup fun
 	}
 
 	// Add custom matcher for requests and cassettes
-	r.SetMatcher(cassetteMatcher)
+	r.SetMatcher(cassettes.Matcher)
 
 	// Add a filter which removes Authorization headers from all requests:
 	r.AddFilter(func(i *cassette.Interaction) error {
 		i.Request.Headers = i.Request.Headers.Clone()
-		delete(i.Request.Headers, "x-auth-token")
-		delete(i.Request.Headers, "X-Auth-Token")
-		delete(i.Request.Headers, "Authorization")
+		for _, header := range cassettes.SensitiveHeaders {
+			delete(i.Request.Headers, header)
+		}
 		return nil
 	})
 
 	// Add a filter that will replace sensitive values with fixed values
-	r.AddSaveFilter(cassetteSensitiveFieldsAnonymizer)
+	r.AddSaveFilter(cassettes.SensitiveFieldsAnonymizer)
 
 	retryOptions := retryableTransportOptions{}
 	if !*UpdateCassettes {
@@ -528,7 +281,7 @@ func createFakeIAMManager(tt *TestTools) (*accountV3.Project, *iam.APIKey, FakeS
 // given project and API key as default profile configuration.
 //
 // This is useful to test resources that need to create resources in another project.
-func fakeSideProjectProviders(ctx context.Context, tt *TestTools, project *accountV3.Project, iamAPIKey *iam.APIKey) map[string]func() (*schema.Provider, error) {
+func fakeSideProjectProviders(ctx context.Context, tt *TestTools, project *accountV3.Project, iamAPIKey *iam.APIKey) map[string]func() (tfprotov6.ProviderServer, error) {
 	t := tt.T
 
 	metaSide, err := buildMeta(ctx, &metaConfig{
@@ -541,9 +294,9 @@ func fakeSideProjectProviders(ctx context.Context, tt *TestTools, project *accou
 	})
 	require.NoError(t, err)
 
-	providers := map[string]func() (*schema.Provider, error){
-		"side": func() (*schema.Provider, error) {
-			return Provider(&ProviderConfig{Meta: metaSide})(), nil
+	providers := map[string]func() (tfprotov6.ProviderServer, error){
+		"side": func() (tfprotov6.ProviderServer, error) {
+			return newProviderServer(ctx, metaSide)
 		},
 	}
 
@@ -554,11 +307,23 @@ func fakeSideProjectProviders(ctx context.Context, tt *TestTools, project *accou
 	return providers
 }
 
+// newProviderServer returns a server of the provider using the given meta, serving both the SDK and framework providers
+func newProviderServer(ctx context.Context, meta *Meta) (tfprotov6.ProviderServer, error) {
+	providerServer, err := ProviderServerFactory(ctx, &ProviderConfig{Meta: meta})
+	if err != nil {
+		return nil, err
+	}
+
+	return providerServer(), nil
+}
+
 type TestTools struct {
 	T                 *testing.T
 	Meta              *Meta
-	ProviderFactories map[string]func() (*schema.Provider, error)
+	ProviderFactories map[string]func() (tfprotov6.ProviderServer, error)
 	Cleanup           func()
+	// FakeAPI is the fake API the provider talks to, nil when using cassettes
+	FakeAPI *fakeapi.Server
 }
 
 func NewTestTools(t *testing.T) *TestTools {
@@ -584,15 +349,50 @@ func NewTestTools(t *testing.T) *TestTools {
 	return &TestTools{
 		T:    t,
 		Meta: meta,
-		ProviderFactories: map[string]func() (*schema.Provider, error){
-			"scaleway": func() (*schema.Provider, error) {
-				return Provider(&ProviderConfig{Meta: meta})(), nil
+		ProviderFactories: map[string]func() (tfprotov6.ProviderServer, error){
+			"scaleway": func() (tfprotov6.ProviderServer, error) {
+				return newProviderServer(ctx, meta)
 			},
 		},
 		Cleanup: cleanup,
 	}
 }
 
+// NewTestToolsWithFakeAPI returns test tools whose provider talks to an in-process fake API instead of cassettes.
+// Tests using it run offline, they can only use the resources supported by the fake API.
+func NewTestToolsWithFakeAPI(t *testing.T) *TestTools {
+	t.Helper()
+	ctx := context.Background()
+	fakeAPI := fakeapi.New()
+
+	meta, err := buildMeta(ctx, &metaConfig{
+		providerSchema:      nil,
+		terraformVersion:    "terraform-tests",
+		forceZone:           scw.ZoneFrPar1,
+		forceProjectID:      fakeapi.DefaultProjectID,
+		forceOrganizationID: fakeapi.DefaultOrganizationID,
+		forceAccessKey:      "SCWXXXXXXXXXXXXXXXXX",
+		forceSecretKey:      "11111111-1111-1111-1111-111111111111",
+		httpClient:          fakeAPI.Client(),
+	})
+	require.NoError(t, err)
+
+	tmp := 0 * time.Second
+	DefaultWaitRetryInterval = &tmp
+
+	return &TestTools{
+		T:    t,
+		Meta: meta,
+		ProviderFactories: map[string]func() (tfprotov6.ProviderServer, error){
+			"scaleway": func() (tfprotov6.ProviderServer, error) {
+				return newProviderServer(ctx, meta)
+			},
+		},
+		Cleanup: func() {},
+		FakeAPI: fakeAPI,
+	}
+}
+
 func TestAccScalewayProvider_SSHKeys(t *testing.T) {
 	tt := NewTestTools(t)
 	defer tt.Cleanup()
@@ -604,7 +404,7 @@ func TestAccScalewayProvider_SSHKeys(t *testing.T) {
 
 	resource.ParallelTest(t, resource.TestCase{
 		PreCheck: func() { testAccPreCheck(t) },
-		ProviderFactories: func() map[string]func() (*schema.Provider, error) {
+		ProtoV6ProviderFactories: func() map[string]func() (tfprotov6.ProviderServer, error) {
 			metaProd, err := buildMeta(ctx, &metaConfig{
 				terraformVersion: "terraform-tests",
 				httpClient:       tt.Meta.httpClient,
@@ -617,12 +417,12 @@ func TestAccScalewayProvider_SSHKeys(t *testing.T) {
 			})
 			require.NoError(t, err)
 
-			return map[string]func() (*schema.Provider, error){
-				"prod": func() (*schema.Provider, error) {
-					return Provider(&ProviderConfig{Meta: metaProd})(), nil
+			return map[string]func() (tfprotov6.ProviderServer, error){
+				"prod": func() (tfprotov6.ProviderServer, error) {
+					return newProviderServer(ctx, metaProd)
 				},
-				"dev": func() (*schema.Provider, error) {
-					return Provider(&ProviderConfig{Meta: metaDev})(), nil
+				"dev": func() (tfprotov6.ProviderServer, error) {
+					return newProviderServer(ctx, metaDev)
 				},
 			}
 		}(),
@@ -659,7 +459,7 @@ func TestAccScalewayProvider_InstanceIPZones(t *testing.T) {
 
 	resource.ParallelTest(t, resource.TestCase{
 		PreCheck: func() { testAccPreCheck(t) },
-		ProviderFactories: func() map[string]func() (*schema.Provider, error) {
+		ProtoV6ProviderFactories: func() map[string]func() (tfprotov6.ProviderServer, error) {
 			metaProd, err := buildMeta(ctx, &metaConfig{
 				terraformVersion: "terraform-tests",
 				forceZone:        scw.ZoneFrPar2,
@@ -674,12 +474,12 @@ func TestAccScalewayProvider_InstanceIPZones(t *testing.T) {
 			})
 			require.NoError(t, err)
 
-			return map[string]func() (*schema.Provider, error){
-				"prod": func() (*schema.Provider, error) {
-					return Provider(&ProviderConfig{Meta: metaProd})(), nil
+			return map[string]func() (tfprotov6.ProviderServer, error){
+				"prod": func() (tfprotov6.ProviderServer, error) {
+					return newProviderServer(ctx, metaProd)
 				},
-				"dev": func() (*schema.Provider, error) {
-					return Provider(&ProviderConfig{Meta: metaDev})(), nil
+				"dev": func() (tfprotov6.ProviderServer, error) {
+					return newProviderServer(ctx, metaDev)
 				},
 			}
 		}(),
@@ -705,3 +505,39 @@ func TestAccScalewayProvider_InstanceIPZones(t *testing.T) {
 		},
 	})
 }
+
+func TestProvider_FakeAPI(t *testing.T) {
+	tt := NewTestToolsWithFakeAPI(t)
+	defer tt.Cleanup()
+	ctx := context.Background()
+	resources := Provider(&ProviderConfig{Meta: tt.Meta})().ResourcesMap
+
+	secretResource := resources["scaleway_secret"]
+	secretData := schema.TestResourceDataRaw(t, secretResource.Schema, map[string]interface{}{
+		"name": "test-fake-api",
+	})
+	diags := secretResource.CreateContext(ctx, secretData, tt.Meta)
+	require.False(t, diags.HasError(), diags)
+	assert.Equal(t, "ready", secretData.Get("status"))
+	assert.Equal(t, fakeapi.DefaultProjectID, secretData.Get("project_id"))
+
+	versionResource := resources["scaleway_secret_version"]
+	versionData := schema.TestResourceDataRaw(t, versionResource.Schema, map[string]interface{}{
+		"secret_id": secretData.Id(),
+		"data":      "my-secret",
+	})
+	diags = versionResource.CreateContext(ctx, versionData, tt.Meta)
+	require.False(t, diags.HasError(), diags)
+	assert.Equal(t, "1", versionData.Get("revision"))
+
+	diags = secretResource.ReadContext(ctx, secretData, tt.Meta)
+	require.False(t, diags.HasError(), diags)
+	assert.Equal(t, 1, secretData.Get("version_count"))
+
+	diags = secretResource.DeleteContext(ctx, secretData, tt.Meta)
+	require.False(t, diags.HasError(), diags)
+
+	diags = secretResource.ReadContext(ctx, secretData, tt.Meta)
+	require.False(t, diags.HasError(), diags)
+	assert.Empty(t, secretData.Id())
+}
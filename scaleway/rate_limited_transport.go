@@ -0,0 +1,189 @@
+package scaleway
+
+import (
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"golang.org/x/time/rate"
+)
+
+// scalewayAPIHost is the host of the Scaleway API, its products are identified by the first segment of the request path
+const scalewayAPIHost = "api.scaleway.com"
+
+// rateLimitSchema returns the provider schema of the rate_limit block
+func rateLimitSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeList,
+		Optional:    true,
+		MaxItems:    1,
+		Description: "Client-side rate limit of the requests made to the Scaleway API, each product or API host has its own limit",
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"requests_per_second": {
+					Type:         schema.TypeFloat,
+					Required:     true,
+					Description:  "Maximum number of requests per second sent to each product",
+					ValidateFunc: validation.FloatAtLeast(0.01),
+				},
+				"burst": {
+					Type:         schema.TypeInt,
+					Optional:     true,
+					Default:      1,
+					Description:  "Maximum number of requests sent at once to each product",
+					ValidateFunc: validation.IntAtLeast(1),
+				},
+				"product": {
+					Type:        schema.TypeList,
+					Optional:    true,
+					Description: "Rate limit of a given product, overriding the default one",
+					Elem: &schema.Resource{
+						Schema: map[string]*schema.Schema{
+							"name": {
+								Type:        schema.TypeString,
+								Required:    true,
+								Description: "Name of the product as found in the API path (e.g. instance, domain, k8s) or API host (e.g. s3.fr-par.scw.cloud)",
+							},
+							"requests_per_second": {
+								Type:         schema.TypeFloat,
+								Required:     true,
+								Description:  "Maximum number of requests per second sent to the product",
+								ValidateFunc: validation.FloatAtLeast(0.01),
+							},
+							"burst": {
+								Type:         schema.TypeInt,
+								Optional:     true,
+								Default:      1,
+								Description:  "Maximum number of requests sent at once to the product",
+								ValidateFunc: validation.IntAtLeast(1),
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// rateLimit is the token bucket configuration of a product
+type rateLimit struct {
+	RequestsPerSecond float64
+	Burst             int
+}
+
+type rateLimitedTransportOptions struct {
+	// Default is the rate limit applied to every product
+	Default rateLimit
+	// Products contains the rate limits overriding the default one, by product name
+	Products map[string]rateLimit
+}
+
+// expandProviderRateLimit returns the rate limit options of the provider rate_limit block, nil if there is none
+func expandProviderRateLimit(d *schema.ResourceData) *rateLimitedTransportOptions {
+	if d == nil {
+		return nil
+	}
+	if _, ok := d.GetOk("rate_limit.0"); !ok {
+		return nil
+	}
+
+	options := &rateLimitedTransportOptions{
+		Default: rateLimit{
+			RequestsPerSecond: d.Get("rate_limit.0.requests_per_second").(float64),
+			Burst:             d.Get("rate_limit.0.burst").(int),
+		},
+		Products: map[string]rateLimit{},
+	}
+	for _, rawProduct := range d.Get("rate_limit.0.product").([]interface{}) {
+		product := rawProduct.(map[string]interface{})
+		options.Products[product["name"].(string)] = rateLimit{
+			RequestsPerSecond: product["requests_per_second"].(float64),
+			Burst:             product["burst"].(int),
+		}
+	}
+
+	return options
+}
+
+// newRateLimiter creates the token buckets of the products, they are shared by the transports of every profile.
+func newRateLimiter(options rateLimitedTransportOptions) *rateLimiter {
+	return &rateLimiter{
+		options:  options,
+		limiters: map[string]*rate.Limiter{},
+	}
+}
+
+type rateLimiter struct {
+	options rateLimitedTransportOptions
+
+	limitersMu sync.Mutex
+	limiters   map[string]*rate.Limiter
+}
+
+// newRateLimitedTransport creates a http transport that smooths the requests sent to each product with the token buckets of limiter.
+func newRateLimitedTransport(defaultTransport http.RoundTripper, limiter *rateLimiter) http.RoundTripper {
+	return &rateLimitedTransport{
+		transport: defaultTransport,
+		limiter:   limiter,
+	}
+}
+
+type rateLimitedTransport struct {
+	transport http.RoundTripper
+	limiter   *rateLimiter
+}
+
+// requestProduct returns the product targeted by a request.
+// This is the first segment of the path for the Scaleway API (ex: instance for /instance/v1/zones/...) or the host otherwise.
+// The bucket name is removed from object storage hosts so all buckets of a region share the same limit.
+func requestProduct(r *http.Request) string {
+	host := r.URL.Hostname()
+	if host != scalewayAPIHost {
+		labels := strings.Split(host, ".")
+		for i, label := range labels {
+			if label == "s3" {
+				return strings.Join(labels[i:], ".")
+			}
+		}
+		return host
+	}
+
+	return apiPathProduct(r.URL.Path)
+}
+
+// apiPathProduct returns the product of a Scaleway API path, its first segment
+func apiPathProduct(path string) string {
+	product, _, _ := strings.Cut(strings.TrimPrefix(path, "/"), "/")
+
+	return product
+}
+
+// limiter returns the token bucket of a product, creating it on first use
+func (l *rateLimiter) limiter(product string) *rate.Limiter {
+	l.limitersMu.Lock()
+	defer l.limitersMu.Unlock()
+
+	if limiter, exists := l.limiters[product]; exists {
+		return limiter
+	}
+
+	limit, exists := l.options.Products[product]
+	if !exists {
+		limit = l.options.Default
+	}
+	limiter := rate.NewLimiter(rate.Limit(limit.RequestsPerSecond), limit.Burst)
+	l.limiters[product] = limiter
+
+	return limiter
+}
+
+// RoundTrip waits for the rate limit of the targeted product before sending the request.
+func (t *rateLimitedTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	if err := t.limiter.limiter(requestProduct(r)).Wait(r.Context()); err != nil {
+		return nil, err
+	}
+
+	return t.transport.RoundTrip(r)
+}
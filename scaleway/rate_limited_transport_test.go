@@ -0,0 +1,88 @@
+package scaleway
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRequestProduct(t *testing.T) {
+	testCases := []struct {
+		url      string
+		expected string
+	}{
+		{
+			url:      "https://api.scaleway.com/instance/v1/zones/fr-par-1/servers",
+			expected: "instance",
+		},
+		{
+			url:      "https://api.scaleway.com/domain/v2beta1/dns-zones/scaleway.com/records",
+			expected: "domain",
+		},
+		{
+			url:      "https://my-bucket.s3.fr-par.scw.cloud/?tagging=",
+			expected: "s3.fr-par.scw.cloud",
+		},
+		{
+			url:      "https://s3.nl-ams.scw.cloud/my-bucket",
+			expected: "s3.nl-ams.scw.cloud",
+		},
+		{
+			url:      "http://localhost:8080/instance/v1/zones/fr-par-1/servers",
+			expected: "localhost",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.url, func(t *testing.T) {
+			req, err := http.NewRequest(http.MethodGet, tc.url, nil)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, requestProduct(req))
+		})
+	}
+}
+
+func TestRateLimitedTransport(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	transport := newRateLimitedTransport(http.DefaultTransport, newRateLimiter(rateLimitedTransportOptions{
+		Default: rateLimit{
+			RequestsPerSecond: 1000,
+			Burst:             10,
+		},
+		Products: map[string]rateLimit{
+			"127.0.0.1": {
+				RequestsPerSecond: 20,
+				Burst:             1,
+			},
+		},
+	}))
+	client := &http.Client{Transport: transport}
+
+	start := time.Now()
+	for i := 0; i < 3; i++ {
+		req, err := http.NewRequest(http.MethodGet, server.URL, nil)
+		require.NoError(t, err)
+		resp, err := client.Do(req)
+		require.NoError(t, err)
+		resp.Body.Close()
+	}
+	// The first request consumes the burst, the two others wait 50ms each
+	assert.GreaterOrEqual(t, time.Since(start), 90*time.Millisecond)
+
+	// A cancelled request does not wait for the rate limit
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, server.URL, nil)
+	require.NoError(t, err)
+	_, err = client.Do(req) //nolint:bodyclose
+	require.Error(t, err)
+}
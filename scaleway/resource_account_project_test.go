@@ -46,8 +46,8 @@ func TestAccScalewayAccountProject_Basic(t *testing.T) {
 	tt := NewTestTools(t)
 	defer tt.Cleanup()
 	resource.ParallelTest(t, resource.TestCase{
-		ProviderFactories: tt.ProviderFactories,
-		CheckDestroy:      testAccCheckScalewayAccountProjectDestroy(tt),
+		ProtoV6ProviderFactories: tt.ProviderFactories,
+		CheckDestroy:             testAccCheckScalewayAccountProjectDestroy(tt),
 		Steps: []resource.TestStep{
 			{
 				Config: `
@@ -83,8 +83,8 @@ func TestAccScalewayAccountProject_NoUpdate(t *testing.T) {
 	tt := NewTestTools(t)
 	defer tt.Cleanup()
 	resource.ParallelTest(t, resource.TestCase{
-		ProviderFactories: tt.ProviderFactories,
-		CheckDestroy:      testAccCheckScalewayAccountProjectDestroy(tt),
+		ProtoV6ProviderFactories: tt.ProviderFactories,
+		CheckDestroy:             testAccCheckScalewayAccountProjectDestroy(tt),
 		Steps: []resource.TestStep{
 			{
 				Config: `
@@ -564,7 +564,7 @@ func resourceScalewayBaremetalServerUpdate(ctx context.Context, d *schema.Resour
 		hasChanged = true
 	}
 
-	if d.HasChange("tags") {
+	if d.HasChanges("tags", "tags_all") {
 		req.Tags = expandUpdatedStringsPtr(d.Get("tags"))
 		hasChanged = true
 	}
@@ -132,7 +132,7 @@ func resourceScalewayBlockSnapshotUpdate(ctx context.Context, d *schema.Resource
 		req.Name = expandUpdatedStringPtr(d.Get("name"))
 	}
 
-	if d.HasChange("tags") {
+	if d.HasChanges("tags", "tags_all") {
 		req.Tags = expandUpdatedStringsPtr(d.Get("tags"))
 	}
 
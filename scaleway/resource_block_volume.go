@@ -180,7 +180,7 @@ func resourceScalewayBlockVolumeUpdate(ctx context.Context, d *schema.ResourceDa
 		req.Size = &volumeSizeInBytes
 	}
 
-	if d.HasChange("tags") {
+	if d.HasChanges("tags", "tags_all") {
 		req.Tags = expandUpdatedStringsPtr(d.Get("tags"))
 	}
 
@@ -2,7 +2,9 @@ package scaleway
 
 import (
 	"context"
+	"fmt"
 
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	cockpit "github.com/scaleway/scaleway-sdk-go/api/cockpit/v1beta1"
@@ -145,7 +147,7 @@ func resourceScalewayCockpitTokenCreate(ctx context.Context, d *schema.ResourceD
 		}
 	}
 
-	l.Debugf("Creating token %+v", scopes)
+	tflog.Debug(ctx, fmt.Sprintf("creating token %+v", scopes))
 
 	res, err := api.CreateToken(&cockpit.CreateTokenRequest{
 		Name:      name,
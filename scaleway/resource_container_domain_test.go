@@ -17,9 +17,9 @@ func TestAccScalewayContainerDomain_Basic(t *testing.T) {
 	l.Debugf("TestAccScalewayContainerDomain_Basic: test dns zone: %s", testDNSZone)
 
 	resource.ParallelTest(t, resource.TestCase{
-		PreCheck:          func() { testAccPreCheck(t) },
-		ProviderFactories: tt.ProviderFactories,
-		CheckDestroy:      testAccCheckScalewayContainerDomainDestroy(tt),
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: tt.ProviderFactories,
+		CheckDestroy:             testAccCheckScalewayContainerDomainDestroy(tt),
 		Steps: []resource.TestStep{
 			{
 				Config: `
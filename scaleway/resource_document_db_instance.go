@@ -60,11 +60,27 @@ func resourceScalewayDocumentDBInstance() *schema.Resource {
 				Description: "Identifier for the first user of the database instance",
 			},
 			"password": {
-				Type:        schema.TypeString,
-				Sensitive:   true,
-				Optional:    true,
-				ForceNew:    true,
-				Description: "Password for the first user of the database instance",
+				Type:          schema.TypeString,
+				Sensitive:     true,
+				Optional:      true,
+				ForceNew:      true,
+				ConflictsWith: []string{"password_wo"},
+				Description:   "Password for the first user of the database instance",
+			},
+			"password_wo": {
+				Type:          schema.TypeString,
+				Sensitive:     true,
+				Optional:      true,
+				WriteOnly:     true,
+				ConflictsWith: []string{"password"},
+				Description:   "Password for the first user of the database instance, write-only and never stored in the state",
+			},
+			"password_wo_version": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ForceNew:     true,
+				RequiredWith: []string{"password_wo"},
+				Description:  "Version of the write-only password, change it to recreate the instance with the new password",
 			},
 			"volume_type": {
 				Type:     schema.TypeString,
@@ -107,6 +123,11 @@ func resourceScalewayDocumentDBInstanceCreate(ctx context.Context, d *schema.Res
 		return diag.FromErr(err)
 	}
 
+	password, diags := expandPassword(d)
+	if diags.HasError() {
+		return diags
+	}
+
 	createReq := &documentdb.CreateInstanceRequest{
 		Region:      region,
 		ProjectID:   expandStringPtr(d.Get("project_id")),
@@ -115,7 +136,7 @@ func resourceScalewayDocumentDBInstanceCreate(ctx context.Context, d *schema.Res
 		Engine:      d.Get("engine").(string),
 		IsHaCluster: d.Get("is_ha_cluster").(bool),
 		UserName:    d.Get("user_name").(string),
-		Password:    d.Get("password").(string),
+		Password:    password,
 		Tags:        expandStrings(d.Get("tags")),
 		VolumeType:  documentdb.VolumeType(d.Get("volume_type").(string)),
 	}
@@ -216,7 +237,7 @@ func resourceScalewayDocumentDBInstanceUpdate(ctx context.Context, d *schema.Res
 		req.Name = expandUpdatedStringPtr(d.Get("name"))
 	}
 
-	if d.HasChange("tags") {
+	if d.HasChanges("tags", "tags_all") {
 		req.Tags = expandUpdatedStringsPtr(d.Get("tags"))
 	}
 
@@ -13,8 +13,8 @@ func TestAccScalewayDocumentDBReadReplica_Basic(t *testing.T) {
 	tt := NewTestTools(t)
 	defer tt.Cleanup()
 	resource.ParallelTest(t, resource.TestCase{
-		PreCheck:          func() { testAccPreCheck(t) },
-		ProviderFactories: tt.ProviderFactories,
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: tt.ProviderFactories,
 		CheckDestroy: resource.ComposeTestCheckFunc(
 			testAccCheckScalewayDocumentDBInstanceDestroy(tt),
 			testAccCheckScalewayDocumentDBReadReplicaDestroy(tt),
@@ -51,8 +51,8 @@ func TestAccScalewayDocumentDBReadReplica_PrivateNetwork(t *testing.T) {
 	tt := NewTestTools(t)
 	defer tt.Cleanup()
 	resource.ParallelTest(t, resource.TestCase{
-		PreCheck:          func() { testAccPreCheck(t) },
-		ProviderFactories: tt.ProviderFactories,
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: tt.ProviderFactories,
 		CheckDestroy: resource.ComposeTestCheckFunc(
 			testAccCheckScalewayDocumentDBInstanceDestroy(tt),
 			testAccCheckScalewayDocumentDBReadReplicaDestroy(tt),
@@ -95,8 +95,8 @@ func TestAccScalewayDocumentDBReadReplica_Update(t *testing.T) {
 	tt := NewTestTools(t)
 	defer tt.Cleanup()
 	resource.ParallelTest(t, resource.TestCase{
-		PreCheck:          func() { testAccPreCheck(t) },
-		ProviderFactories: tt.ProviderFactories,
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: tt.ProviderFactories,
 		CheckDestroy: resource.ComposeTestCheckFunc(
 			testAccCheckScalewayDocumentDBInstanceDestroy(tt),
 			testAccCheckScalewayDocumentDBReadReplicaDestroy(tt),
@@ -165,8 +165,8 @@ func TestAccScalewayDocumentDBReadReplica_MultipleEndpoints(t *testing.T) {
 	tt := NewTestTools(t)
 	defer tt.Cleanup()
 	resource.ParallelTest(t, resource.TestCase{
-		PreCheck:          func() { testAccPreCheck(t) },
-		ProviderFactories: tt.ProviderFactories,
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: tt.ProviderFactories,
 		CheckDestroy: resource.ComposeTestCheckFunc(
 			testAccCheckScalewayDocumentDBInstanceDestroy(tt),
 			testAccCheckScalewayDocumentDBReadReplicaDestroy(tt),
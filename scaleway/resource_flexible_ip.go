@@ -180,7 +180,7 @@ func resourceScalewayFlexibleIPUpdate(ctx context.Context, d *schema.ResourceDat
 		hasChanged = true
 	}
 
-	if d.HasChange("tags") {
+	if d.HasChanges("tags", "tags_all") {
 		updateRequest.Tags = expandUpdatedStringsPtr(d.Get("tags"))
 		hasChanged = true
 	}
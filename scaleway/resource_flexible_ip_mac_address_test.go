@@ -13,8 +13,8 @@ func TestAccScalewayFlexibleIPMACAddress_Basic(t *testing.T) {
 	tt := NewTestTools(t)
 	defer tt.Cleanup()
 	resource.ParallelTest(t, resource.TestCase{
-		ProviderFactories: tt.ProviderFactories,
-		CheckDestroy:      testAccCheckScalewayFlexibleIPDestroy(tt),
+		ProtoV6ProviderFactories: tt.ProviderFactories,
+		CheckDestroy:             testAccCheckScalewayFlexibleIPDestroy(tt),
 		Steps: []resource.TestStep{
 			{
 				Config: `
@@ -41,8 +41,8 @@ func TestAccScalewayFlexibleIPMACAddress_MoveToAnotherFlexibleIP(t *testing.T) {
 	tt := NewTestTools(t)
 	defer tt.Cleanup()
 	resource.ParallelTest(t, resource.TestCase{
-		ProviderFactories: tt.ProviderFactories,
-		CheckDestroy:      testAccCheckScalewayFlexibleIPDestroy(tt),
+		ProtoV6ProviderFactories: tt.ProviderFactories,
+		CheckDestroy:             testAccCheckScalewayFlexibleIPDestroy(tt),
 		Steps: []resource.TestStep{
 			{
 				Config: `
@@ -96,7 +96,7 @@ func TestAccScalewayFlexibleIPMACAddress_DuplicateOnOtherFlexibleIPs(t *testing.
 	tt := NewTestTools(t)
 	defer tt.Cleanup()
 	resource.ParallelTest(t, resource.TestCase{
-		ProviderFactories: tt.ProviderFactories,
+		ProtoV6ProviderFactories: tt.ProviderFactories,
 		CheckDestroy: resource.ComposeTestCheckFunc(
 			testAccCheckScalewayFlexibleIPDestroy(tt),
 			testAccCheckScalewayBaremetalServerDestroy(tt),
@@ -49,8 +49,8 @@ func TestAccScalewayFlexibleIP_Basic(t *testing.T) {
 	tt := NewTestTools(t)
 	defer tt.Cleanup()
 	resource.ParallelTest(t, resource.TestCase{
-		ProviderFactories: tt.ProviderFactories,
-		CheckDestroy:      testAccCheckScalewayFlexibleIPDestroy(tt),
+		ProtoV6ProviderFactories: tt.ProviderFactories,
+		CheckDestroy:             testAccCheckScalewayFlexibleIPDestroy(tt),
 		Steps: []resource.TestStep{
 			{
 				Config: `
@@ -74,8 +74,8 @@ func TestAccScalewayFlexibleIP_WithZone(t *testing.T) {
 	tt := NewTestTools(t)
 	defer tt.Cleanup()
 	resource.ParallelTest(t, resource.TestCase{
-		ProviderFactories: tt.ProviderFactories,
-		CheckDestroy:      testAccCheckScalewayFlexibleIPDestroy(tt),
+		ProtoV6ProviderFactories: tt.ProviderFactories,
+		CheckDestroy:             testAccCheckScalewayFlexibleIPDestroy(tt),
 		Steps: []resource.TestStep{
 			{
 				Config: `
@@ -105,8 +105,8 @@ func TestAccScalewayFlexibleIP_IPv6(t *testing.T) {
 	tt := NewTestTools(t)
 	defer tt.Cleanup()
 	resource.ParallelTest(t, resource.TestCase{
-		ProviderFactories: tt.ProviderFactories,
-		CheckDestroy:      testAccCheckScalewayFlexibleIPDestroy(tt),
+		ProtoV6ProviderFactories: tt.ProviderFactories,
+		CheckDestroy:             testAccCheckScalewayFlexibleIPDestroy(tt),
 		Steps: []resource.TestStep{
 			{
 				Config: `
@@ -132,7 +132,7 @@ func TestAccScalewayFlexibleIP_CreateAndAttachToBaremetalServer(t *testing.T) {
 	name := "TestAccScalewayFlexibleIP_CreateAndAttachToBaremetalServer"
 
 	resource.ParallelTest(t, resource.TestCase{
-		ProviderFactories: tt.ProviderFactories,
+		ProtoV6ProviderFactories: tt.ProviderFactories,
 		CheckDestroy: resource.ComposeTestCheckFunc(
 			testAccCheckScalewayFlexibleIPDestroy(tt),
 			testAccCheckScalewayBaremetalServerDestroy(tt),
@@ -226,7 +226,7 @@ func TestAccScalewayFlexibleIP_AttachAndDetachFromBaremetalServer(t *testing.T)
 	SSHKeyName := "TestAccScalewayFlexibleIP_AttachAndDetachFromBaremetalServer"
 	name := "TestAccScalewayFlexibleIP_AttachAndDetachFromBaremetalServer"
 	resource.ParallelTest(t, resource.TestCase{
-		ProviderFactories: tt.ProviderFactories,
+		ProtoV6ProviderFactories: tt.ProviderFactories,
 		CheckDestroy: resource.ComposeTestCheckFunc(
 			testAccCheckScalewayFlexibleIPDestroy(tt),
 			testAccCheckScalewayBaremetalServerDestroy(tt),
@@ -53,7 +53,7 @@ func TestAccScalewayIamApiKey_WithApplication(t *testing.T) {
 	tt := NewTestTools(t)
 	defer tt.Cleanup()
 	resource.ParallelTest(t, resource.TestCase{
-		ProviderFactories: tt.ProviderFactories,
+		ProtoV6ProviderFactories: tt.ProviderFactories,
 		CheckDestroy: resource.ComposeTestCheckFunc(
 			testAccCheckScalewayIamAPIKeyDestroy(tt),
 			testAccCheckScalewayIamApplicationDestroy(tt),
@@ -109,7 +109,7 @@ func TestAccScalewayIamApiKey_WithApplicationChange(t *testing.T) {
 	tt := NewTestTools(t)
 	defer tt.Cleanup()
 	resource.ParallelTest(t, resource.TestCase{
-		ProviderFactories: tt.ProviderFactories,
+		ProtoV6ProviderFactories: tt.ProviderFactories,
 		CheckDestroy: resource.ComposeTestCheckFunc(
 			testAccCheckScalewayIamAPIKeyDestroy(tt),
 			testAccCheckScalewayIamApplicationDestroy(tt),
@@ -173,7 +173,7 @@ func TestAccScalewayIamApiKey_Expires(t *testing.T) {
 	tt := NewTestTools(t)
 	defer tt.Cleanup()
 	resource.ParallelTest(t, resource.TestCase{
-		ProviderFactories: tt.ProviderFactories,
+		ProtoV6ProviderFactories: tt.ProviderFactories,
 		CheckDestroy: resource.ComposeTestCheckFunc(
 			testAccCheckScalewayIamAPIKeyDestroy(tt),
 			testAccCheckScalewayIamApplicationDestroy(tt),
@@ -207,8 +207,8 @@ func TestAccScalewayIamApiKey_NoUpdate(t *testing.T) {
 	defer tt.Cleanup()
 
 	resource.ParallelTest(t, resource.TestCase{
-		ProviderFactories: tt.ProviderFactories,
-		CheckDestroy:      testAccCheckScalewayIamAPIKeyDestroy(tt),
+		ProtoV6ProviderFactories: tt.ProviderFactories,
+		CheckDestroy:             testAccCheckScalewayIamAPIKeyDestroy(tt),
 		Steps: []resource.TestStep{
 			{
 				Config: `
@@ -116,7 +116,7 @@ func resourceScalewayIamApplicationUpdate(ctx context.Context, d *schema.Resourc
 		req.Description = expandUpdatedStringPtr(d.Get("description"))
 		hasChanged = true
 	}
-	if d.HasChange("tags") {
+	if d.HasChanges("tags", "tags_all") {
 		req.Tags = expandUpdatedStringsPtr(d.Get("tags"))
 		hasChanged = true
 	}
@@ -52,8 +52,8 @@ func TestAccScalewayIamApplication_Basic(t *testing.T) {
 	tt := NewTestTools(t)
 	defer tt.Cleanup()
 	resource.ParallelTest(t, resource.TestCase{
-		ProviderFactories: tt.ProviderFactories,
-		CheckDestroy:      testAccCheckScalewayIamApplicationDestroy(tt),
+		ProtoV6ProviderFactories: tt.ProviderFactories,
+		CheckDestroy:             testAccCheckScalewayIamApplicationDestroy(tt),
 		Steps: []resource.TestStep{
 			{
 				Config: `
@@ -96,8 +96,8 @@ func TestAccScalewayIamApplication_NoUpdate(t *testing.T) {
 	defer tt.Cleanup()
 
 	resource.ParallelTest(t, resource.TestCase{
-		ProviderFactories: tt.ProviderFactories,
-		CheckDestroy:      testAccCheckScalewayIamApplicationDestroy(tt),
+		ProtoV6ProviderFactories: tt.ProviderFactories,
+		CheckDestroy:             testAccCheckScalewayIamApplicationDestroy(tt),
 		Steps: []resource.TestStep{
 			{
 				Config: `
@@ -147,7 +147,7 @@ func resourceScalewayIamGroupUpdate(ctx context.Context, d *schema.ResourceData,
 		return diag.FromErr(err)
 	}
 
-	if d.HasChanges("name", "description", "tags") {
+	if d.HasChanges("name", "description", "tags", "tags_all") {
 		_, err = api.UpdateGroup(&iam.UpdateGroupRequest{
 			GroupID:     group.ID,
 			Name:        expandUpdatedStringPtr(d.Get("name")),
@@ -203,7 +203,7 @@ func resourceScalewayIamPolicyUpdate(ctx context.Context, d *schema.ResourceData
 		hasUpdated = true
 		req.Description = expandUpdatedStringPtr(d.Get("description"))
 	}
-	if d.HasChange("tags") {
+	if d.HasChanges("tags", "tags_all") {
 		hasUpdated = true
 		req.Tags = expandUpdatedStringsPtr(d.Get("tags"))
 	}
@@ -37,9 +37,34 @@ func resourceScalewayInstanceImage() *schema.Resource {
 			},
 			"root_volume_id": {
 				Type:         schema.TypeString,
-				Required:     true,
+				Optional:     true,
+				Computed:     true,
 				Description:  "UUID of the snapshot from which the image is to be created",
 				ValidateFunc: validationUUIDorUUIDWithLocality(),
+				ExactlyOneOf: []string{"root_volume_id", "import"},
+			},
+			"import": {
+				Type:     schema.TypeList,
+				ForceNew: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"bucket": {
+							Type:        schema.TypeString,
+							Required:    true,
+							ForceNew:    true,
+							Description: "Bucket containing qcow",
+						},
+						"key": {
+							Type:        schema.TypeString,
+							Required:    true,
+							ForceNew:    true,
+							Description: "Key of the qcow file in the specified bucket",
+						},
+					},
+				},
+				Optional:    true,
+				Description: "Import the root volume of the image from a qcow, the snapshot is managed with the image",
 			},
 			"architecture": {
 				Type:        schema.TypeString,
@@ -178,10 +203,19 @@ func resourceScalewayInstanceImageCreate(ctx context.Context, d *schema.Resource
 		return diag.FromErr(err)
 	}
 
+	name := expandOrGenerateString(d.Get("name"), "image")
+	rootVolumeID := expandZonedID(d.Get("root_volume_id").(string)).ID
+	if _, isImported := d.GetOk("import"); isImported {
+		rootVolumeID, err = importInstanceImageRootVolume(ctx, d, instanceAPI, zone, name)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
 	req := &instance.CreateImageRequest{
 		Zone:       zone,
-		Name:       expandOrGenerateString(d.Get("name"), "image"),
-		RootVolume: expandZonedID(d.Get("root_volume_id").(string)).ID,
+		Name:       name,
+		RootVolume: rootVolumeID,
 		Arch:       instance.Arch(d.Get("architecture").(string)),
 		Project:    expandStringPtr(d.Get("project_id")),
 		Public:     expandBoolPtr(d.Get("public")),
@@ -205,6 +239,13 @@ func resourceScalewayInstanceImageCreate(ctx context.Context, d *schema.Resource
 
 	res, err := instanceAPI.CreateImage(req, scw.WithContext(ctx))
 	if err != nil {
+		if _, isImported := d.GetOk("import"); isImported {
+			// The imported snapshot would not be managed by any resource
+			_ = instanceAPI.DeleteSnapshot(&instance.DeleteSnapshotRequest{
+				Zone:       zone,
+				SnapshotID: rootVolumeID,
+			}, scw.WithContext(ctx))
+		}
 		return diag.FromErr(err)
 	}
 
@@ -358,5 +399,47 @@ func resourceScalewayInstanceImageDelete(ctx context.Context, d *schema.Resource
 		}
 	}
 
+	// The imported snapshot is only used by the image
+	if _, isImported := d.GetOk("import"); isImported {
+		snapshotID := expandZonedID(d.Get("root_volume_id").(string)).ID
+		_, err = waitForInstanceSnapshot(ctx, instanceAPI, zone, snapshotID, d.Timeout(schema.TimeoutDelete))
+		if err == nil {
+			err = instanceAPI.DeleteSnapshot(&instance.DeleteSnapshotRequest{
+				Zone:       zone,
+				SnapshotID: snapshotID,
+			}, scw.WithContext(ctx))
+		}
+		if err != nil && !is404Error(err) {
+			return diag.FromErr(err)
+		}
+	}
+
 	return nil
 }
+
+// importInstanceImageRootVolume imports the qcow file of the import block as a snapshot, it returns the ID of the snapshot once imported
+func importInstanceImageRootVolume(ctx context.Context, d *schema.ResourceData, instanceAPI *instance.API, zone scw.Zone, name string) (string, error) {
+	res, err := instanceAPI.CreateSnapshot(&instance.CreateSnapshotRequest{
+		Zone:       zone,
+		Name:       name,
+		Project:    expandStringPtr(d.Get("project_id")),
+		VolumeType: instance.SnapshotVolumeTypeUnified,
+		Bucket:     expandStringPtr(d.Get("import.0.bucket")),
+		Key:        expandStringPtr(d.Get("import.0.key")),
+	}, scw.WithContext(ctx))
+	if err != nil {
+		return "", fmt.Errorf("couldn't import root volume: %w", err)
+	}
+
+	_, err = waitForInstanceSnapshot(ctx, instanceAPI, zone, res.Snapshot.ID, d.Timeout(schema.TimeoutCreate))
+	if err != nil {
+		// The imported snapshot would not be managed by any resource
+		_ = instanceAPI.DeleteSnapshot(&instance.DeleteSnapshotRequest{
+			Zone:       zone,
+			SnapshotID: res.Snapshot.ID,
+		}, scw.WithContext(ctx))
+		return "", err
+	}
+
+	return res.Snapshot.ID, nil
+}
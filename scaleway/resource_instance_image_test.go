@@ -49,8 +49,8 @@ func TestAccScalewayInstanceImage_BlockVolume(t *testing.T) {
 	tt := NewTestTools(t)
 	defer tt.Cleanup()
 	resource.ParallelTest(t, resource.TestCase{
-		PreCheck:          func() { testAccPreCheck(t) },
-		ProviderFactories: tt.ProviderFactories,
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: tt.ProviderFactories,
 		CheckDestroy: resource.ComposeTestCheckFunc(
 			testAccCheckScalewayInstanceImageDestroy(tt),
 			testAccCheckScalewayInstanceSnapshotDestroy(tt),
@@ -145,8 +145,8 @@ func TestAccScalewayInstanceImage_Server(t *testing.T) {
 	tt := NewTestTools(t)
 	defer tt.Cleanup()
 	resource.ParallelTest(t, resource.TestCase{
-		PreCheck:          func() { testAccPreCheck(t) },
-		ProviderFactories: tt.ProviderFactories,
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: tt.ProviderFactories,
 		CheckDestroy: resource.ComposeTestCheckFunc(
 			testAccCheckScalewayInstanceImageDestroy(tt),
 			testAccCheckScalewayInstanceSnapshotDestroy(tt),
@@ -219,8 +219,8 @@ func TestAccScalewayInstanceImage_ServerWithBlockVolume(t *testing.T) {
 	tt := NewTestTools(t)
 	defer tt.Cleanup()
 	resource.ParallelTest(t, resource.TestCase{
-		PreCheck:          func() { testAccPreCheck(t) },
-		ProviderFactories: tt.ProviderFactories,
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: tt.ProviderFactories,
 		CheckDestroy: resource.ComposeTestCheckFunc(
 			testAccCheckScalewayInstanceImageDestroy(tt),
 			testAccCheckScalewayInstanceSnapshotDestroy(tt),
@@ -362,8 +362,8 @@ func TestAccScalewayInstanceImage_ServerWithLocalVolume(t *testing.T) {
 	tt := NewTestTools(t)
 	defer tt.Cleanup()
 	resource.ParallelTest(t, resource.TestCase{
-		PreCheck:          func() { testAccPreCheck(t) },
-		ProviderFactories: tt.ProviderFactories,
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: tt.ProviderFactories,
 		CheckDestroy: resource.ComposeTestCheckFunc(
 			testAccCheckScalewayInstanceImageDestroy(tt),
 			testAccCheckScalewayInstanceSnapshotDestroy(tt),
@@ -479,6 +479,33 @@ func TestAccScalewayInstanceImage_ServerWithLocalVolume(t *testing.T) {
 	})
 }
 
+func TestAccScalewayInstanceImage_Import(t *testing.T) {
+	tt := NewTestToolsWithFakeAPI(t)
+	defer tt.Cleanup()
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: tt.ProviderFactories,
+		CheckDestroy:             testAccCheckScalewayInstanceImageDestroy(tt),
+		Steps: []resource.TestStep{
+			{
+				Config: `
+					resource "scaleway_instance_image" "main" {
+						name = "imported-image"
+						import {
+							bucket = "images"
+							key    = "server.qcow2"
+						}
+					}`,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckScalewayInstanceImageExists(tt, "scaleway_instance_image.main"),
+					resource.TestCheckResourceAttr("scaleway_instance_image.main", "name", "imported-image"),
+					resource.TestCheckResourceAttr("scaleway_instance_image.main", "state", "available"),
+					resource.TestCheckResourceAttrSet("scaleway_instance_image.main", "root_volume_id"),
+				),
+			},
+		},
+	})
+}
+
 func testAccCheckScalewayInstanceImageExists(tt *TestTools, n string) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		rs, ok := s.RootModule().Resources[n]
@@ -12,9 +12,9 @@ func TestAccScalewayInstanceIPReverseDns_Basic(t *testing.T) {
 	defer tt.Cleanup()
 	testDNSZone := fmt.Sprintf("tf-reverse-instance.%s", testDomain)
 	resource.ParallelTest(t, resource.TestCase{
-		PreCheck:          func() { testAccPreCheck(t) },
-		ProviderFactories: tt.ProviderFactories,
-		CheckDestroy:      testAccCheckScalewayInstanceIPDestroy(tt),
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: tt.ProviderFactories,
+		CheckDestroy:             testAccCheckScalewayInstanceIPDestroy(tt),
 		Steps: []resource.TestStep{
 			{
 				Config: fmt.Sprintf(`
@@ -46,8 +46,8 @@ func TestAccScalewayInstanceIP_Basic(t *testing.T) {
 	tt := NewTestTools(t)
 	defer tt.Cleanup()
 	resource.ParallelTest(t, resource.TestCase{
-		ProviderFactories: tt.ProviderFactories,
-		CheckDestroy:      testAccCheckScalewayInstanceIPDestroy(tt),
+		ProtoV6ProviderFactories: tt.ProviderFactories,
+		CheckDestroy:             testAccCheckScalewayInstanceIPDestroy(tt),
 		Steps: []resource.TestStep{
 			{
 				Config: `
@@ -67,8 +67,8 @@ func TestAccScalewayInstanceIP_WithZone(t *testing.T) {
 	tt := NewTestTools(t)
 	defer tt.Cleanup()
 	resource.ParallelTest(t, resource.TestCase{
-		ProviderFactories: tt.ProviderFactories,
-		CheckDestroy:      testAccCheckScalewayInstanceIPDestroy(tt),
+		ProtoV6ProviderFactories: tt.ProviderFactories,
+		CheckDestroy:             testAccCheckScalewayInstanceIPDestroy(tt),
 		Steps: []resource.TestStep{
 			{
 				Config: `
@@ -98,8 +98,8 @@ func TestAccScalewayInstanceIP_Tags(t *testing.T) {
 	tt := NewTestTools(t)
 	defer tt.Cleanup()
 	resource.ParallelTest(t, resource.TestCase{
-		ProviderFactories: tt.ProviderFactories,
-		CheckDestroy:      testAccCheckScalewayInstanceIPDestroy(tt),
+		ProtoV6ProviderFactories: tt.ProviderFactories,
+		CheckDestroy:             testAccCheckScalewayInstanceIPDestroy(tt),
 		Steps: []resource.TestStep{
 			{
 				Config: `
@@ -131,8 +131,8 @@ func TestAccScalewayInstanceIP_RoutedMigrate(t *testing.T) {
 	defer tt.Cleanup()
 
 	resource.ParallelTest(t, resource.TestCase{
-		ProviderFactories: tt.ProviderFactories,
-		CheckDestroy:      testAccCheckScalewayInstanceIPDestroy(tt),
+		ProtoV6ProviderFactories: tt.ProviderFactories,
+		CheckDestroy:             testAccCheckScalewayInstanceIPDestroy(tt),
 		Steps: []resource.TestStep{
 			{
 				Config: `
@@ -201,8 +201,8 @@ func TestAccScalewayInstanceIP_RoutedDowngrade(t *testing.T) {
 	defer tt.Cleanup()
 
 	resource.ParallelTest(t, resource.TestCase{
-		ProviderFactories: tt.ProviderFactories,
-		CheckDestroy:      testAccCheckScalewayInstanceIPDestroy(tt),
+		ProtoV6ProviderFactories: tt.ProviderFactories,
+		CheckDestroy:             testAccCheckScalewayInstanceIPDestroy(tt),
 		Steps: []resource.TestStep{
 			{
 				Config: `
@@ -239,8 +239,8 @@ func TestAccScalewayInstanceIP_RoutedIPV6(t *testing.T) {
 	defer tt.Cleanup()
 
 	resource.ParallelTest(t, resource.TestCase{
-		ProviderFactories: tt.ProviderFactories,
-		CheckDestroy:      testAccCheckScalewayInstanceIPDestroy(tt),
+		ProtoV6ProviderFactories: tt.ProviderFactories,
+		CheckDestroy:             testAccCheckScalewayInstanceIPDestroy(tt),
 		Steps: []resource.TestStep{
 			{
 				Config: `
@@ -152,7 +152,7 @@ func resourceScalewayInstancePlacementGroupUpdate(ctx context.Context, d *schema
 		hasChanged = true
 	}
 
-	if d.HasChange("tags") {
+	if d.HasChanges("tags", "tags_all") {
 		req.Tags = expandUpdatedStringsPtr(d.Get("tags"))
 		hasChanged = true
 	}
@@ -157,7 +157,7 @@ func resourceScalewayInstancePrivateNICUpdate(ctx context.Context, d *schema.Res
 		return diag.FromErr(err)
 	}
 
-	if d.HasChange("tags") {
+	if d.HasChanges("tags", "tags_all") {
 		_, err := instanceAPI.UpdatePrivateNIC(
 			&instance.UpdatePrivateNICRequest{
 				Zone:         zone,
@@ -0,0 +1,274 @@
+package scaleway
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/scaleway/scaleway-sdk-go/api/instance/v1"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+)
+
+func resourceScalewayInstanceSecurityGroupRule() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceScalewayInstanceSecurityGroupRuleCreate,
+		ReadContext:   resourceScalewayInstanceSecurityGroupRuleRead,
+		UpdateContext: resourceScalewayInstanceSecurityGroupRuleUpdate,
+		DeleteContext: resourceScalewayInstanceSecurityGroupRuleDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Default: schema.DefaultTimeout(defaultInstanceSecurityGroupRuleTimeout),
+		},
+		SchemaVersion: 0,
+		Schema: map[string]*schema.Schema{
+			"security_group_id": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				Description:  "The security group of the rule",
+				ValidateFunc: validationUUIDWithLocality(),
+			},
+			"direction": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Direction of the traffic matched by the rule (inbound or outbound)",
+				ValidateFunc: validation.StringInSlice([]string{
+					instance.SecurityGroupRuleDirectionInbound.String(),
+					instance.SecurityGroupRuleDirectionOutbound.String(),
+				}, false),
+			},
+			"action": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Action when rule match request (drop or accept)",
+				ValidateFunc: validation.StringInSlice([]string{
+					instance.SecurityGroupRuleActionAccept.String(),
+					instance.SecurityGroupRuleActionDrop.String(),
+				}, false),
+			},
+			"protocol": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Default:     instance.SecurityGroupRuleProtocolTCP.String(),
+				Description: "Protocol for this rule (TCP, UDP, ICMP or ANY)",
+				ValidateFunc: validation.StringInSlice([]string{
+					instance.SecurityGroupRuleProtocolICMP.String(),
+					instance.SecurityGroupRuleProtocolTCP.String(),
+					instance.SecurityGroupRuleProtocolUDP.String(),
+					instance.SecurityGroupRuleProtocolANY.String(),
+				}, false),
+			},
+			"port": {
+				Type:          schema.TypeInt,
+				Optional:      true,
+				ForceNew:      true,
+				Description:   "Network port for this rule",
+				ValidateFunc:  validation.IsPortNumber,
+				ConflictsWith: []string{"port_range"},
+			},
+			"port_range": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ForceNew:      true,
+				Computed:      true,
+				Description:   "Port range for this rule (e.g: 1-1024, 22-22)",
+				ConflictsWith: []string{"port"},
+			},
+			"ip_range": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				Default:      "0.0.0.0/0",
+				ValidateFunc: validation.IsCIDRNetwork(0, 128),
+				Description:  "Ip range for this rule (e.g: 192.168.1.0/24)",
+			},
+			"position": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Computed:     true,
+				Description:  "Position of the rule in the security group, starting at 1. The rule is added last if not set",
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+			"zone": zoneSchema(),
+		},
+		CustomizeDiff: customizeDiffLocalityCheck("security_group_id"),
+	}
+}
+
+func resourceScalewayInstanceSecurityGroupRuleCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	instanceAPI, zone, err := instanceAPIWithZone(d, meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	securityGroupID := expandID(d.Get("security_group_id"))
+	rule, err := expandInstanceSecurityGroupRule(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	// An identical rule is most likely managed by another resource, which would remove this one on its next update
+	resRules, err := instanceAPI.ListSecurityGroupRules(&instance.ListSecurityGroupRulesRequest{
+		Zone:            zone,
+		SecurityGroupID: securityGroupID,
+	}, scw.WithAllPages(), scw.WithContext(ctx))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	for _, apiRule := range resRules.Rules {
+		if equal, _ := securityGroupRuleEquals(rule, apiRule); equal && apiRule.Editable && apiRule.Direction == rule.Direction {
+			return diag.Errorf("security group %s already has an identical %s rule (%s): import it, or make sure its rules are not managed by scaleway_instance_security_group_rules or by scaleway_instance_security_group without external_rules",
+				securityGroupID, rule.Direction, newZonedNestedIDString(zone, securityGroupID, apiRule.ID))
+		}
+	}
+
+	res, err := instanceAPI.CreateSecurityGroupRule(&instance.CreateSecurityGroupRuleRequest{
+		Zone:            zone,
+		SecurityGroupID: securityGroupID,
+		Protocol:        rule.Protocol,
+		Direction:       rule.Direction,
+		Action:          rule.Action,
+		IPRange:         rule.IPRange,
+		DestPortFrom:    rule.DestPortFrom,
+		DestPortTo:      rule.DestPortTo,
+		Position:        uint32(d.Get("position").(int)),
+		Editable:        true,
+	}, scw.WithContext(ctx))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(newZonedNestedIDString(zone, securityGroupID, res.Rule.ID))
+
+	return resourceScalewayInstanceSecurityGroupRuleRead(ctx, d, meta)
+}
+
+func resourceScalewayInstanceSecurityGroupRuleRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	instanceAPI, zone, ruleID, securityGroupID, err := instanceAPIWithZoneAndNestedID(meta, d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	res, err := instanceAPI.GetSecurityGroupRule(&instance.GetSecurityGroupRuleRequest{
+		Zone:                zone,
+		SecurityGroupID:     securityGroupID,
+		SecurityGroupRuleID: ruleID,
+	}, scw.WithContext(ctx))
+	if err != nil {
+		if !is404Error(err) {
+			return diag.FromErr(err)
+		}
+		d.SetId("")
+
+		// The rules of the security group may have all been replaced by another resource
+		_, err = instanceAPI.GetSecurityGroup(&instance.GetSecurityGroupRequest{
+			Zone:            zone,
+			SecurityGroupID: securityGroupID,
+		}, scw.WithContext(ctx))
+		if err != nil {
+			if is404Error(err) {
+				return nil
+			}
+			return diag.FromErr(err)
+		}
+		return diag.Diagnostics{{
+			Severity: diag.Warning,
+			Summary:  fmt.Sprintf("Security group rule %s was removed outside of this resource", ruleID),
+			Detail: fmt.Sprintf("The rule will be created again. If this happens on every apply, the rules of security group %s are also managed by scaleway_instance_security_group_rules "+
+				"or by scaleway_instance_security_group without external_rules, which replace all of its rules.", securityGroupID),
+		}}
+	}
+
+	rawRule, err := securityGroupRuleFlatten(res.Rule)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	_ = d.Set("security_group_id", newZonedIDString(zone, securityGroupID))
+	_ = d.Set("direction", res.Rule.Direction.String())
+	_ = d.Set("action", rawRule["action"])
+	_ = d.Set("protocol", rawRule["protocol"])
+	_ = d.Set("ip_range", rawRule["ip_range"])
+	_ = d.Set("port_range", rawRule["port_range"])
+	// A single port has no end port
+	if res.Rule.DestPortFrom != nil && (res.Rule.DestPortTo == nil || *res.Rule.DestPortTo == *res.Rule.DestPortFrom) {
+		_ = d.Set("port_range", fmt.Sprintf("%d-%d", *res.Rule.DestPortFrom, *res.Rule.DestPortFrom))
+		if d.Get("port").(int) != 0 {
+			_ = d.Set("port", int(*res.Rule.DestPortFrom))
+		}
+	}
+	_ = d.Set("position", int(res.Rule.Position))
+	_ = d.Set("zone", zone.String())
+
+	return nil
+}
+
+func resourceScalewayInstanceSecurityGroupRuleUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	instanceAPI, zone, ruleID, securityGroupID, err := instanceAPIWithZoneAndNestedID(meta, d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	// Only the position can be updated, the rule is replaced otherwise
+	if d.HasChange("position") {
+		_, err = instanceAPI.UpdateSecurityGroupRule(&instance.UpdateSecurityGroupRuleRequest{
+			Zone:                zone,
+			SecurityGroupID:     securityGroupID,
+			SecurityGroupRuleID: ruleID,
+			Position:            scw.Uint32Ptr(uint32(d.Get("position").(int))),
+		}, scw.WithContext(ctx))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return resourceScalewayInstanceSecurityGroupRuleRead(ctx, d, meta)
+}
+
+func resourceScalewayInstanceSecurityGroupRuleDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	instanceAPI, zone, ruleID, securityGroupID, err := instanceAPIWithZoneAndNestedID(meta, d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	err = instanceAPI.DeleteSecurityGroupRule(&instance.DeleteSecurityGroupRuleRequest{
+		Zone:                zone,
+		SecurityGroupID:     securityGroupID,
+		SecurityGroupRuleID: ruleID,
+	}, scw.WithContext(ctx))
+	if err != nil && !is404Error(err) {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+// expandInstanceSecurityGroupRule transforms the state of the resource to an api rule, the port takes precedence over the port range.
+func expandInstanceSecurityGroupRule(d *schema.ResourceData) (*instance.SecurityGroupRule, error) {
+	portRange := d.Get("port_range").(string)
+	if d.Get("port").(int) != 0 {
+		portRange = ""
+	}
+
+	rule, err := securityGroupRuleExpand(map[string]interface{}{
+		"action":     d.Get("action"),
+		"protocol":   d.Get("protocol"),
+		"port":       d.Get("port"),
+		"port_range": portRange,
+		"ip":         "",
+		"ip_range":   d.Get("ip_range"),
+	})
+	if err != nil {
+		return nil, err
+	}
+	rule.Direction = instance.SecurityGroupRuleDirection(d.Get("direction").(string))
+
+	return rule, nil
+}
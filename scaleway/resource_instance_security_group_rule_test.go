@@ -0,0 +1,117 @@
+package scaleway
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccScalewayInstanceSecurityGroupRule_Basic(t *testing.T) {
+	tt := NewTestToolsWithFakeAPI(t)
+	defer tt.Cleanup()
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: tt.ProviderFactories,
+		CheckDestroy:             testAccCheckScalewayInstanceSecurityGroupDestroy(tt),
+		Steps: []resource.TestStep{
+			{
+				Config: `
+					resource "scaleway_instance_security_group" "main" {
+						inbound_default_policy = "drop"
+						external_rules         = true
+					}
+
+					resource "scaleway_instance_security_group_rule" "ssh" {
+						security_group_id = scaleway_instance_security_group.main.id
+						direction         = "inbound"
+						action            = "accept"
+						port              = 22
+						ip_range          = "10.0.0.0/8"
+					}
+
+					resource "scaleway_instance_security_group_rule" "http" {
+						security_group_id = scaleway_instance_security_group_rule.ssh.security_group_id
+						direction         = "inbound"
+						action            = "accept"
+						port_range        = "80-90"
+						position          = 1
+					}
+				`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("scaleway_instance_security_group_rule.ssh", "port", "22"),
+					resource.TestCheckResourceAttr("scaleway_instance_security_group_rule.ssh", "port_range", "22-22"),
+					resource.TestCheckResourceAttr("scaleway_instance_security_group_rule.ssh", "ip_range", "10.0.0.0/8"),
+					resource.TestCheckResourceAttr("scaleway_instance_security_group_rule.ssh", "protocol", "TCP"),
+					resource.TestCheckResourceAttr("scaleway_instance_security_group_rule.http", "port_range", "80-90"),
+					resource.TestCheckResourceAttr("scaleway_instance_security_group_rule.http", "position", "1"),
+				),
+			},
+			{
+				ResourceName:            "scaleway_instance_security_group_rule.ssh",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"port"},
+			},
+			{
+				Config: `
+					resource "scaleway_instance_security_group" "main" {
+						inbound_default_policy = "drop"
+						external_rules         = true
+					}
+
+					resource "scaleway_instance_security_group_rule" "ssh" {
+						security_group_id = scaleway_instance_security_group.main.id
+						direction         = "inbound"
+						action            = "accept"
+						port              = 22
+						ip_range          = "10.0.0.0/8"
+						position          = 1
+					}
+
+					resource "scaleway_instance_security_group_rule" "http" {
+						security_group_id = scaleway_instance_security_group_rule.ssh.security_group_id
+						direction         = "inbound"
+						action            = "accept"
+						port_range        = "80-90"
+					}
+				`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("scaleway_instance_security_group_rule.ssh", "position", "1"),
+				),
+			},
+			{
+				Config: `
+					resource "scaleway_instance_security_group" "main" {
+						inbound_default_policy = "drop"
+						external_rules         = true
+					}
+
+					resource "scaleway_instance_security_group_rule" "ssh" {
+						security_group_id = scaleway_instance_security_group.main.id
+						direction         = "inbound"
+						action            = "accept"
+						port              = 22
+						ip_range          = "10.0.0.0/8"
+						position          = 1
+					}
+
+					resource "scaleway_instance_security_group_rule" "http" {
+						security_group_id = scaleway_instance_security_group_rule.ssh.security_group_id
+						direction         = "inbound"
+						action            = "accept"
+						port_range        = "80-90"
+					}
+
+					resource "scaleway_instance_security_group_rule" "duplicate" {
+						security_group_id = scaleway_instance_security_group_rule.http.security_group_id
+						direction         = "inbound"
+						action            = "accept"
+						port              = 22
+						ip_range          = "10.0.0.0/8"
+					}
+				`,
+				ExpectError: regexp.MustCompile("already has an identical inbound rule"),
+			},
+		},
+	})
+}
@@ -5,7 +5,6 @@ import (
 	"context"
 	"fmt"
 	"io"
-	"log"
 	"strconv"
 
 	"github.com/google/go-cmp/cmp"
@@ -15,6 +14,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	block "github.com/scaleway/scaleway-sdk-go/api/block/v1alpha1"
 	"github.com/scaleway/scaleway-sdk-go/api/instance/v1"
 	"github.com/scaleway/scaleway-sdk-go/api/marketplace/v2"
 	"github.com/scaleway/scaleway-sdk-go/scw"
@@ -107,18 +107,17 @@ func resourceScalewayInstanceServer() *schema.Resource {
 							Type:        schema.TypeInt,
 							Optional:    true,
 							Computed:    true,
-							ForceNew:    true,
-							Description: "Size of the root volume in gigabytes",
+							Description: "Size of the root volume in gigabytes, network volumes are grown in place",
 						},
 						"volume_type": {
 							Type:        schema.TypeString,
 							Optional:    true,
 							Computed:    true,
-							ForceNew:    true,
-							Description: "Volume type of the root volume",
+							Description: "Volume type of the root volume, l_ssd volumes are moved in place to b_ssd or sbs_volume and b_ssd volumes to sbs_volume",
 							ValidateFunc: validation.StringInSlice([]string{
 								instance.VolumeVolumeTypeBSSD.String(),
 								instance.VolumeVolumeTypeLSSD.String(),
+								blockVolumeType.String(),
 							}, false),
 						},
 						"delete_on_termination": {
@@ -322,6 +321,7 @@ func resourceScalewayInstanceServer() *schema.Resource {
 			),
 			customDiffInstanceServerType,
 			customDiffInstanceServerImage,
+			customDiffInstanceRootVolume,
 		),
 	}
 }
@@ -569,7 +569,7 @@ func resourceScalewayInstanceServerRead(ctx context.Context, d *schema.ResourceD
 	server, err := waitForInstanceServer(ctx, instanceAPI, zone, id, d.Timeout(schema.TimeoutRead))
 	if err != nil {
 		if errorCheck(err, "is not found") {
-			log.Printf("[WARN] instance %s not found droping from state", d.Id())
+			tflog.Warn(ctx, fmt.Sprintf("instance %s not found dropping from state", d.Id()))
 			d.SetId("")
 			return nil
 		}
@@ -761,7 +761,7 @@ func resourceScalewayInstanceServerUpdate(ctx context.Context, d *schema.Resourc
 		updateRequest.Name = expandStringPtr(d.Get("name"))
 	}
 
-	if d.HasChange("tags") {
+	if d.HasChanges("tags", "tags_all") {
 		serverShouldUpdate = true
 		updateRequest.Tags = expandUpdatedStringsPtr(d.Get("tags"))
 	}
@@ -784,14 +784,25 @@ func resourceScalewayInstanceServerUpdate(ctx context.Context, d *schema.Resourc
 		updateRequest.DynamicIPRequired = scw.BoolPtr(d.Get("enable_dynamic_ip").(bool))
 	}
 
+	if d.HasChanges("root_volume.0.size_in_gb", "root_volume.0.volume_type") {
+		err := resourceScalewayInstanceServerUpdateRootVolume(ctx, d, api, zone, id)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
 	volumes := map[string]*instance.VolumeServerTemplate{}
 
-	if raw, hasAdditionalVolumes := d.GetOk("additional_volume_ids"); d.HasChanges("additional_volume_ids", "root_volume") {
+	if raw, hasAdditionalVolumes := d.GetOk("additional_volume_ids"); d.HasChanges("additional_volume_ids", "root_volume.0.volume_id", "root_volume.0.boot") {
 		volumes["0"] = &instance.VolumeServerTemplate{
 			ID:   scw.StringPtr(expandZonedID(d.Get("root_volume.0.volume_id")).ID),
 			Name: scw.StringPtr(newRandomName("vol")), // name is ignored by the API, any name will work here
 			Boot: expandBoolPtr(d.Get("root_volume.0.boot")),
 		}
+		if rootVolumeType := d.Get("root_volume.0.volume_type").(string); rootVolumeType == blockVolumeType.String() {
+			// Volumes from the block API must be passed with their type
+			volumes["0"].VolumeType = instance.VolumeVolumeType(rootVolumeType)
+		}
 
 		if !hasAdditionalVolumes {
 			raw = []interface{}{} // Set an empty list if not volumes exist
@@ -1060,7 +1071,7 @@ func resourceScalewayInstanceServerDelete(ctx context.Context, d *schema.Resourc
 			Server: &instance.NullableStringValue{Null: true},
 		})
 		if err != nil {
-			log.Print("[WARN] Failed to detach eip of server")
+			tflog.Warn(ctx, "failed to detach eip of server")
 		}
 	}
 	// Remove instance from placement group to free it even if instance won't stop
@@ -1071,7 +1082,7 @@ func resourceScalewayInstanceServerDelete(ctx context.Context, d *schema.Resourc
 			ServerID:       id,
 		})
 		if err != nil {
-			log.Print("[WARN] Failed remove server from instance group")
+			tflog.Warn(ctx, "failed to remove server from placement group")
 		}
 	}
 	// reach stopped state
@@ -1125,10 +1136,21 @@ func resourceScalewayInstanceServerDelete(ctx context.Context, d *schema.Resourc
 		if !volumeExist {
 			return diag.Errorf("volume ID not found")
 		}
-		err = api.DeleteVolume(&instance.DeleteVolumeRequest{
-			Zone:     zone,
-			VolumeID: expandID(volumeID),
-		})
+		if d.Get("root_volume.0.volume_type").(string) == blockVolumeType.String() {
+			// The volume must be detached from the deleted server before being deleted
+			_, err = waitForBlockVolume(ctx, api.blockAPI, zone, expandID(volumeID), d.Timeout(schema.TimeoutDelete))
+			if err == nil {
+				err = api.blockAPI.DeleteVolume(&block.DeleteVolumeRequest{
+					Zone:     zone,
+					VolumeID: expandID(volumeID),
+				}, scw.WithContext(ctx))
+			}
+		} else {
+			err = api.DeleteVolume(&instance.DeleteVolumeRequest{
+				Zone:     zone,
+				VolumeID: expandID(volumeID),
+			})
+		}
 		if err != nil && !is404Error(err) {
 			return diag.FromErr(err)
 		}
@@ -1252,6 +1274,250 @@ func customDiffInstanceServerImage(ctx context.Context, diff *schema.ResourceDif
 	return nil
 }
 
+// customDiffInstanceRootVolume forces the replacement of the server when its root volume cannot be changed in place.
+// Only network volumes can be grown, l_ssd volumes can be moved to b_ssd or block storage and b_ssd volumes to block storage.
+func customDiffInstanceRootVolume(_ context.Context, diff *schema.ResourceDiff, _ interface{}) error {
+	if diff.Id() == "" {
+		return nil
+	}
+
+	oldType, newType := diff.GetChange("root_volume.0.volume_type")
+	if diff.HasChange("root_volume.0.volume_type") && !isInstanceRootVolumeMigration(oldType.(string), newType.(string)) {
+		return diff.ForceNew("root_volume.0.volume_type")
+	}
+
+	oldSize, newSize := diff.GetChange("root_volume.0.size_in_gb")
+	if diff.HasChange("root_volume.0.size_in_gb") &&
+		(newSize.(int) < oldSize.(int) || newType.(string) == instance.VolumeVolumeTypeLSSD.String()) {
+		return diff.ForceNew("root_volume.0.size_in_gb")
+	}
+
+	return nil
+}
+
+// isInstanceRootVolumeMigration returns true if a root volume can be moved in place from a volume type to another
+func isInstanceRootVolumeMigration(oldType string, newType string) bool {
+	switch oldType {
+	case instance.VolumeVolumeTypeLSSD.String():
+		return newType == instance.VolumeVolumeTypeBSSD.String() || newType == blockVolumeType.String()
+	case instance.VolumeVolumeTypeBSSD.String():
+		return newType == blockVolumeType.String()
+	default:
+		return false
+	}
+}
+
+// resourceScalewayInstanceServerUpdateRootVolume moves the root volume to the new volume type then grows it, the server is kept.
+// A l_ssd volume is first copied to a b_ssd volume while the server is stopped, it is then migrated to block storage if needed.
+func resourceScalewayInstanceServerUpdateRootVolume(ctx context.Context, d *schema.ResourceData, api *InstanceBlockAPI, zone scw.Zone, id string) error {
+	volumeID := expandZonedID(d.Get("root_volume.0.volume_id")).ID
+	oldType, _ := d.GetChange("root_volume.0.volume_type")
+	if !d.HasChange("root_volume.0.volume_type") || oldType.(string) != instance.VolumeVolumeTypeLSSD.String() {
+		return resourceScalewayInstanceServerMigrateRootVolume(ctx, d, api, zone, volumeID)
+	}
+
+	server, err := waitForInstanceServer(ctx, api.API, zone, id, d.Timeout(schema.TimeoutUpdate))
+	if err != nil {
+		return err
+	}
+	beginningState := server.State
+
+	// Local volumes can only be detached from a stopped server
+	err = reachState(ctx, api, zone, id, instance.ServerStateStopped)
+	if err != nil {
+		return fmt.Errorf("failed to stop server before moving its root volume: %w", err)
+	}
+
+	volumeID, err = resourceScalewayInstanceServerReplaceLocalRootVolume(ctx, d, api, zone, id, volumeID)
+	if err != nil {
+		return err
+	}
+
+	err = resourceScalewayInstanceServerMigrateRootVolume(ctx, d, api, zone, volumeID)
+	if err != nil {
+		return err
+	}
+
+	err = reachState(ctx, api, zone, id, beginningState)
+	if err != nil {
+		return fmt.Errorf("failed to start server after moving its root volume: %w", err)
+	}
+
+	return nil
+}
+
+// resourceScalewayInstanceServerReplaceLocalRootVolume copies the local root volume of a stopped server to a b_ssd volume
+// through a snapshot, then replaces the root volume of the server with the copy. It returns the ID of the new root volume.
+func resourceScalewayInstanceServerReplaceLocalRootVolume(ctx context.Context, d *schema.ResourceData, api *InstanceBlockAPI, zone scw.Zone, id string, volumeID string) (string, error) {
+	timeout := d.Timeout(schema.TimeoutUpdate)
+
+	snapshot, err := api.CreateSnapshot(&instance.CreateSnapshotRequest{
+		Zone:     zone,
+		Name:     newRandomName("snp"),
+		VolumeID: &volumeID,
+		Project:  expandStringPtr(d.Get("project_id")),
+	}, scw.WithContext(ctx))
+	if err != nil {
+		return "", fmt.Errorf("failed to snapshot the local root volume: %w", err)
+	}
+
+	_, err = waitForInstanceSnapshot(ctx, api.API, zone, snapshot.Snapshot.ID, timeout)
+	if err != nil {
+		return "", err
+	}
+
+	// The snapshot is only needed to create the new volume
+	defer func() {
+		_ = api.DeleteSnapshot(&instance.DeleteSnapshotRequest{
+			Zone:       zone,
+			SnapshotID: snapshot.Snapshot.ID,
+		}, scw.WithContext(ctx))
+	}()
+
+	volume, err := api.CreateVolume(&instance.CreateVolumeRequest{
+		Zone:         zone,
+		Name:         newRandomName("vol"),
+		Project:      expandStringPtr(d.Get("project_id")),
+		VolumeType:   instance.VolumeVolumeTypeBSSD,
+		BaseSnapshot: &snapshot.Snapshot.ID,
+	}, scw.WithContext(ctx))
+	if err != nil {
+		return "", fmt.Errorf("failed to create the new root volume: %w", err)
+	}
+
+	_, err = waitForInstanceVolume(ctx, api.API, zone, volume.Volume.ID, timeout)
+	if err != nil {
+		return "", err
+	}
+
+	server, err := waitForInstanceServer(ctx, api.API, zone, id, timeout)
+	if err != nil {
+		return "", err
+	}
+
+	volumes := map[string]*instance.VolumeServerTemplate{}
+	for index, serverVolume := range server.Volumes {
+		volumes[index] = &instance.VolumeServerTemplate{
+			ID:   scw.StringPtr(serverVolume.ID),
+			Name: scw.StringPtr(newRandomName("vol")), // name is ignored by the API, any name will work here
+		}
+		if serverVolume.VolumeType == instance.VolumeServerVolumeType(blockVolumeType) {
+			// Volumes from the block API must be passed with their type
+			volumes[index].VolumeType = instance.VolumeVolumeType(blockVolumeType)
+		}
+	}
+	volumes["0"] = &instance.VolumeServerTemplate{
+		ID:   scw.StringPtr(volume.Volume.ID),
+		Name: scw.StringPtr(newRandomName("vol")),
+		Boot: expandBoolPtr(d.Get("root_volume.0.boot")),
+	}
+
+	_, err = api.UpdateServer(&instance.UpdateServerRequest{
+		Zone:     zone,
+		ServerID: id,
+		Volumes:  &volumes,
+	}, scw.WithContext(ctx))
+	if err != nil {
+		_ = api.DeleteVolume(&instance.DeleteVolumeRequest{
+			Zone:     zone,
+			VolumeID: volume.Volume.ID,
+		}, scw.WithContext(ctx))
+		return "", fmt.Errorf("failed to replace the root volume: %w", err)
+	}
+
+	err = api.DeleteVolume(&instance.DeleteVolumeRequest{
+		Zone:     zone,
+		VolumeID: volumeID,
+	}, scw.WithContext(ctx))
+	if err != nil && !is404Error(err) {
+		return "", fmt.Errorf("failed to delete the local root volume: %w", err)
+	}
+
+	// Later updates of the server volumes must use the new root volume
+	rootVolume := d.Get("root_volume.0").(map[string]interface{})
+	rootVolume["volume_id"] = newZonedIDString(zone, volume.Volume.ID)
+	_ = d.Set("root_volume", []interface{}{rootVolume})
+
+	return volume.Volume.ID, nil
+}
+
+// resourceScalewayInstanceServerMigrateRootVolume migrates a b_ssd root volume to block storage then grows it
+func resourceScalewayInstanceServerMigrateRootVolume(ctx context.Context, d *schema.ResourceData, api *InstanceBlockAPI, zone scw.Zone, volumeID string) error {
+	volumeType := d.Get("root_volume.0.volume_type").(string)
+	timeout := d.Timeout(schema.TimeoutUpdate)
+
+	if d.HasChange("root_volume.0.volume_type") && volumeType == blockVolumeType.String() {
+		_, err := waitForInstanceVolume(ctx, api.API, zone, volumeID, timeout)
+		if err != nil {
+			return err
+		}
+
+		// The volume is migrated along with its snapshots, as listed in the plan
+		plan, err := api.PlanBlockMigration(&instance.PlanBlockMigrationRequest{
+			Zone:     zone,
+			VolumeID: &volumeID,
+		}, scw.WithContext(ctx))
+		if err != nil {
+			return fmt.Errorf("failed to plan the migration of the root volume to block storage: %w", err)
+		}
+
+		err = api.ApplyBlockMigration(&instance.ApplyBlockMigrationRequest{
+			Zone:          zone,
+			VolumeID:      &volumeID,
+			ValidationKey: plan.ValidationKey,
+		}, scw.WithContext(ctx))
+		if err != nil {
+			return fmt.Errorf("failed to migrate the root volume to block storage: %w", err)
+		}
+
+		_, err = waitForBlockVolume(ctx, api.blockAPI, zone, volumeID, timeout)
+		if err != nil {
+			return err
+		}
+	}
+
+	if d.HasChange("root_volume.0.size_in_gb") {
+		size := scw.Size(uint64(d.Get("root_volume.0.size_in_gb").(int)) * gb)
+
+		if volumeType == blockVolumeType.String() {
+			_, err := api.blockAPI.UpdateVolume(&block.UpdateVolumeRequest{
+				Zone:     zone,
+				VolumeID: volumeID,
+				Size:     &size,
+			}, scw.WithContext(ctx))
+			if err != nil {
+				return fmt.Errorf("couldn't resize root volume: %w", err)
+			}
+
+			_, err = waitForBlockVolume(ctx, api.blockAPI, zone, volumeID, timeout)
+			if err != nil {
+				return err
+			}
+		} else {
+			_, err := waitForInstanceVolume(ctx, api.API, zone, volumeID, timeout)
+			if err != nil {
+				return err
+			}
+
+			_, err = api.UpdateVolume(&instance.UpdateVolumeRequest{
+				Zone:     zone,
+				VolumeID: volumeID,
+				Size:     &size,
+			}, scw.WithContext(ctx))
+			if err != nil {
+				return fmt.Errorf("couldn't resize root volume: %w", err)
+			}
+
+			_, err = waitForInstanceVolume(ctx, api.API, zone, volumeID, timeout)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
 func resourceScalewayInstanceServerMigrate(ctx context.Context, d *schema.ResourceData, api *InstanceBlockAPI, zone scw.Zone, id string) error {
 	server, err := waitForInstanceServer(ctx, api.API, zone, id, d.Timeout(schema.TimeoutUpdate))
 	if err != nil {
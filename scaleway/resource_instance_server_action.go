@@ -0,0 +1,171 @@
+package scaleway
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/scaleway/scaleway-sdk-go/api/instance/v1"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+)
+
+func resourceScalewayInstanceServerAction() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceScalewayInstanceServerActionCreate,
+		ReadContext:   resourceScalewayInstanceServerActionRead,
+		DeleteContext: resourceScalewayInstanceServerActionDelete,
+		Timeouts: &schema.ResourceTimeout{
+			Create:  schema.DefaultTimeout(defaultInstanceServerWaitTimeout),
+			Read:    schema.DefaultTimeout(defaultInstanceServerWaitTimeout),
+			Delete:  schema.DefaultTimeout(defaultInstanceServerWaitTimeout),
+			Default: schema.DefaultTimeout(defaultInstanceServerWaitTimeout),
+		},
+		SchemaVersion: 0,
+		Schema: map[string]*schema.Schema{
+			"server_id": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				Description:  "The ID of the server",
+				ValidateFunc: validationUUIDWithLocality(),
+			},
+			"action": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The action to perform on the server",
+				ValidateFunc: validation.StringInSlice([]string{
+					instance.ServerActionPoweron.String(),
+					instance.ServerActionPoweroff.String(),
+					instance.ServerActionStopInPlace.String(),
+					instance.ServerActionReboot.String(),
+					instance.ServerActionBackup.String(),
+					instance.ServerActionEnableRoutedIP.String(),
+				}, false),
+			},
+			"backup_name": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "The name of the image created by the backup action",
+			},
+			"triggers": {
+				Type:        schema.TypeMap,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "Arbitrary values that, when changed, perform the action again",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"wait": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				ForceNew:    true,
+				Default:     true,
+				Description: "Wait for the server to reach a stable state once the action is performed",
+			},
+			"image_id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The ID of the image created by the backup action",
+			},
+			"zone": zoneSchema(),
+		},
+		CustomizeDiff: customdiff.All(
+			customizeDiffLocalityCheck("server_id"),
+			func(_ context.Context, diff *schema.ResourceDiff, _ interface{}) error {
+				if _, ok := diff.GetOk("backup_name"); ok && diff.Get("action").(string) != instance.ServerActionBackup.String() {
+					return fmt.Errorf("backup_name can only be set with the %s action", instance.ServerActionBackup)
+				}
+				return nil
+			},
+		),
+	}
+}
+
+func resourceScalewayInstanceServerActionCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	instanceAPI, zone, err := instanceAPIWithZone(d, meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	serverID := expandID(d.Get("server_id").(string))
+	server, err := waitForInstanceServer(ctx, instanceAPI, zone, serverID, d.Timeout(schema.TimeoutCreate))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	action := instance.ServerAction(d.Get("action").(string))
+	actionRequest := &instance.ServerActionRequest{
+		Zone:     zone,
+		ServerID: server.ID,
+		Action:   action,
+	}
+	if action == instance.ServerActionBackup {
+		actionRequest.Name = expandStringPtr(d.Get("backup_name"))
+	}
+
+	res, err := instanceAPI.ServerAction(actionRequest, scw.WithContext(ctx))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(newZonedNestedIDString(zone, action.String(), server.ID))
+
+	if action == instance.ServerActionBackup && res.Task != nil {
+		imageID := instanceTaskImageID(res.Task)
+		_ = d.Set("image_id", newZonedIDString(zone, imageID))
+
+		if d.Get("wait").(bool) && imageID != "" {
+			_, err = waitForInstanceImage(ctx, instanceAPI, zone, imageID, d.Timeout(schema.TimeoutCreate))
+			if err != nil {
+				return diag.FromErr(err)
+			}
+		}
+	}
+
+	if d.Get("wait").(bool) {
+		_, err = waitForInstanceServer(ctx, instanceAPI, zone, server.ID, d.Timeout(schema.TimeoutCreate))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return resourceScalewayInstanceServerActionRead(ctx, d, meta)
+}
+
+func resourceScalewayInstanceServerActionRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	instanceAPI, zone, id, action, err := instanceAPIWithZoneAndNestedID(meta, d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	server, err := instanceAPI.GetServer(&instance.GetServerRequest{
+		Zone:     zone,
+		ServerID: id,
+	}, scw.WithContext(ctx))
+	if err != nil {
+		if is404Error(err) {
+			d.SetId("")
+			return nil
+		}
+		return diag.FromErr(err)
+	}
+
+	_ = d.Set("server_id", newZonedID(zone, server.Server.ID).String())
+	_ = d.Set("action", action)
+	_ = d.Set("zone", zone.String())
+
+	return nil
+}
+
+// resourceScalewayInstanceServerActionDelete only removes the action from the state, an action cannot be undone.
+func resourceScalewayInstanceServerActionDelete(_ context.Context, d *schema.ResourceData, _ interface{}) diag.Diagnostics {
+	d.SetId("")
+
+	return nil
+}
@@ -0,0 +1,66 @@
+package scaleway
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccScalewayInstanceServerAction_Basic(t *testing.T) {
+	tt := NewTestToolsWithFakeAPI(t)
+	defer tt.Cleanup()
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: tt.ProviderFactories,
+		CheckDestroy:             testAccCheckScalewayInstanceServerDestroy(tt),
+		Steps: []resource.TestStep{
+			{
+				Config: `
+					resource "scaleway_instance_server" "main" {
+						image = "ubuntu_jammy"
+						type  = "DEV1-S"
+					}
+
+					resource "scaleway_instance_server_action" "reboot" {
+						server_id = scaleway_instance_server.main.id
+						action    = "reboot"
+						triggers = {
+							kernel = "5.15"
+						}
+					}
+				`,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckScalewayInstanceServerExists(tt, "scaleway_instance_server.main"),
+					resource.TestCheckResourceAttr("scaleway_instance_server_action.reboot", "action", "reboot"),
+					resource.TestCheckResourceAttrPair("scaleway_instance_server_action.reboot", "server_id", "scaleway_instance_server.main", "id"),
+					resource.TestCheckResourceAttr("scaleway_instance_server.main", "state", "started"),
+				),
+			},
+			{
+				Config: `
+					resource "scaleway_instance_server" "main" {
+						image = "ubuntu_jammy"
+						type  = "DEV1-S"
+					}
+
+					resource "scaleway_instance_server_action" "reboot" {
+						server_id = scaleway_instance_server.main.id
+						action    = "reboot"
+						triggers = {
+							kernel = "6.2"
+						}
+					}
+
+					resource "scaleway_instance_server_action" "backup" {
+						server_id   = scaleway_instance_server.main.id
+						action      = "backup"
+						backup_name = "tf-backup"
+					}
+				`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("scaleway_instance_server_action.reboot", "triggers.kernel", "6.2"),
+					resource.TestCheckResourceAttrSet("scaleway_instance_server_action.backup", "image_id"),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,333 @@
+package scaleway
+
+import (
+	"context"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	block "github.com/scaleway/scaleway-sdk-go/api/block/v1alpha1"
+	"github.com/scaleway/scaleway-sdk-go/api/instance/v1"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+)
+
+func resourceScalewayInstanceServerBackup() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceScalewayInstanceServerBackupCreate,
+		ReadContext:   resourceScalewayInstanceServerBackupRead,
+		UpdateContext: resourceScalewayInstanceServerBackupUpdate,
+		DeleteContext: resourceScalewayInstanceServerBackupDelete,
+		Timeouts: &schema.ResourceTimeout{
+			Create:  schema.DefaultTimeout(defaultInstanceImageTimeout),
+			Read:    schema.DefaultTimeout(defaultInstanceImageTimeout),
+			Update:  schema.DefaultTimeout(defaultInstanceImageTimeout),
+			Delete:  schema.DefaultTimeout(defaultInstanceImageTimeout),
+			Default: schema.DefaultTimeout(defaultInstanceImageTimeout),
+		},
+		SchemaVersion: 0,
+		Schema: map[string]*schema.Schema{
+			"server_id": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				Description:  "The ID of the server to backup",
+				ValidateFunc: validationUUIDWithLocality(),
+			},
+			"name": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "The name of the backup images",
+			},
+			"keep_last": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Description:  "The number of backups to keep, older backups are deleted",
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+			"triggers": {
+				Type:        schema.TypeMap,
+				Optional:    true,
+				Description: "Arbitrary values that, when changed, take a new backup",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"image_id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The ID of the image of the latest backup",
+			},
+			"snapshot_ids": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The IDs of the volume snapshots of the latest backup",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"backups": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The backups managed by this resource, from the oldest to the latest",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"image_id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The ID of the image of the backup",
+						},
+						"snapshot_ids": {
+							Type:        schema.TypeList,
+							Computed:    true,
+							Description: "The IDs of the volume snapshots of the backup",
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
+						"creation_date": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The date and time of the creation of the backup",
+						},
+					},
+				},
+			},
+			"zone": zoneSchema(),
+		},
+		CustomizeDiff: customdiff.All(
+			customizeDiffLocalityCheck("server_id"),
+			customdiff.ComputedIf("image_id", func(_ context.Context, d *schema.ResourceDiff, _ interface{}) bool {
+				return d.HasChange("triggers")
+			}),
+			customdiff.ComputedIf("snapshot_ids", func(_ context.Context, d *schema.ResourceDiff, _ interface{}) bool {
+				return d.HasChange("triggers")
+			}),
+			customdiff.ComputedIf("backups", func(_ context.Context, d *schema.ResourceDiff, _ interface{}) bool {
+				return d.HasChanges("triggers", "keep_last")
+			}),
+		),
+	}
+}
+
+func resourceScalewayInstanceServerBackupCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	_, zone, err := instanceAPIWithZone(d, meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	serverID := expandID(d.Get("server_id").(string))
+	name := expandOrGenerateString(d.Get("name"), "backup")
+	_ = d.Set("name", name)
+
+	image, err := createInstanceServerBackup(ctx, meta, zone, serverID, name, d.Timeout(schema.TimeoutCreate))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(newZonedIDString(zone, serverID))
+	_ = d.Set("backups", []interface{}{flattenInstanceServerBackup(image)})
+
+	diags := pruneInstanceServerBackups(ctx, d, meta, zone, d.Timeout(schema.TimeoutCreate))
+	if diags.HasError() {
+		return diags
+	}
+
+	return append(diags, resourceScalewayInstanceServerBackupRead(ctx, d, meta)...)
+}
+
+func resourceScalewayInstanceServerBackupRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	instanceAPI, zone, serverID, err := instanceAPIWithZoneAndID(meta, d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	// Backups deleted outside of Terraform are forgotten
+	backups := []interface{}(nil)
+	for _, backup := range d.Get("backups").([]interface{}) {
+		imageID := expandID(backup.(map[string]interface{})["image_id"])
+		res, err := instanceAPI.GetImage(&instance.GetImageRequest{
+			Zone:    zone,
+			ImageID: imageID,
+		}, scw.WithContext(ctx))
+		if err != nil {
+			if is404Error(err) {
+				continue
+			}
+			return diag.FromErr(err)
+		}
+		backups = append(backups, flattenInstanceServerBackup(res.Image))
+	}
+
+	if len(backups) == 0 {
+		d.SetId("")
+		return nil
+	}
+
+	latest := backups[len(backups)-1].(map[string]interface{})
+	_ = d.Set("server_id", newZonedIDString(zone, serverID))
+	_ = d.Set("image_id", latest["image_id"])
+	_ = d.Set("snapshot_ids", latest["snapshot_ids"])
+	_ = d.Set("backups", backups)
+	_ = d.Set("zone", zone.String())
+
+	return nil
+}
+
+func resourceScalewayInstanceServerBackupUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	_, zone, serverID, err := instanceAPIWithZoneAndID(meta, d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	// The backups are unknown in the plan when they change, the previous ones are kept from the state
+	oldBackups, _ := d.GetChange("backups")
+	backups := oldBackups.([]interface{})
+	_ = d.Set("backups", backups)
+
+	if d.HasChange("triggers") {
+		image, err := createInstanceServerBackup(ctx, meta, zone, serverID, d.Get("name").(string), d.Timeout(schema.TimeoutUpdate))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		_ = d.Set("backups", append(backups, flattenInstanceServerBackup(image)))
+	}
+
+	diags := pruneInstanceServerBackups(ctx, d, meta, zone, d.Timeout(schema.TimeoutUpdate))
+	if diags.HasError() {
+		return diags
+	}
+
+	return append(diags, resourceScalewayInstanceServerBackupRead(ctx, d, meta)...)
+}
+
+func resourceScalewayInstanceServerBackupDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	_, zone, _, err := instanceAPIWithZoneAndID(meta, d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	for _, backup := range d.Get("backups").([]interface{}) {
+		err := deleteInstanceServerBackup(ctx, meta, zone, backup.(map[string]interface{}), d.Timeout(schema.TimeoutDelete))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return nil
+}
+
+// createInstanceServerBackup performs the backup action on a server and waits for its image and snapshots.
+func createInstanceServerBackup(ctx context.Context, meta interface{}, zone scw.Zone, serverID string, name string, timeout time.Duration) (*instance.Image, error) {
+	api := instance.NewAPI(meta.(*Meta).scwClient)
+	blockAPI := block.NewAPI(meta.(*Meta).scwClient)
+
+	server, err := waitForInstanceServer(ctx, api, zone, serverID, timeout)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := api.ServerAction(&instance.ServerActionRequest{
+		Zone:     zone,
+		ServerID: server.ID,
+		Action:   instance.ServerActionBackup,
+		Name:     &name,
+	}, scw.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	image, err := waitForInstanceImage(ctx, api, zone, instanceTaskImageID(res.Task), timeout)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, volume := range instanceImageVolumes(image) {
+		if volume.VolumeType == instance.VolumeVolumeTypeSbsSnapshot {
+			_, err = waitForBlockSnapshot(ctx, blockAPI, zone, volume.ID, timeout)
+		} else {
+			_, err = waitForInstanceSnapshot(ctx, api, zone, volume.ID, timeout)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return image, nil
+}
+
+// pruneInstanceServerBackups deletes the oldest backups until keep_last backups are left.
+func pruneInstanceServerBackups(ctx context.Context, d *schema.ResourceData, meta interface{}, zone scw.Zone, timeout time.Duration) diag.Diagnostics {
+	keepLast := d.Get("keep_last").(int)
+	backups := d.Get("backups").([]interface{})
+	if keepLast == 0 || len(backups) <= keepLast {
+		return nil
+	}
+
+	for len(backups) > keepLast {
+		err := deleteInstanceServerBackup(ctx, meta, zone, backups[0].(map[string]interface{}), timeout)
+		if err != nil {
+			_ = d.Set("backups", backups)
+			return diag.FromErr(err)
+		}
+		backups = backups[1:]
+	}
+	_ = d.Set("backups", backups)
+
+	return nil
+}
+
+// deleteInstanceServerBackup deletes the image of a backup then its snapshots, which cannot be deleted while used by the image.
+func deleteInstanceServerBackup(ctx context.Context, meta interface{}, zone scw.Zone, backup map[string]interface{}, timeout time.Duration) error {
+	instanceAPI := instance.NewAPI(meta.(*Meta).scwClient)
+	blockAPI := block.NewAPI(meta.(*Meta).scwClient)
+	imageID := expandID(backup["image_id"])
+
+	_, err := waitForInstanceImage(ctx, instanceAPI, zone, imageID, timeout)
+	if err != nil && !is404Error(err) {
+		return err
+	}
+
+	err = instanceAPI.DeleteImage(&instance.DeleteImageRequest{
+		Zone:    zone,
+		ImageID: imageID,
+	}, scw.WithContext(ctx))
+	if err != nil && !is404Error(err) {
+		return err
+	}
+
+	for _, rawSnapshotID := range backup["snapshot_ids"].([]interface{}) {
+		snapshotID := expandID(rawSnapshotID)
+		err = instanceAPI.DeleteSnapshot(&instance.DeleteSnapshotRequest{
+			Zone:       zone,
+			SnapshotID: snapshotID,
+		}, scw.WithContext(ctx))
+		if is404Error(err) {
+			// Snapshots of block volumes are managed by the block API
+			err = blockAPI.DeleteSnapshot(&block.DeleteSnapshotRequest{
+				Zone:       zone,
+				SnapshotID: snapshotID,
+			}, scw.WithContext(ctx))
+		}
+		if err != nil && !is404Error(err) {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func flattenInstanceServerBackup(image *instance.Image) map[string]interface{} {
+	snapshotIDs := []interface{}(nil)
+	for _, volume := range instanceImageVolumes(image) {
+		snapshotIDs = append(snapshotIDs, newZonedIDString(image.Zone, volume.ID))
+	}
+
+	return map[string]interface{}{
+		"image_id":      newZonedIDString(image.Zone, image.ID),
+		"snapshot_ids":  snapshotIDs,
+		"creation_date": flattenTime(image.CreationDate),
+	}
+}
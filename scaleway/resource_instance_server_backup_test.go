@@ -0,0 +1,130 @@
+package scaleway
+
+import (
+	"fmt"
+	"strconv"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/scaleway/scaleway-sdk-go/api/instance/v1"
+)
+
+func TestAccScalewayInstanceServerBackup_Retention(t *testing.T) {
+	tt := NewTestToolsWithFakeAPI(t)
+	defer tt.Cleanup()
+
+	config := func(trigger string) string {
+		return fmt.Sprintf(`
+			resource "scaleway_instance_server" "main" {
+				image = "ubuntu_jammy"
+				type  = "DEV1-S"
+			}
+
+			resource "scaleway_instance_server_backup" "main" {
+				server_id = scaleway_instance_server.main.id
+				name      = "tf-backup"
+				keep_last = 2
+				triggers = {
+					schedule = "%s"
+				}
+			}
+		`, trigger)
+	}
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: tt.ProviderFactories,
+		CheckDestroy: resource.ComposeTestCheckFunc(
+			testAccCheckScalewayInstanceServerBackupDestroy(tt),
+			testAccCheckScalewayInstanceServerDestroy(tt),
+		),
+		Steps: []resource.TestStep{
+			{
+				Config: config("2024-01-01"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckScalewayInstanceServerBackupExists(tt, "scaleway_instance_server_backup.main"),
+					resource.TestCheckResourceAttr("scaleway_instance_server_backup.main", "backups.#", "1"),
+					resource.TestCheckResourceAttr("scaleway_instance_server_backup.main", "snapshot_ids.#", "1"),
+					resource.TestCheckResourceAttrPair("scaleway_instance_server_backup.main", "image_id", "scaleway_instance_server_backup.main", "backups.0.image_id"),
+				),
+			},
+			{
+				Config: config("2024-01-02"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckScalewayInstanceServerBackupExists(tt, "scaleway_instance_server_backup.main"),
+					resource.TestCheckResourceAttr("scaleway_instance_server_backup.main", "backups.#", "2"),
+					resource.TestCheckResourceAttrPair("scaleway_instance_server_backup.main", "image_id", "scaleway_instance_server_backup.main", "backups.1.image_id"),
+				),
+			},
+			{
+				Config: config("2024-01-03"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckScalewayInstanceServerBackupExists(tt, "scaleway_instance_server_backup.main"),
+					resource.TestCheckResourceAttr("scaleway_instance_server_backup.main", "backups.#", "2"),
+					resource.TestCheckResourceAttrPair("scaleway_instance_server_backup.main", "image_id", "scaleway_instance_server_backup.main", "backups.1.image_id"),
+				),
+			},
+		},
+	})
+}
+
+// testAccCheckScalewayInstanceServerBackupExists checks that the images of all the backups of a resource exist
+func testAccCheckScalewayInstanceServerBackupExists(tt *TestTools, n string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("not found: %s", n)
+		}
+
+		instanceAPI := instance.NewAPI(tt.Meta.scwClient)
+		count, _ := strconv.Atoi(rs.Primary.Attributes["backups.#"])
+		for i := 0; i < count; i++ {
+			zone, ID, err := parseZonedID(rs.Primary.Attributes[fmt.Sprintf("backups.%d.image_id", i)])
+			if err != nil {
+				return err
+			}
+			_, err = instanceAPI.GetImage(&instance.GetImageRequest{
+				ImageID: ID,
+				Zone:    zone,
+			})
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+}
+
+func testAccCheckScalewayInstanceServerBackupDestroy(tt *TestTools) resource.TestCheckFunc {
+	return func(state *terraform.State) error {
+		instanceAPI := instance.NewAPI(tt.Meta.scwClient)
+		for _, rs := range state.RootModule().Resources {
+			if rs.Type != "scaleway_instance_server_backup" {
+				continue
+			}
+
+			count, _ := strconv.Atoi(rs.Primary.Attributes["backups.#"])
+			for i := 0; i < count; i++ {
+				zone, ID, err := parseZonedID(rs.Primary.Attributes[fmt.Sprintf("backups.%d.image_id", i)])
+				if err != nil {
+					return err
+				}
+				_, err = instanceAPI.GetImage(&instance.GetImageRequest{
+					ImageID: ID,
+					Zone:    zone,
+				})
+				// If no error resource still exist
+				if err == nil {
+					return fmt.Errorf("backup image (%s) still exists", ID)
+				}
+				// Unexpected api error we return it
+				if !is404Error(err) {
+					return err
+				}
+			}
+		}
+
+		return nil
+	}
+}
@@ -92,6 +92,26 @@ func resourceScalewayInstanceSnapshot() *schema.Resource {
 				Description:   "Import snapshot from a qcow",
 				ConflictsWith: []string{"volume_id"},
 			},
+			"export": {
+				Type:     schema.TypeList,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"bucket": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "Bucket where the qcow is exported",
+						},
+						"key": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "Key of the exported qcow file in the specified bucket",
+						},
+					},
+				},
+				Optional:    true,
+				Description: "Export the snapshot to a qcow, exported again when changed",
+			},
 			"created_at": {
 				Type:        schema.TypeString,
 				Computed:    true,
@@ -150,6 +170,13 @@ func resourceScalewayInstanceSnapshotCreate(ctx context.Context, d *schema.Resou
 		return diag.FromErr(err)
 	}
 
+	if _, isExported := d.GetOk("export"); isExported {
+		err = exportInstanceSnapshot(ctx, d, instanceAPI, zone, res.Snapshot.ID, d.Timeout(schema.TimeoutCreate))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
 	return resourceScalewayInstanceSnapshotRead(ctx, d, meta)
 }
 
@@ -193,7 +220,7 @@ func resourceScalewayInstanceSnapshotUpdate(ctx context.Context, d *schema.Resou
 	}
 
 	tags := expandStrings(d.Get("tags"))
-	if d.HasChange("tags") && len(tags) > 0 {
+	if d.HasChanges("tags", "tags_all") && len(tags) > 0 {
 		req.Tags = scw.StringsPtr(expandStrings(d.Get("tags")))
 	}
 
@@ -202,6 +229,13 @@ func resourceScalewayInstanceSnapshotUpdate(ctx context.Context, d *schema.Resou
 		return diag.FromErr(fmt.Errorf("couldn't update snapshot: %s", err))
 	}
 
+	if _, isExported := d.GetOk("export"); isExported && d.HasChange("export") {
+		err = exportInstanceSnapshot(ctx, d, instanceAPI, zone, id, d.Timeout(schema.TimeoutUpdate))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
 	return resourceScalewayInstanceSnapshotRead(ctx, d, meta)
 }
 
@@ -235,3 +269,25 @@ func resourceScalewayInstanceSnapshotDelete(ctx context.Context, d *schema.Resou
 
 	return nil
 }
+
+// exportInstanceSnapshot exports a snapshot to the qcow file of the export block and waits for the end of the export
+func exportInstanceSnapshot(ctx context.Context, d *schema.ResourceData, instanceAPI *instance.API, zone scw.Zone, id string, timeout time.Duration) error {
+	_, err := waitForInstanceSnapshot(ctx, instanceAPI, zone, id, timeout)
+	if err != nil {
+		return err
+	}
+
+	_, err = instanceAPI.ExportSnapshot(&instance.ExportSnapshotRequest{
+		Zone:       zone,
+		SnapshotID: id,
+		Bucket:     d.Get("export.0.bucket").(string),
+		Key:        d.Get("export.0.key").(string),
+	}, scw.WithContext(ctx))
+	if err != nil {
+		return fmt.Errorf("couldn't export snapshot: %w", err)
+	}
+
+	_, err = waitForInstanceSnapshot(ctx, instanceAPI, zone, id, timeout)
+
+	return err
+}
@@ -47,9 +47,9 @@ func TestAccScalewayInstanceSnapshot_BlockVolume(t *testing.T) {
 	tt := NewTestTools(t)
 	defer tt.Cleanup()
 	resource.ParallelTest(t, resource.TestCase{
-		PreCheck:          func() { testAccPreCheck(t) },
-		ProviderFactories: tt.ProviderFactories,
-		CheckDestroy:      testAccCheckScalewayInstanceVolumeDestroy(tt),
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: tt.ProviderFactories,
+		CheckDestroy:             testAccCheckScalewayInstanceVolumeDestroy(tt),
 		Steps: []resource.TestStep{
 			{
 				Config: `
@@ -73,9 +73,9 @@ func TestAccScalewayInstanceSnapshot_Unified(t *testing.T) {
 	tt := NewTestTools(t)
 	defer tt.Cleanup()
 	resource.Test(t, resource.TestCase{
-		PreCheck:          func() { testAccPreCheck(t) },
-		ProviderFactories: tt.ProviderFactories,
-		CheckDestroy:      testAccCheckScalewayInstanceVolumeDestroy(tt),
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: tt.ProviderFactories,
+		CheckDestroy:             testAccCheckScalewayInstanceVolumeDestroy(tt),
 		Steps: []resource.TestStep{
 			{
 				Config: `
@@ -115,9 +115,9 @@ func TestAccScalewayInstanceSnapshot_Server(t *testing.T) {
 	tt := NewTestTools(t)
 	defer tt.Cleanup()
 	resource.ParallelTest(t, resource.TestCase{
-		PreCheck:          func() { testAccPreCheck(t) },
-		ProviderFactories: tt.ProviderFactories,
-		CheckDestroy:      testAccCheckScalewayInstanceVolumeDestroy(tt),
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: tt.ProviderFactories,
+		CheckDestroy:             testAccCheckScalewayInstanceVolumeDestroy(tt),
 		Steps: []resource.TestStep{
 			{
 				Config: `
@@ -141,8 +141,8 @@ func TestAccScalewayInstanceSnapshot_ServerWithBlockVolume(t *testing.T) {
 	tt := NewTestTools(t)
 	defer tt.Cleanup()
 	resource.ParallelTest(t, resource.TestCase{
-		PreCheck:          func() { testAccPreCheck(t) },
-		ProviderFactories: tt.ProviderFactories,
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: tt.ProviderFactories,
 		CheckDestroy: resource.ComposeTestCheckFunc(
 			testAccCheckScalewayInstanceVolumeDestroy(tt),
 			testAccCheckScalewayInstanceServerDestroy(tt),
@@ -183,9 +183,9 @@ func TestAccScalewayInstanceSnapshot_RenameSnapshot(t *testing.T) {
 	tt := NewTestTools(t)
 	defer tt.Cleanup()
 	resource.ParallelTest(t, resource.TestCase{
-		PreCheck:          func() { testAccPreCheck(t) },
-		ProviderFactories: tt.ProviderFactories,
-		CheckDestroy:      testAccCheckScalewayInstanceVolumeDestroy(tt),
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: tt.ProviderFactories,
+		CheckDestroy:             testAccCheckScalewayInstanceVolumeDestroy(tt),
 		Steps: []resource.TestStep{
 			{
 				Config: `
@@ -224,6 +224,74 @@ func TestAccScalewayInstanceSnapshot_RenameSnapshot(t *testing.T) {
 	})
 }
 
+func TestAccScalewayInstanceSnapshot_Export(t *testing.T) {
+	tt := NewTestToolsWithFakeAPI(t)
+	defer tt.Cleanup()
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: tt.ProviderFactories,
+		CheckDestroy:             testAccCheckScalewayInstanceSnapshotDestroy(tt),
+		Steps: []resource.TestStep{
+			{
+				Config: `
+					resource "scaleway_instance_volume" "main" {
+						type       = "b_ssd"
+						size_in_gb = 20
+					}
+
+					resource "scaleway_instance_snapshot" "main" {
+						volume_id = scaleway_instance_volume.main.id
+						export {
+							bucket = "snapshot-exports"
+							key    = "main.qcow2"
+						}
+					}`,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckScalewayInstanceSnapShotExists(tt, "scaleway_instance_snapshot.main"),
+					resource.TestCheckResourceAttr("scaleway_instance_snapshot.main", "export.0.key", "main.qcow2"),
+					testAccCheckScalewayInstanceSnapshotExportedTo(tt, "scaleway_instance_snapshot.main", "snapshot-exports/main.qcow2"),
+				),
+			},
+			{
+				Config: `
+					resource "scaleway_instance_volume" "main" {
+						type       = "b_ssd"
+						size_in_gb = 20
+					}
+
+					resource "scaleway_instance_snapshot" "main" {
+						volume_id = scaleway_instance_volume.main.id
+						export {
+							bucket = "snapshot-exports"
+							key    = "main-v2.qcow2"
+						}
+					}`,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckScalewayInstanceSnapShotExists(tt, "scaleway_instance_snapshot.main"),
+					resource.TestCheckResourceAttr("scaleway_instance_snapshot.main", "export.0.key", "main-v2.qcow2"),
+					testAccCheckScalewayInstanceSnapshotExportedTo(tt, "scaleway_instance_snapshot.main", "snapshot-exports/main-v2.qcow2"),
+				),
+			},
+		},
+	})
+}
+
+// testAccCheckScalewayInstanceSnapshotExportedTo checks the last export of the snapshot went to the given bucket/key
+func testAccCheckScalewayInstanceSnapshotExportedTo(tt *TestTools, n string, object string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("resource not found: %s", n)
+		}
+
+		exports := tt.FakeAPI.SnapshotExports(expandID(rs.Primary.ID))
+		if len(exports) == 0 || exports[len(exports)-1] != object {
+			return fmt.Errorf("snapshot %s was not exported to %s, exports: %v", rs.Primary.ID, object, exports)
+		}
+
+		return nil
+	}
+}
+
 func testAccCheckScalewayInstanceSnapShotExists(tt *TestTools, n string) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		rs, ok := s.RootModule().Resources[n]
@@ -182,7 +182,7 @@ func resourceScalewayInstanceVolumeUpdate(ctx context.Context, d *schema.Resourc
 	}
 
 	tags := expandStrings(d.Get("tags"))
-	if d.HasChange("tags") && len(tags) > 0 {
+	if d.HasChanges("tags", "tags_all") && len(tags) > 0 {
 		req.Tags = scw.StringsPtr(expandStrings(d.Get("tags")))
 	}
 
@@ -0,0 +1,268 @@
+package scaleway
+
+import (
+	"context"
+	"errors"
+	"net"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+)
+
+func resourceScalewayK8SACL() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceScalewayK8SACLCreate,
+		ReadContext:   resourceScalewayK8SACLRead,
+		UpdateContext: resourceScalewayK8SACLUpdate,
+		DeleteContext: resourceScalewayK8SACLDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create:  schema.DefaultTimeout(defaultK8SClusterTimeout),
+			Read:    schema.DefaultTimeout(defaultK8SClusterTimeout),
+			Update:  schema.DefaultTimeout(defaultK8SClusterTimeout),
+			Delete:  schema.DefaultTimeout(defaultK8SClusterTimeout),
+			Default: schema.DefaultTimeout(defaultK8SClusterTimeout),
+		},
+		SchemaVersion: 0,
+		Schema: map[string]*schema.Schema{
+			"cluster_id": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validationUUIDorUUIDWithLocality(),
+				Description:  "Cluster on which the ACL is applied",
+			},
+			"acl_rules": {
+				Type:          schema.TypeSet,
+				Optional:      true,
+				Description:   "The rules allowing access to the control plane of the cluster",
+				ConflictsWith: []string{"no_ip_allowed"},
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The ID of the rule",
+						},
+						"ip": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							ValidateFunc: validation.IsCIDRNetwork(0, 128),
+							Description:  "The IP range allowed to reach the control plane, e.g. 1.2.3.0/24",
+						},
+						"scaleway_ranges": {
+							Type:        schema.TypeBool,
+							Optional:    true,
+							Default:     false,
+							Description: "Allow the IP ranges of Scaleway, used by its managed products, to reach the control plane",
+						},
+						"description": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "The description of the rule",
+						},
+					},
+				},
+			},
+			"no_ip_allowed": {
+				Type:          schema.TypeBool,
+				Optional:      true,
+				Default:       false,
+				Description:   "Deny all the IPs, the control plane can only be reached from the private network of the cluster",
+				ConflictsWith: []string{"acl_rules"},
+			},
+			// Common
+			"region": regionSchema(),
+		},
+		CustomizeDiff: customizeDiffLocalityCheck("cluster_id"),
+	}
+}
+
+func resourceScalewayK8SACLCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	k8sAPI, region, err := k8sAPIWithRegion(d, meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	aclAPI := newK8SACLAPI(meta.(*Meta).scwClient)
+
+	clusterID := expandID(d.Get("cluster_id"))
+	_, err = waitK8SCluster(ctx, k8sAPI, region, clusterID, d.Timeout(schema.TimeoutCreate))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	rules, err := expandK8SACLRules(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	_, err = aclAPI.SetClusterACLRules(region, clusterID, rules, scw.WithContext(ctx))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(newRegionalIDString(region, clusterID))
+
+	_, err = waitK8SCluster(ctx, k8sAPI, region, clusterID, d.Timeout(schema.TimeoutCreate))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return resourceScalewayK8SACLRead(ctx, d, meta)
+}
+
+func resourceScalewayK8SACLRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	k8sAPI, region, clusterID, err := k8sAPIWithRegionAndID(meta, d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	aclAPI := newK8SACLAPI(meta.(*Meta).scwClient)
+
+	_, err = waitK8SCluster(ctx, k8sAPI, region, clusterID, d.Timeout(schema.TimeoutRead))
+	if err != nil {
+		if is404Error(err) {
+			d.SetId("")
+			return nil
+		}
+		return diag.FromErr(err)
+	}
+
+	res, err := aclAPI.ListClusterACLRules(region, clusterID, scw.WithAllPages(), scw.WithContext(ctx))
+	if err != nil {
+		if is404Error(err) {
+			d.SetId("")
+			return nil
+		}
+		return diag.FromErr(err)
+	}
+
+	_ = d.Set("cluster_id", newRegionalIDString(region, clusterID))
+	_ = d.Set("acl_rules", flattenK8SACLRules(res.Rules))
+	_ = d.Set("no_ip_allowed", len(res.Rules) == 0)
+	_ = d.Set("region", region)
+
+	return nil
+}
+
+func resourceScalewayK8SACLUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	k8sAPI, region, clusterID, err := k8sAPIWithRegionAndID(meta, d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	aclAPI := newK8SACLAPI(meta.(*Meta).scwClient)
+
+	if d.HasChanges("acl_rules", "no_ip_allowed") {
+		_, err = waitK8SCluster(ctx, k8sAPI, region, clusterID, d.Timeout(schema.TimeoutUpdate))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		rules, err := expandK8SACLRules(d)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		_, err = aclAPI.SetClusterACLRules(region, clusterID, rules, scw.WithContext(ctx))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		_, err = waitK8SCluster(ctx, k8sAPI, region, clusterID, d.Timeout(schema.TimeoutUpdate))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return resourceScalewayK8SACLRead(ctx, d, meta)
+}
+
+// resourceScalewayK8SACLDelete allows all the IPs again, like the ACL of a new cluster
+func resourceScalewayK8SACLDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	k8sAPI, region, clusterID, err := k8sAPIWithRegionAndID(meta, d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	aclAPI := newK8SACLAPI(meta.(*Meta).scwClient)
+
+	_, err = waitK8SCluster(ctx, k8sAPI, region, clusterID, d.Timeout(schema.TimeoutDelete))
+	if err != nil {
+		if is404Error(err) {
+			return nil
+		}
+		return diag.FromErr(err)
+	}
+
+	_, allowAll, _ := net.ParseCIDR("0.0.0.0/0")
+	_, err = aclAPI.SetClusterACLRules(region, clusterID, []*k8sACLRule{{
+		IP:          &scw.IPNet{IPNet: *allowAll},
+		Description: "Automatically generated after scaleway_k8s_acl resource deletion",
+	}}, scw.WithContext(ctx))
+	if err != nil {
+		if is404Error(err) {
+			return nil
+		}
+		return diag.FromErr(err)
+	}
+
+	_, err = waitK8SCluster(ctx, k8sAPI, region, clusterID, d.Timeout(schema.TimeoutDelete))
+	if err != nil && !is404Error(err) {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+// expandK8SACLRules returns the rules to set on the cluster, no rule denies all the IPs
+func expandK8SACLRules(d *schema.ResourceData) ([]*k8sACLRule, error) {
+	rawRules := d.Get("acl_rules").(*schema.Set).List()
+	if len(rawRules) == 0 && !d.Get("no_ip_allowed").(bool) {
+		return nil, errors.New("acl_rules must be set, or no_ip_allowed must be true to deny all the IPs")
+	}
+
+	rules := make([]*k8sACLRule, 0, len(rawRules))
+	for _, rawRule := range rawRules {
+		rule := rawRule.(map[string]interface{})
+		ip := rule["ip"].(string)
+		scalewayRanges := rule["scaleway_ranges"].(bool)
+		if (ip == "") == !scalewayRanges {
+			return nil, errors.New("each rule of acl_rules must either have an ip or allow the scaleway_ranges")
+		}
+
+		aclRule := &k8sACLRule{
+			Description: rule["description"].(string),
+		}
+		if scalewayRanges {
+			aclRule.ScalewayRanges = scw.BoolPtr(true)
+		} else {
+			ipNet, err := expandIPNet(ip)
+			if err != nil {
+				return nil, err
+			}
+			aclRule.IP = &ipNet
+		}
+		rules = append(rules, aclRule)
+	}
+
+	return rules, nil
+}
+
+func flattenK8SACLRules(rules []*k8sACLRule) []map[string]interface{} {
+	res := make([]map[string]interface{}, 0, len(rules))
+	for _, rule := range rules {
+		rawRule := map[string]interface{}{
+			"id":              rule.ID,
+			"scaleway_ranges": rule.ScalewayRanges != nil && *rule.ScalewayRanges,
+			"description":     rule.Description,
+		}
+		if rule.IP != nil {
+			rawRule["ip"] = rule.IP.String()
+		}
+		res = append(res, rawRule)
+	}
+
+	return res
+}
@@ -0,0 +1,107 @@
+package scaleway
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccScalewayK8SACL_Basic(t *testing.T) {
+	tt := NewTestToolsWithFakeAPI(t)
+	defer tt.Cleanup()
+
+	clusterConfig := `
+		resource "scaleway_vpc_private_network" "main" {}
+
+		resource "scaleway_k8s_cluster" "main" {
+			name                        = "tf-cluster-acl"
+			version                     = "1.31.2"
+			cni                         = "cilium"
+			private_network_id          = scaleway_vpc_private_network.main.id
+			delete_additional_resources = true
+		}
+	`
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: tt.ProviderFactories,
+		CheckDestroy:             testAccCheckScalewayK8SClusterDestroy(tt),
+		Steps: []resource.TestStep{
+			{
+				Config: clusterConfig + `
+					resource "scaleway_k8s_acl" "main" {
+						cluster_id = scaleway_k8s_cluster.main.id
+						acl_rules {
+							ip          = "1.2.3.0/24"
+							description = "office"
+						}
+						acl_rules {
+							scaleway_ranges = true
+						}
+					}
+				`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair("scaleway_k8s_acl.main", "id", "scaleway_k8s_cluster.main", "id"),
+					resource.TestCheckResourceAttr("scaleway_k8s_acl.main", "acl_rules.#", "2"),
+					resource.TestCheckTypeSetElemNestedAttrs("scaleway_k8s_acl.main", "acl_rules.*", map[string]string{
+						"ip":              "1.2.3.0/24",
+						"scaleway_ranges": "false",
+						"description":     "office",
+					}),
+					resource.TestCheckTypeSetElemNestedAttrs("scaleway_k8s_acl.main", "acl_rules.*", map[string]string{
+						"ip":              "",
+						"scaleway_ranges": "true",
+					}),
+					resource.TestCheckResourceAttr("scaleway_k8s_acl.main", "no_ip_allowed", "false"),
+				),
+			},
+			{
+				ResourceName:      "scaleway_k8s_acl.main",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				Config: clusterConfig + `
+					resource "scaleway_k8s_acl" "main" {
+						cluster_id    = scaleway_k8s_cluster.main.id
+						no_ip_allowed = true
+					}
+				`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("scaleway_k8s_acl.main", "acl_rules.#", "0"),
+					resource.TestCheckResourceAttr("scaleway_k8s_acl.main", "no_ip_allowed", "true"),
+				),
+			},
+			{
+				Config: clusterConfig,
+				Check:  testAccCheckScalewayK8SACLAllowsAll(tt, "scaleway_k8s_cluster.main"),
+			},
+		},
+	})
+}
+
+// testAccCheckScalewayK8SACLAllowsAll checks the cluster allows all the IPs, like once its scaleway_k8s_acl is deleted
+func testAccCheckScalewayK8SACLAllowsAll(tt *TestTools, n string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("resource not found: %s", n)
+		}
+
+		_, region, clusterID, err := k8sAPIWithRegionAndID(tt.Meta, rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+
+		res, err := newK8SACLAPI(tt.Meta.scwClient).ListClusterACLRules(region, clusterID)
+		if err != nil {
+			return err
+		}
+		if len(res.Rules) != 1 || res.Rules[0].IP == nil || res.Rules[0].IP.String() != "0.0.0.0/0" {
+			return fmt.Errorf("cluster %s does not allow all the IPs: %d rules", clusterID, len(res.Rules))
+		}
+
+		return nil
+	}
+}
@@ -654,7 +654,7 @@ func resourceScalewayK8SClusterUpdate(ctx context.Context, d *schema.ResourceDat
 		updateRequest.Description = expandStringPtr(d.Get("description"))
 	}
 
-	if d.HasChange("tags") {
+	if d.HasChanges("tags", "tags_all") {
 		updateRequest.Tags = expandUpdatedStringsPtr(d.Get("tags"))
 	}
 
@@ -125,7 +125,7 @@ func TestAccScalewayK8SCluster_Basic(t *testing.T) {
 		PreCheck: func() {
 			testAccPreCheck(t)
 		},
-		ProviderFactories: tt.ProviderFactories,
+		ProtoV6ProviderFactories: tt.ProviderFactories,
 		CheckDestroy: resource.ComposeTestCheckFunc(
 			testAccCheckScalewayK8SClusterDestroy(tt),
 			testAccCheckScalewayVPCPrivateNetworkDestroy(tt),
@@ -181,7 +181,7 @@ func TestAccScalewayK8SCluster_Autoscaling(t *testing.T) {
 		PreCheck: func() {
 			testAccPreCheck(t)
 		},
-		ProviderFactories: tt.ProviderFactories,
+		ProtoV6ProviderFactories: tt.ProviderFactories,
 		CheckDestroy: resource.ComposeTestCheckFunc(
 			testAccCheckScalewayK8SClusterDestroy(tt),
 			testAccCheckScalewayVPCPrivateNetworkDestroy(tt),
@@ -257,7 +257,7 @@ func TestAccScalewayK8SCluster_OIDC(t *testing.T) {
 		PreCheck: func() {
 			testAccPreCheck(t)
 		},
-		ProviderFactories: tt.ProviderFactories,
+		ProtoV6ProviderFactories: tt.ProviderFactories,
 		CheckDestroy: resource.ComposeTestCheckFunc(
 			testAccCheckScalewayK8SClusterDestroy(tt),
 			testAccCheckScalewayVPCPrivateNetworkDestroy(tt),
@@ -327,7 +327,7 @@ func TestAccScalewayK8SCluster_AutoUpgrade(t *testing.T) {
 		PreCheck: func() {
 			testAccPreCheck(t)
 		},
-		ProviderFactories: tt.ProviderFactories,
+		ProtoV6ProviderFactories: tt.ProviderFactories,
 		CheckDestroy: resource.ComposeTestCheckFunc(
 			testAccCheckScalewayK8SClusterDestroy(tt),
 			testAccCheckScalewayVPCPrivateNetworkDestroy(tt),
@@ -409,7 +409,7 @@ func TestAccScalewayK8SCluster_PrivateNetwork(t *testing.T) {
 		PreCheck: func() {
 			testAccPreCheck(t)
 		},
-		ProviderFactories: tt.ProviderFactories,
+		ProtoV6ProviderFactories: tt.ProviderFactories,
 		CheckDestroy: resource.ComposeTestCheckFunc(
 			testAccCheckScalewayK8SClusterDestroy(tt),
 			testAccCheckScalewayVPCPrivateNetworkDestroy(tt),
@@ -448,8 +448,8 @@ func TestAccScalewayK8SCluster_Multicloud(t *testing.T) {
 		PreCheck: func() {
 			testAccPreCheck(t)
 		},
-		ProviderFactories: tt.ProviderFactories,
-		CheckDestroy:      testAccCheckScalewayK8SClusterDestroy(tt),
+		ProtoV6ProviderFactories: tt.ProviderFactories,
+		CheckDestroy:             testAccCheckScalewayK8SClusterDestroy(tt),
 		Steps: []resource.TestStep{
 			{
 				Config: testAccCheckScalewayK8SClusterMulticloud(latestK8SVersion),
@@ -474,7 +474,7 @@ func TestAccScalewayK8SCluster_TypeChange(t *testing.T) {
 		PreCheck: func() {
 			testAccPreCheck(t)
 		},
-		ProviderFactories: tt.ProviderFactories,
+		ProtoV6ProviderFactories: tt.ProviderFactories,
 		CheckDestroy: resource.ComposeTestCheckFunc(
 			testAccCheckScalewayK8SClusterDestroy(tt),
 			testAccCheckScalewayVPCPrivateNetworkDestroy(tt),
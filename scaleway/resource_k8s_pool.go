@@ -2,6 +2,7 @@ package scaleway
 
 import (
 	"context"
+	"strings"
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
@@ -43,10 +44,15 @@ func resourceScalewayK8SPool() *schema.Resource {
 			"node_type": {
 				Type:             schema.TypeString,
 				Required:         true,
-				ForceNew:         true,
 				Description:      "Server type of the pool servers",
 				DiffSuppressFunc: diffSuppressFuncIgnoreCaseAndHyphen,
 			},
+			"migrate_on_node_type_change": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Migrate the workloads to a new pool when the node type changes, instead of replacing the pool",
+			},
 			"autoscaling": {
 				Type:        schema.TypeBool,
 				Optional:    true,
@@ -117,6 +123,26 @@ func resourceScalewayK8SPool() *schema.Resource {
 				Optional:    true,
 				Description: "The Kubelet arguments to be used by this pool",
 			},
+			"labels": {
+				Type: schema.TypeMap,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+				Optional:    true,
+				Description: "The Kubernetes labels applied and reconciled on the nodes of the pool",
+			},
+			"taints": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Description: "The Kubernetes taints applied and reconciled on the nodes of the pool",
+				Elem:        k8sTaintSchema(),
+			},
+			"startup_taints": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Description: "The Kubernetes taints applied on the nodes of the pool when they are created, they are not reconciled afterwards",
+				Elem:        k8sTaintSchema(),
+			},
 			"upgrade_policy": {
 				Type:        schema.TypeList,
 				MaxItems:    1,
@@ -235,66 +261,7 @@ func resourceScalewayK8SPoolCreate(ctx context.Context, d *schema.ResourceData,
 	////
 	// Create pool
 	////
-	req := &k8s.CreatePoolRequest{
-		Region:           region,
-		ClusterID:        expandID(d.Get("cluster_id")),
-		Name:             expandOrGenerateString(d.Get("name"), "pool"),
-		NodeType:         d.Get("node_type").(string),
-		Autoscaling:      d.Get("autoscaling").(bool),
-		Autohealing:      d.Get("autohealing").(bool),
-		Size:             uint32(d.Get("size").(int)),
-		Tags:             expandStrings(d.Get("tags")),
-		Zone:             scw.Zone(d.Get("zone").(string)),
-		KubeletArgs:      expandKubeletArgs(d.Get("kubelet_args")),
-		PublicIPDisabled: d.Get("public_ip_disabled").(bool),
-	}
-
-	if v, ok := d.GetOk("region"); ok {
-		req.Region = scw.Region(v.(string))
-	}
-
-	if v, ok := d.GetOk("zone"); ok {
-		req.Zone = scw.Zone(v.(string))
-	}
-
-	if placementGroupID, ok := d.GetOk("placement_group_id"); ok {
-		req.PlacementGroupID = expandStringPtr(expandID(placementGroupID))
-	}
-
-	if minSize, ok := d.GetOk("min_size"); ok {
-		req.MinSize = scw.Uint32Ptr(uint32(minSize.(int)))
-	}
-
-	if maxSize, ok := d.GetOk("max_size"); ok {
-		req.MaxSize = scw.Uint32Ptr(uint32(maxSize.(int)))
-	} else {
-		req.MaxSize = scw.Uint32Ptr(req.Size)
-	}
-
-	if containerRuntime, ok := d.GetOk("container_runtime"); ok {
-		req.ContainerRuntime = k8s.Runtime(containerRuntime.(string))
-	}
-
-	upgradePolicyReq := &k8s.CreatePoolRequestUpgradePolicy{}
-
-	if maxSurge, ok := d.GetOk("upgrade_policy.0.max_surge"); ok {
-		req.UpgradePolicy = upgradePolicyReq
-		upgradePolicyReq.MaxSurge = scw.Uint32Ptr(uint32(maxSurge.(int)))
-	}
-
-	if maxUnavailable, ok := d.GetOk("upgrade_policy.0.max_unavailable"); ok {
-		req.UpgradePolicy = upgradePolicyReq
-		upgradePolicyReq.MaxUnavailable = scw.Uint32Ptr(uint32(maxUnavailable.(int)))
-	}
-
-	if volumeType, ok := d.GetOk("root_volume_type"); ok {
-		req.RootVolumeType = k8s.PoolVolumeType(volumeType.(string))
-	}
-
-	if volumeSize, ok := d.GetOk("root_volume_size_in_gb"); ok {
-		volumeSizeInBytes := scw.Size(uint64(volumeSize.(int)) * gb)
-		req.RootVolumeSize = &volumeSizeInBytes
-	}
+	req := expandK8SPoolCreateRequest(d, region)
 
 	// check if the cluster is waiting for a pool
 	cluster, err := k8sAPI.GetCluster(&k8s.GetClusterRequest{
@@ -312,7 +279,7 @@ func resourceScalewayK8SPoolCreate(ctx context.Context, d *schema.ResourceData,
 		}
 	}
 
-	res, err := k8sAPI.CreatePool(req, scw.WithContext(ctx))
+	res, err := newK8SPoolAPI(meta.(*Meta).scwClient).CreatePool(req, scw.WithContext(ctx))
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -340,15 +307,10 @@ func resourceScalewayK8SPoolRead(ctx context.Context, d *schema.ResourceData, me
 		return diag.FromErr(err)
 	}
 
-	req := &k8s.GetPoolRequest{
-		Region: region,
-		PoolID: poolID,
-	}
-
 	////
 	// Read Pool
 	////
-	pool, err := k8sAPI.GetPool(req, scw.WithContext(ctx))
+	pool, err := newK8SPoolAPI(meta.(*Meta).scwClient).GetPool(region, poolID, scw.WithContext(ctx))
 	if err != nil {
 		if is404Error(err) {
 			d.SetId("")
@@ -357,13 +319,16 @@ func resourceScalewayK8SPoolRead(ctx context.Context, d *schema.ResourceData, me
 		return diag.FromErr(err)
 	}
 
-	nodes, err := getNodes(ctx, k8sAPI, pool)
+	nodes, err := getNodes(ctx, k8sAPI, &pool.Pool)
 	if err != nil {
 		return diag.FromErr(err)
 	}
 
 	_ = d.Set("cluster_id", newRegionalIDString(region, pool.ClusterID))
-	_ = d.Set("name", pool.Name)
+	// A pool created by a node type migration keeps the name of the pool it replaced, also when it is imported
+	if pool.Name != d.Get("name").(string) {
+		_ = d.Set("name", k8sPoolNameBeforeMigration(pool.Name, pool.NodeType))
+	}
 	_ = d.Set("node_type", pool.NodeType)
 	_ = d.Set("autoscaling", pool.Autoscaling)
 	_ = d.Set("autohealing", pool.Autohealing)
@@ -385,9 +350,12 @@ func resourceScalewayK8SPoolRead(ctx context.Context, d *schema.ResourceData, me
 	_ = d.Set("nodes", nodes)
 	_ = d.Set("status", pool.Status)
 	_ = d.Set("kubelet_args", flattenKubeletArgs(pool.KubeletArgs))
+	_ = d.Set("labels", flattenMap(pool.Labels))
+	_ = d.Set("taints", flattenK8STaints(pool.Taints))
+	_ = d.Set("startup_taints", flattenK8STaints(pool.StartupTaints))
 	_ = d.Set("region", region)
 	_ = d.Set("zone", pool.Zone)
-	_ = d.Set("upgrade_policy", poolUpgradePolicyFlatten(pool))
+	_ = d.Set("upgrade_policy", poolUpgradePolicyFlatten(&pool.Pool))
 	_ = d.Set("public_ip_disabled", pool.PublicIPDisabled)
 
 	if pool.PlacementGroupID != nil {
@@ -403,6 +371,54 @@ func resourceScalewayK8SPoolUpdate(ctx context.Context, d *schema.ResourceData,
 		return diag.FromErr(err)
 	}
 
+	// node_type only changes in place in migration mode, the pool is replaced otherwise
+	if d.HasChange("node_type") {
+		newPoolID, err := migrateK8SPool(ctx, d, meta.(*Meta), k8sAPI, region, poolID)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		d.SetId(newRegionalIDString(region, newPoolID))
+
+		return resourceScalewayK8SPoolRead(ctx, d, meta)
+	}
+
+	////
+	// Update the labels and taints of the nodes
+	////
+	poolAPI := newK8SPoolAPI(meta.(*Meta).scwClient)
+	nodeConfigChanged := false
+
+	if d.HasChange("labels") {
+		_, err = poolAPI.SetPoolLabels(region, poolID, expandMapStringString(d.Get("labels")), scw.WithContext(ctx))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		nodeConfigChanged = true
+	}
+
+	if d.HasChange("taints") {
+		_, err = poolAPI.SetPoolTaints(region, poolID, expandK8STaints(d.Get("taints")), scw.WithContext(ctx))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		nodeConfigChanged = true
+	}
+
+	if d.HasChange("startup_taints") {
+		_, err = poolAPI.SetPoolStartupTaints(region, poolID, expandK8STaints(d.Get("startup_taints")), scw.WithContext(ctx))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		nodeConfigChanged = true
+	}
+
+	if nodeConfigChanged {
+		_, err = waitK8SPoolReady(ctx, k8sAPI, region, poolID, d.Timeout(schema.TimeoutUpdate))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
 	////
 	// Update Pool
 	////
@@ -431,7 +447,7 @@ func resourceScalewayK8SPoolUpdate(ctx context.Context, d *schema.ResourceData,
 		updateRequest.Size = scw.Uint32Ptr(uint32(d.Get("size").(int)))
 	}
 
-	if d.HasChange("tags") {
+	if d.HasChanges("tags", "tags_all") {
 		updateRequest.Tags = expandUpdatedStringsPtr(d.Get("tags"))
 	}
 
@@ -473,40 +489,224 @@ func resourceScalewayK8SPoolDelete(ctx context.Context, d *schema.ResourceData,
 		return diag.FromErr(err)
 	}
 
-	////
-	// Delete Pool
-	////
-	req := &k8s.DeletePoolRequest{
-		Region: region,
-		PoolID: poolID,
+	err = deleteK8SPool(ctx, k8sAPI, region, poolID)
+	if err != nil {
+		return diag.FromErr(err)
 	}
 
-	_, err = k8sAPI.DeletePool(req, scw.WithContext(ctx))
-	if err != nil {
-		if !is404Error(err) {
-			return diag.FromErr(err)
+	return nil
+}
+
+func resourceScalewayK8SPoolCustomDiff(_ context.Context, diff *schema.ResourceDiff, _ interface{}) error {
+	if diff.HasChange("size") {
+		err := diff.SetNewComputed("nodes")
+		if err != nil {
+			return err
+		}
+	}
+	if diff.Id() != "" && diff.HasChange("node_type") {
+		if !diff.Get("migrate_on_node_type_change").(bool) {
+			return diff.ForceNew("node_type")
+		}
+		err := diff.SetNewComputed("nodes")
+		if err != nil {
+			return err
 		}
 	}
+	return nil
+}
 
-	_, err = k8sAPI.WaitForPool(&k8s.WaitForPoolRequest{
-		PoolID: poolID,
+// expandK8SPoolCreateRequest returns the request creating a pool from its configuration
+func expandK8SPoolCreateRequest(d *schema.ResourceData, region scw.Region) *k8sCreatePoolRequest {
+	req := &k8s.CreatePoolRequest{
+		Region:           region,
+		ClusterID:        expandID(d.Get("cluster_id")),
+		Name:             expandOrGenerateString(d.Get("name"), "pool"),
+		NodeType:         d.Get("node_type").(string),
+		Autoscaling:      d.Get("autoscaling").(bool),
+		Autohealing:      d.Get("autohealing").(bool),
+		Size:             uint32(d.Get("size").(int)),
+		Tags:             expandStrings(d.Get("tags")),
+		Zone:             scw.Zone(d.Get("zone").(string)),
+		KubeletArgs:      expandKubeletArgs(d.Get("kubelet_args")),
+		PublicIPDisabled: d.Get("public_ip_disabled").(bool),
+	}
+
+	if v, ok := d.GetOk("region"); ok {
+		req.Region = scw.Region(v.(string))
+	}
+
+	if v, ok := d.GetOk("zone"); ok {
+		req.Zone = scw.Zone(v.(string))
+	}
+
+	if placementGroupID, ok := d.GetOk("placement_group_id"); ok {
+		req.PlacementGroupID = expandStringPtr(expandID(placementGroupID))
+	}
+
+	if minSize, ok := d.GetOk("min_size"); ok {
+		req.MinSize = scw.Uint32Ptr(uint32(minSize.(int)))
+	}
+
+	if maxSize, ok := d.GetOk("max_size"); ok {
+		req.MaxSize = scw.Uint32Ptr(uint32(maxSize.(int)))
+	} else {
+		req.MaxSize = scw.Uint32Ptr(req.Size)
+	}
+
+	if containerRuntime, ok := d.GetOk("container_runtime"); ok {
+		req.ContainerRuntime = k8s.Runtime(containerRuntime.(string))
+	}
+
+	upgradePolicyReq := &k8s.CreatePoolRequestUpgradePolicy{}
+
+	if maxSurge, ok := d.GetOk("upgrade_policy.0.max_surge"); ok {
+		req.UpgradePolicy = upgradePolicyReq
+		upgradePolicyReq.MaxSurge = scw.Uint32Ptr(uint32(maxSurge.(int)))
+	}
+
+	if maxUnavailable, ok := d.GetOk("upgrade_policy.0.max_unavailable"); ok {
+		req.UpgradePolicy = upgradePolicyReq
+		upgradePolicyReq.MaxUnavailable = scw.Uint32Ptr(uint32(maxUnavailable.(int)))
+	}
+
+	if volumeType, ok := d.GetOk("root_volume_type"); ok {
+		req.RootVolumeType = k8s.PoolVolumeType(volumeType.(string))
+	}
+
+	if volumeSize, ok := d.GetOk("root_volume_size_in_gb"); ok {
+		volumeSizeInBytes := scw.Size(uint64(volumeSize.(int)) * gb)
+		req.RootVolumeSize = &volumeSizeInBytes
+	}
+
+	return &k8sCreatePoolRequest{
+		CreatePoolRequest: req,
+		Labels:            expandMapStringString(d.Get("labels")),
+		Taints:            expandK8STaints(d.Get("taints")),
+		StartupTaints:     expandK8STaints(d.Get("startup_taints")),
+	}
+}
+
+// k8sPoolMigrationName returns the name of the pool replacing the given one during a node type migration, as pool names are unique in a cluster
+func k8sPoolMigrationName(name string, nodeType string) string {
+	return name + "-" + strings.ToLower(strings.ReplaceAll(nodeType, "_", "-"))
+}
+
+// k8sPoolNameBeforeMigration returns the name of the pool replaced by a node type migration, the name of the given pool without its node type suffix
+func k8sPoolNameBeforeMigration(name string, nodeType string) string {
+	return strings.TrimSuffix(name, k8sPoolMigrationName("", nodeType))
+}
+
+// migrateK8SPool creates a pool with the new node type, drains the nodes of the current pool so their workloads move to it,
+// then deletes the current pool. It returns the ID of the new pool.
+// A pool left by an interrupted migration is reused.
+func migrateK8SPool(ctx context.Context, d *schema.ResourceData, meta *Meta, k8sAPI *k8s.API, region scw.Region, poolID string) (string, error) {
+	timeout := d.Timeout(schema.TimeoutUpdate)
+
+	oldPool, err := k8sAPI.GetPool(&k8s.GetPoolRequest{
 		Region: region,
+		PoolID: poolID,
 	}, scw.WithContext(ctx))
 	if err != nil {
-		if !is404Error(err) {
-			return diag.FromErr(err)
+		return "", err
+	}
+
+	poolAPI := newK8SPoolAPI(meta.scwClient)
+	req := expandK8SPoolCreateRequest(d, region)
+	req.ClusterID = oldPool.ClusterID
+	req.Name = k8sPoolMigrationName(d.Get("name").(string), req.NodeType)
+
+	pools, err := k8sAPI.ListPools(&k8s.ListPoolsRequest{
+		Region:    region,
+		ClusterID: oldPool.ClusterID,
+		Name:      &req.Name,
+	}, scw.WithAllPages(), scw.WithContext(ctx))
+	if err != nil {
+		return "", err
+	}
+
+	newPoolID := ""
+	for _, pool := range pools.Pools {
+		if pool.Name == req.Name && pool.ID != oldPool.ID {
+			newPoolID = pool.ID
 		}
 	}
 
-	return nil
-}
+	if newPoolID == "" {
+		_, err = waitK8SCluster(ctx, k8sAPI, region, oldPool.ClusterID, timeout)
+		if err != nil {
+			return "", err
+		}
 
-func resourceScalewayK8SPoolCustomDiff(_ context.Context, diff *schema.ResourceDiff, _ interface{}) error {
-	if diff.HasChange("size") {
-		err := diff.SetNewComputed("nodes")
+		newPool, err := poolAPI.CreatePool(req, scw.WithContext(ctx))
 		if err != nil {
-			return err
+			return "", err
 		}
+		newPoolID = newPool.ID
+	}
+
+	_, err = waitK8SPoolReady(ctx, k8sAPI, region, newPoolID, timeout)
+	if err != nil {
+		return "", err
 	}
+
+	kubeClient, err := newK8SKubeClient(ctx, meta, k8sAPI, region, oldPool.ClusterID)
+	if err != nil {
+		return "", err
+	}
+
+	nodes, err := k8sAPI.ListNodes(&k8s.ListNodesRequest{
+		Region:    region,
+		ClusterID: oldPool.ClusterID,
+		PoolID:    &oldPool.ID,
+	}, scw.WithAllPages(), scw.WithContext(ctx))
+	if err != nil {
+		return "", err
+	}
+
+	for _, node := range nodes.Nodes {
+		err = kubeClient.drainNode(ctx, node.Name, timeout)
+		if err != nil {
+			return "", err
+		}
+	}
+
+	err = deleteK8SPool(ctx, k8sAPI, region, oldPool.ID)
+	if err != nil {
+		return "", err
+	}
+
+	_, err = waitK8SCluster(ctx, k8sAPI, region, oldPool.ClusterID, timeout)
+	if err != nil {
+		return "", err
+	}
+
+	return newPoolID, nil
+}
+
+// deleteK8SPool deletes the pool and waits for its deletion, a pool which does not exist is ignored
+func deleteK8SPool(ctx context.Context, k8sAPI *k8s.API, region scw.Region, poolID string) error {
+	_, err := k8sAPI.DeletePool(&k8s.DeletePoolRequest{
+		Region: region,
+		PoolID: poolID,
+	}, scw.WithContext(ctx))
+	if err != nil && !is404Error(err) {
+		return err
+	}
+
+	retryInterval := defaultK8SRetryInterval
+	if DefaultWaitRetryInterval != nil {
+		retryInterval = *DefaultWaitRetryInterval
+	}
+
+	_, err = k8sAPI.WaitForPool(&k8s.WaitForPoolRequest{
+		PoolID:        poolID,
+		Region:        region,
+		RetryInterval: &retryInterval,
+	}, scw.WithContext(ctx))
+	if err != nil && !is404Error(err) {
+		return err
+	}
+
 	return nil
 }
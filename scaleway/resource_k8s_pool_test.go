@@ -19,8 +19,8 @@ func TestAccScalewayK8SCluster_PoolBasic(t *testing.T) {
 	latestK8SVersion := testAccScalewayK8SClusterGetLatestK8SVersion(tt)
 
 	resource.ParallelTest(t, resource.TestCase{
-		PreCheck:          func() { testAccPreCheck(t) },
-		ProviderFactories: tt.ProviderFactories,
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: tt.ProviderFactories,
 		CheckDestroy: resource.ComposeTestCheckFunc(
 			testAccCheckScalewayK8SPoolDestroy(tt, "scaleway_k8s_pool.default"),
 			testAccCheckScalewayK8SPoolDestroy(tt, "scaleway_k8s_pool.minimal"),
@@ -81,8 +81,8 @@ func TestAccScalewayK8SCluster_PoolWait(t *testing.T) {
 	defer tt.Cleanup()
 	latestK8SVersion := testAccScalewayK8SClusterGetLatestK8SVersion(tt)
 	resource.ParallelTest(t, resource.TestCase{
-		PreCheck:          func() { testAccPreCheck(t) },
-		ProviderFactories: tt.ProviderFactories,
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: tt.ProviderFactories,
 		CheckDestroy: resource.ComposeTestCheckFunc(
 			testAccCheckScalewayK8SPoolDestroy(tt, "scaleway_k8s_pool.default"),
 			testAccCheckScalewayK8SPoolDestroy(tt, "scaleway_k8s_pool.minimal"),
@@ -162,8 +162,8 @@ func TestAccScalewayK8SCluster_PoolPlacementGroup(t *testing.T) {
 	latestK8SVersion := testAccScalewayK8SClusterGetLatestK8SVersion(tt)
 
 	resource.ParallelTest(t, resource.TestCase{
-		PreCheck:          func() { testAccPreCheck(t) },
-		ProviderFactories: tt.ProviderFactories,
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: tt.ProviderFactories,
 		CheckDestroy: resource.ComposeTestCheckFunc(
 			testAccCheckScalewayK8SPoolDestroy(tt, "scaleway_k8s_pool.placement_group"),
 			testAccCheckScalewayK8SPoolDestroy(tt, "scaleway_k8s_pool.placement_group_2"),
@@ -219,8 +219,8 @@ func TestAccScalewayK8SCluster_PoolUpgradePolicy(t *testing.T) {
 	latestK8SVersion := testAccScalewayK8SClusterGetLatestK8SVersion(tt)
 
 	resource.ParallelTest(t, resource.TestCase{
-		PreCheck:          func() { testAccPreCheck(t) },
-		ProviderFactories: tt.ProviderFactories,
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: tt.ProviderFactories,
 		CheckDestroy: resource.ComposeTestCheckFunc(
 			testAccCheckScalewayK8SPoolDestroy(tt, "scaleway_k8s_pool.upgrade_policy"),
 			testAccCheckScalewayK8SClusterDestroy(tt),
@@ -274,8 +274,8 @@ func TestAccScalewayK8SCluster_PoolKubeletArgs(t *testing.T) {
 	latestK8SVersion := testAccScalewayK8SClusterGetLatestK8SVersion(tt)
 
 	resource.ParallelTest(t, resource.TestCase{
-		PreCheck:          func() { testAccPreCheck(t) },
-		ProviderFactories: tt.ProviderFactories,
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: tt.ProviderFactories,
 		CheckDestroy: resource.ComposeTestCheckFunc(
 			testAccCheckScalewayK8SPoolDestroy(tt, "scaleway_k8s_pool.kubelet_args"),
 			testAccCheckScalewayK8SClusterDestroy(tt),
@@ -313,8 +313,8 @@ func TestAccScalewayK8SCluster_PoolZone(t *testing.T) {
 	latestK8SVersion := testAccScalewayK8SClusterGetLatestK8SVersion(tt)
 
 	resource.ParallelTest(t, resource.TestCase{
-		PreCheck:          func() { testAccPreCheck(t) },
-		ProviderFactories: tt.ProviderFactories,
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: tt.ProviderFactories,
 		CheckDestroy: resource.ComposeTestCheckFunc(
 			testAccCheckScalewayK8SPoolDestroy(tt, "scaleway_k8s_pool.zone"),
 			testAccCheckScalewayK8SClusterDestroy(tt),
@@ -342,8 +342,8 @@ func TestAccScalewayK8SCluster_PoolSize(t *testing.T) {
 	latestK8SVersionMinor := testAccScalewayK8SClusterGetLatestK8SVersionMinor(tt)
 
 	resource.ParallelTest(t, resource.TestCase{
-		PreCheck:          func() { testAccPreCheck(t) },
-		ProviderFactories: tt.ProviderFactories,
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: tt.ProviderFactories,
 		CheckDestroy: resource.ComposeTestCheckFunc(
 			testAccCheckScalewayK8SPoolDestroy(tt, "scaleway_k8s_pool.pool"),
 			testAccCheckScalewayK8SClusterDestroy(tt),
@@ -419,8 +419,8 @@ func TestAccScalewayK8SCluster_PoolPublicIPDisabled(t *testing.T) {
 	latestK8SVersion := testAccScalewayK8SClusterGetLatestK8SVersion(tt)
 
 	resource.ParallelTest(t, resource.TestCase{
-		PreCheck:          func() { testAccPreCheck(t) },
-		ProviderFactories: tt.ProviderFactories,
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: tt.ProviderFactories,
 		CheckDestroy: resource.ComposeTestCheckFunc(
 			testAccCheckScalewayK8SPoolDestroy(tt, "scaleway_k8s_pool.public_ip"),
 			testAccCheckScalewayK8SClusterDestroy(tt),
@@ -517,6 +517,181 @@ func TestAccScalewayK8SCluster_PoolPublicIPDisabled(t *testing.T) {
 	})
 }
 
+func TestAccScalewayK8SCluster_PoolNodeTypeMigration(t *testing.T) {
+	tt := NewTestToolsWithFakeAPI(t)
+	defer tt.Cleanup()
+
+	config := func(nodeType string) string {
+		return fmt.Sprintf(`
+			resource "scaleway_vpc_private_network" "migration" {}
+
+			resource "scaleway_k8s_cluster" "migration" {
+			  name                        = "test-k8s-migration"
+			  version                     = "1.31.2"
+			  cni                         = "cilium"
+			  private_network_id          = scaleway_vpc_private_network.migration.id
+			  delete_additional_resources = true
+			}
+
+			resource "scaleway_k8s_pool" "migration" {
+			  cluster_id                  = scaleway_k8s_cluster.migration.id
+			  name                        = "test-k8s-migration"
+			  node_type                   = "%s"
+			  size                        = 2
+			  migrate_on_node_type_change = true
+			}`, nodeType)
+	}
+
+	var poolID string
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: tt.ProviderFactories,
+		CheckDestroy: resource.ComposeTestCheckFunc(
+			testAccCheckScalewayK8SPoolDestroy(tt, "scaleway_k8s_pool.migration"),
+			testAccCheckScalewayK8SClusterDestroy(tt),
+		),
+		Steps: []resource.TestStep{
+			{
+				Config: config("DEV1-M"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckScalewayK8SPoolExists(tt, "scaleway_k8s_pool.migration"),
+					resource.TestCheckResourceAttr("scaleway_k8s_pool.migration", "node_type", "DEV1-M"),
+					func(s *terraform.State) error {
+						poolID = s.RootModule().Resources["scaleway_k8s_pool.migration"].Primary.ID
+						return nil
+					},
+				),
+			},
+			{
+				Config: config("GP1-S"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckScalewayK8SPoolExists(tt, "scaleway_k8s_pool.migration"),
+					resource.TestCheckResourceAttr("scaleway_k8s_pool.migration", "name", "test-k8s-migration"),
+					resource.TestCheckResourceAttr("scaleway_k8s_pool.migration", "node_type", "GP1-S"),
+					resource.TestCheckResourceAttr("scaleway_k8s_pool.migration", "nodes.#", "2"),
+					testAccCheckScalewayK8SPoolMigrated(tt, "scaleway_k8s_cluster.migration", "scaleway_k8s_pool.migration", &poolID),
+				),
+			},
+			{
+				ResourceName:            "scaleway_k8s_pool.migration",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"wait_for_pool_ready", "migrate_on_node_type_change"},
+			},
+		},
+	})
+}
+
+func TestAccScalewayK8SCluster_PoolLabelsAndTaints(t *testing.T) {
+	tt := NewTestToolsWithFakeAPI(t)
+	defer tt.Cleanup()
+
+	clusterConfig := `
+		resource "scaleway_vpc_private_network" "taints" {}
+
+		resource "scaleway_k8s_cluster" "taints" {
+		  name                        = "test-k8s-taints"
+		  version                     = "1.31.2"
+		  cni                         = "cilium"
+		  private_network_id          = scaleway_vpc_private_network.taints.id
+		  delete_additional_resources = true
+		}
+	`
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: tt.ProviderFactories,
+		CheckDestroy: resource.ComposeTestCheckFunc(
+			testAccCheckScalewayK8SPoolDestroy(tt, "scaleway_k8s_pool.taints"),
+			testAccCheckScalewayK8SClusterDestroy(tt),
+		),
+		Steps: []resource.TestStep{
+			{
+				Config: clusterConfig + `
+					resource "scaleway_k8s_pool" "taints" {
+					  cluster_id = scaleway_k8s_cluster.taints.id
+					  name       = "test-k8s-taints"
+					  node_type  = "DEV1-M"
+					  size       = 1
+					  labels = {
+					    team = "data"
+					    env  = "prod"
+					  }
+					  taints {
+					    key    = "dedicated"
+					    value  = "gpu"
+					    effect = "NoExecute"
+					  }
+					  taints {
+					    key = "spot"
+					  }
+					  startup_taints {
+					    key   = "node.cilium.io/agent-not-ready"
+					    value = "true"
+					  }
+					}`,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckScalewayK8SPoolExists(tt, "scaleway_k8s_pool.taints"),
+					resource.TestCheckResourceAttr("scaleway_k8s_pool.taints", "labels.%", "2"),
+					resource.TestCheckResourceAttr("scaleway_k8s_pool.taints", "labels.team", "data"),
+					resource.TestCheckResourceAttr("scaleway_k8s_pool.taints", "labels.env", "prod"),
+					resource.TestCheckResourceAttr("scaleway_k8s_pool.taints", "taints.#", "2"),
+					resource.TestCheckTypeSetElemNestedAttrs("scaleway_k8s_pool.taints", "taints.*", map[string]string{
+						"key":    "dedicated",
+						"value":  "gpu",
+						"effect": "NoExecute",
+					}),
+					resource.TestCheckTypeSetElemNestedAttrs("scaleway_k8s_pool.taints", "taints.*", map[string]string{
+						"key":    "spot",
+						"value":  "",
+						"effect": "NoSchedule",
+					}),
+					resource.TestCheckResourceAttr("scaleway_k8s_pool.taints", "startup_taints.#", "1"),
+					resource.TestCheckTypeSetElemNestedAttrs("scaleway_k8s_pool.taints", "startup_taints.*", map[string]string{
+						"key":    "node.cilium.io/agent-not-ready",
+						"value":  "true",
+						"effect": "NoSchedule",
+					}),
+				),
+			},
+			{
+				ResourceName:            "scaleway_k8s_pool.taints",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"wait_for_pool_ready", "migrate_on_node_type_change"},
+			},
+			{
+				Config: clusterConfig + `
+					resource "scaleway_k8s_pool" "taints" {
+					  cluster_id = scaleway_k8s_cluster.taints.id
+					  name       = "test-k8s-taints"
+					  node_type  = "DEV1-M"
+					  size       = 1
+					  labels = {
+					    team = "ml"
+					  }
+					  taints {
+					    key    = "dedicated"
+					    value  = "gpu"
+					    effect = "PreferNoSchedule"
+					  }
+					}`,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckScalewayK8SPoolExists(tt, "scaleway_k8s_pool.taints"),
+					resource.TestCheckResourceAttr("scaleway_k8s_pool.taints", "labels.%", "1"),
+					resource.TestCheckResourceAttr("scaleway_k8s_pool.taints", "labels.team", "ml"),
+					resource.TestCheckResourceAttr("scaleway_k8s_pool.taints", "taints.#", "1"),
+					resource.TestCheckTypeSetElemNestedAttrs("scaleway_k8s_pool.taints", "taints.*", map[string]string{
+						"key":    "dedicated",
+						"value":  "gpu",
+						"effect": "PreferNoSchedule",
+					}),
+					resource.TestCheckResourceAttr("scaleway_k8s_pool.taints", "startup_taints.#", "0"),
+				),
+			},
+		},
+	})
+}
+
 func testAccCheckScalewayK8SPoolServersAreInPrivateNetwork(tt *TestTools, clusterTFName, poolTFName, pnTFName string) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		rs, ok := s.RootModule().Resources[clusterTFName]
@@ -674,6 +849,50 @@ func testAccCheckScalewayK8SPoolDestroy(tt *TestTools, n string) resource.TestCh
 	}
 }
 
+// testAccCheckScalewayK8SPoolMigrated checks the pool replaced the previous one, whose pods were evicted to the nodes of the new pool
+func testAccCheckScalewayK8SPoolMigrated(tt *TestTools, clusterTFName, poolTFName string, previousPoolID *string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs := s.RootModule().Resources[poolTFName]
+		if rs.Primary.ID == *previousPoolID {
+			return fmt.Errorf("pool %s was not replaced", *previousPoolID)
+		}
+
+		k8sAPI, region, previousID, err := k8sAPIWithRegionAndID(tt.Meta, *previousPoolID)
+		if err != nil {
+			return err
+		}
+		_, err = k8sAPI.GetPool(&k8s.GetPoolRequest{
+			Region: region,
+			PoolID: previousID,
+		})
+		if err == nil {
+			return fmt.Errorf("previous pool %s still exists", *previousPoolID)
+		}
+		if !is404Error(err) {
+			return err
+		}
+
+		clusterID := expandID(s.RootModule().Resources[clusterTFName].Primary.ID)
+		if evictedPods := tt.FakeAPI.K8SEvictedPods(clusterID); len(evictedPods) == 0 {
+			return fmt.Errorf("no pod was evicted from the nodes of pool %s", *previousPoolID)
+		}
+
+		nodeNames := map[string]bool{}
+		for key, value := range rs.Primary.Attributes {
+			if strings.HasPrefix(key, "nodes.") && strings.HasSuffix(key, ".name") {
+				nodeNames[value] = true
+			}
+		}
+		for pod, nodeName := range tt.FakeAPI.K8SPodNodes(clusterID) {
+			if !nodeNames[nodeName] {
+				return fmt.Errorf("pod %s runs on node %q, which is not a node of the pool", pod, nodeName)
+			}
+		}
+
+		return nil
+	}
+}
+
 func testAccCheckScalewayK8SPoolExists(tt *TestTools, n string) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		rs, ok := s.RootModule().Resources[n]
@@ -50,9 +50,9 @@ func TestAccScalewayMNQNatsAccount_Basic(t *testing.T) {
 	defer tt.Cleanup()
 
 	resource.ParallelTest(t, resource.TestCase{
-		PreCheck:          func() { testAccPreCheck(t) },
-		ProviderFactories: tt.ProviderFactories,
-		CheckDestroy:      testAccCheckScalewayMNQNatsAccountDestroy(tt),
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: tt.ProviderFactories,
+		CheckDestroy:             testAccCheckScalewayMNQNatsAccountDestroy(tt),
 		Steps: []resource.TestStep{
 			{
 				Config: `
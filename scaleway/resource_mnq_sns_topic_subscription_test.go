@@ -16,9 +16,9 @@ func TestAccScalewayMNQSNSTopicSubscription_Basic(t *testing.T) {
 	defer tt.Cleanup()
 
 	resource.ParallelTest(t, resource.TestCase{
-		PreCheck:          func() { testAccPreCheck(t) },
-		ProviderFactories: tt.ProviderFactories,
-		CheckDestroy:      testAccCheckScalewayMNQSNSTopicSubscriptionDestroy(tt),
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: tt.ProviderFactories,
+		CheckDestroy:             testAccCheckScalewayMNQSNSTopicSubscriptionDestroy(tt),
 		Steps: []resource.TestStep{
 			{
 				Config: `
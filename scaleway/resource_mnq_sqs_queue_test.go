@@ -8,8 +8,8 @@ import (
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/sqs"
 	"github.com/hashicorp/aws-sdk-go-base/tfawserr"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 	accountV3 "github.com/scaleway/scaleway-sdk-go/api/account/v3"
 	mnq "github.com/scaleway/scaleway-sdk-go/api/mnq/v1beta1"
@@ -22,9 +22,9 @@ func TestAccScalewayMNQSQSQueue_Basic(t *testing.T) {
 	defer tt.Cleanup()
 
 	resource.ParallelTest(t, resource.TestCase{
-		PreCheck:          func() { testAccPreCheck(t) },
-		ProviderFactories: tt.ProviderFactories,
-		CheckDestroy:      testAccCheckScalewayMNQSQSQueueDestroy(tt),
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: tt.ProviderFactories,
+		CheckDestroy:             testAccCheckScalewayMNQSQSQueueDestroy(tt),
 		Steps: []resource.TestStep{
 			{
 				Config: `
@@ -111,16 +111,16 @@ func TestAccScalewayMNQSQSQueue_DefaultProject(t *testing.T) {
 
 	resource.ParallelTest(t, resource.TestCase{
 		PreCheck: func() { testAccPreCheck(t) },
-		ProviderFactories: func() map[string]func() (*schema.Provider, error) {
+		ProtoV6ProviderFactories: func() map[string]func() (tfprotov6.ProviderServer, error) {
 			metaProd, err := buildMeta(ctx, &metaConfig{
 				terraformVersion: "terraform-tests",
 				httpClient:       tt.Meta.httpClient,
 			})
 			require.NoError(t, err)
 
-			return map[string]func() (*schema.Provider, error){
-				"scaleway": func() (*schema.Provider, error) {
-					return Provider(&ProviderConfig{Meta: metaProd})(), nil
+			return map[string]func() (tfprotov6.ProviderServer, error){
+				"scaleway": func() (tfprotov6.ProviderServer, error) {
+					return newProviderServer(ctx, metaProd)
 				},
 			}
 		}(),
@@ -235,7 +235,7 @@ func resourceScalewayObjectUpdate(ctx context.Context, d *schema.ResourceData, m
 		}
 	}
 
-	if d.HasChange("tags") {
+	if d.HasChanges("tags", "tags_all") {
 		_, err := s3Client.PutObjectTaggingWithContext(ctx, &s3.PutObjectTaggingInput{
 			Bucket: expandStringPtr(bucketUpdated),
 			Key:    expandStringPtr(key),
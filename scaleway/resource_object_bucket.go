@@ -3,7 +3,6 @@ package scaleway
 import (
 	"context"
 	"fmt"
-	"log"
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/s3"
@@ -288,7 +287,7 @@ func resourceScalewayObjectBucketUpdate(ctx context.Context, d *schema.ResourceD
 		}
 	}
 
-	if d.HasChange("tags") {
+	if d.HasChanges("tags", "tags_all") {
 		tagsSet := expandObjectBucketTags(d.Get("tags"))
 
 		if len(tagsSet) > 0 {
@@ -561,7 +560,7 @@ func resourceScalewayObjectBucketRead(ctx context.Context, d *schema.ResourceDat
 		lifecycleRules = make([]map[string]interface{}, 0, len(lifecycle.Rules))
 
 		for _, lifecycleRule := range lifecycle.Rules {
-			log.Printf("[DEBUG] SCW bucket: %s, read lifecycle rule: %v", d.Id(), lifecycleRule)
+			tflog.Debug(ctx, fmt.Sprintf("SCW bucket: %s, read lifecycle rule: %v", d.Id(), lifecycleRule))
 			rule := make(map[string]interface{})
 
 			// ID
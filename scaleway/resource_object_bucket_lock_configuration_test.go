@@ -25,9 +25,9 @@ func TestAccScalewayObjectBucketLockConfiguration_Basic(t *testing.T) {
 	defer tt.Cleanup()
 
 	resource.ParallelTest(t, resource.TestCase{
-		PreCheck:          func() { testAccPreCheck(t) },
-		ErrorCheck:        ErrorCheck(t, EndpointsID),
-		ProviderFactories: tt.ProviderFactories,
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ErrorCheck:               ErrorCheck(t, EndpointsID),
+		ProtoV6ProviderFactories: tt.ProviderFactories,
 		CheckDestroy: resource.ComposeTestCheckFunc(
 			testAccCheckScalewayBucketLockConfigurationDestroy(tt),
 			testAccCheckScalewayObjectBucketDestroy(tt),
@@ -124,9 +124,9 @@ func TestAccScalewayObjectBucketLockConfiguration_Update(t *testing.T) {
 	defer tt.Cleanup()
 
 	resource.ParallelTest(t, resource.TestCase{
-		PreCheck:          func() { testAccPreCheck(t) },
-		ErrorCheck:        ErrorCheck(t, EndpointsID),
-		ProviderFactories: tt.ProviderFactories,
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ErrorCheck:               ErrorCheck(t, EndpointsID),
+		ProtoV6ProviderFactories: tt.ProviderFactories,
 		CheckDestroy: resource.ComposeTestCheckFunc(
 			testAccCheckScalewayBucketLockConfigurationDestroy(tt),
 			testAccCheckScalewayObjectBucketDestroy(tt),
@@ -218,9 +218,9 @@ func TestAccScalewayObjectBucketLockConfiguration_WithBucketName(t *testing.T) {
 	defer tt.Cleanup()
 
 	resource.ParallelTest(t, resource.TestCase{
-		PreCheck:          func() { testAccPreCheck(t) },
-		ErrorCheck:        ErrorCheck(t, EndpointsID),
-		ProviderFactories: tt.ProviderFactories,
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ErrorCheck:               ErrorCheck(t, EndpointsID),
+		ProtoV6ProviderFactories: tt.ProviderFactories,
 		CheckDestroy: resource.ComposeTestCheckFunc(
 			testAccCheckScalewayBucketLockConfigurationDestroy(tt),
 			testAccCheckScalewayObjectBucketDestroy(tt),
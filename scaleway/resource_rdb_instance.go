@@ -86,10 +86,25 @@ func resourceScalewayRdbInstance() *schema.Resource {
 				Description: "Identifier for the first user of the database instance",
 			},
 			"password": {
-				Type:        schema.TypeString,
-				Sensitive:   true,
-				Optional:    true,
-				Description: "Password for the first user of the database instance",
+				Type:          schema.TypeString,
+				Sensitive:     true,
+				Optional:      true,
+				ConflictsWith: []string{"password_wo"},
+				Description:   "Password for the first user of the database instance",
+			},
+			"password_wo": {
+				Type:          schema.TypeString,
+				Sensitive:     true,
+				Optional:      true,
+				WriteOnly:     true,
+				ConflictsWith: []string{"password"},
+				Description:   "Password for the first user of the database instance, write-only and never stored in the state",
+			},
+			"password_wo_version": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				RequiredWith: []string{"password_wo"},
+				Description:  "Version of the write-only password, change it to update the password",
 			},
 			"settings": {
 				Type: schema.TypeMap,
@@ -285,6 +300,11 @@ func resourceScalewayRdbInstanceCreate(ctx context.Context, d *schema.ResourceDa
 		return diag.FromErr(err)
 	}
 
+	password, diags := expandPassword(d)
+	if diags.HasError() {
+		return diags
+	}
+
 	createReq := &rdb.CreateInstanceRequest{
 		Region:        region,
 		ProjectID:     expandStringPtr(d.Get("project_id")),
@@ -294,7 +314,7 @@ func resourceScalewayRdbInstanceCreate(ctx context.Context, d *schema.ResourceDa
 		IsHaCluster:   d.Get("is_ha_cluster").(bool),
 		DisableBackup: d.Get("disable_backup").(bool),
 		UserName:      d.Get("user_name").(string),
-		Password:      d.Get("password").(string),
+		Password:      password,
 		VolumeType:    rdb.VolumeType(d.Get("volume_type").(string)),
 	}
 
@@ -622,7 +642,7 @@ func resourceScalewayRdbInstanceUpdate(ctx context.Context, d *schema.ResourceDa
 	if d.HasChange("backup_same_region") {
 		req.BackupSameRegion = expandBoolPtr(d.Get("backup_same_region"))
 	}
-	if d.HasChange("tags") {
+	if d.HasChanges("tags", "tags_all") {
 		req.Tags = expandUpdatedStringsPtr(d.Get("tags"))
 	}
 
@@ -657,7 +677,11 @@ func resourceScalewayRdbInstanceUpdate(ctx context.Context, d *schema.ResourceDa
 	////////////////////
 	// Update user
 	////////////////////
-	if d.HasChange("password") {
+	password, diags := expandUpdatedPassword(d)
+	if diags.HasError() {
+		return diags
+	}
+	if password != nil {
 		_, err := waitForRDBInstance(ctx, rdbAPI, region, ID, d.Timeout(schema.TimeoutUpdate))
 		if err != nil {
 			return diag.FromErr(err)
@@ -667,7 +691,7 @@ func resourceScalewayRdbInstanceUpdate(ctx context.Context, d *schema.ResourceDa
 			Region:     region,
 			InstanceID: ID,
 			Name:       d.Get("user_name").(string),
-			Password:   expandStringPtr(d.Get("password")),
+			Password:   password,
 		}
 
 		_, err = rdbAPI.UpdateUser(req, scw.WithContext(ctx))
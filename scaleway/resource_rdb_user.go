@@ -45,10 +45,24 @@ func resourceScalewayRdbUser() *schema.Resource {
 				ForceNew:    true,
 			},
 			"password": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Sensitive:    true,
+				ExactlyOneOf: []string{"password", "password_wo"},
+				Description:  "Database user password",
+			},
+			"password_wo": {
 				Type:        schema.TypeString,
-				Required:    true,
+				Optional:    true,
 				Sensitive:   true,
-				Description: "Database user password",
+				WriteOnly:   true,
+				Description: "Database user password, write-only and never stored in the state",
+			},
+			"password_wo_version": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				RequiredWith: []string{"password_wo"},
+				Description:  "Version of the write-only password, change it to update the password",
 			},
 			"is_admin": {
 				Type:        schema.TypeBool,
@@ -76,11 +90,16 @@ func resourceScalewayRdbUserCreate(ctx context.Context, d *schema.ResourceData,
 		return diag.FromErr(err)
 	}
 
+	password, diags := expandPassword(d)
+	if diags.HasError() {
+		return diags
+	}
+
 	createReq := &rdb.CreateUserRequest{
 		Region:     region,
 		InstanceID: ins.ID,
 		Name:       d.Get("name").(string),
-		Password:   d.Get("password").(string),
+		Password:   password,
 		IsAdmin:    d.Get("is_admin").(bool),
 	}
 
@@ -175,16 +194,20 @@ func resourceScalewayRdbUserUpdate(ctx context.Context, d *schema.ResourceData,
 		Name:       userName,
 	}
 
-	if d.HasChange("password") {
-		req.Password = expandStringPtr(d.Get("password"))
+	password, diags := expandUpdatedPassword(d)
+	if diags.HasError() {
+		return diags
 	}
+	req.Password = password
 	if d.HasChange("is_admin") {
 		req.IsAdmin = scw.BoolPtr(d.Get("is_admin").(bool))
 	}
 
-	_, err = rdbAPI.UpdateUser(req, scw.WithContext(ctx))
-	if err != nil {
-		return diag.FromErr(err)
+	if req.Password != nil || req.IsAdmin != nil {
+		_, err = rdbAPI.UpdateUser(req, scw.WithContext(ctx))
+		if err != nil {
+			return diag.FromErr(err)
+		}
 	}
 
 	return resourceScalewayRdbUserRead(ctx, d, meta)
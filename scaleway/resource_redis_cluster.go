@@ -54,10 +54,24 @@ func resourceScalewayRedisCluster() *schema.Resource {
 				Description: "Name of the user created when the cluster is created",
 			},
 			"password": {
+				Type:         schema.TypeString,
+				Sensitive:    true,
+				Optional:     true,
+				ExactlyOneOf: []string{"password", "password_wo"},
+				Description:  "Password of the user",
+			},
+			"password_wo": {
 				Type:        schema.TypeString,
 				Sensitive:   true,
-				Required:    true,
-				Description: "Password of the user",
+				Optional:    true,
+				WriteOnly:   true,
+				Description: "Password of the user, write-only and never stored in the state",
+			},
+			"password_wo_version": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				RequiredWith: []string{"password_wo"},
+				Description:  "Version of the write-only password, change it to update the password",
 			},
 			"tags": {
 				Type:     schema.TypeList,
@@ -213,6 +227,11 @@ func resourceScalewayRedisClusterCreate(ctx context.Context, d *schema.ResourceD
 		return diag.FromErr(err)
 	}
 
+	password, diags := expandPassword(d)
+	if diags.HasError() {
+		return diags
+	}
+
 	createReq := &redis.CreateClusterRequest{
 		Zone:      zone,
 		ProjectID: d.Get("project_id").(string),
@@ -220,7 +239,7 @@ func resourceScalewayRedisClusterCreate(ctx context.Context, d *schema.ResourceD
 		Version:   d.Get("version").(string),
 		NodeType:  d.Get("node_type").(string),
 		UserName:  d.Get("user_name").(string),
-		Password:  d.Get("password").(string),
+		Password:  password,
 	}
 
 	tags, tagsExist := d.GetOk("tags")
@@ -354,10 +373,12 @@ func resourceScalewayRedisClusterUpdate(ctx context.Context, d *schema.ResourceD
 	if d.HasChange("user_name") {
 		req.UserName = expandStringPtr(d.Get("user_name"))
 	}
-	if d.HasChange("password") {
-		req.Password = expandStringPtr(d.Get("password"))
+	password, diags := expandUpdatedPassword(d)
+	if diags.HasError() {
+		return diags
 	}
-	if d.HasChange("tags") {
+	req.Password = password
+	if d.HasChanges("tags", "tags_all") {
 		req.Tags = expandUpdatedStringsPtr(d.Get("tags"))
 	}
 	if d.HasChange("acl") {
@@ -378,9 +399,11 @@ func resourceScalewayRedisClusterUpdate(ctx context.Context, d *schema.ResourceD
 		return diag.FromErr(err)
 	}
 
-	_, err = redisAPI.UpdateCluster(req, scw.WithContext(ctx))
-	if err != nil {
-		return diag.FromErr(err)
+	if req.Name != nil || req.UserName != nil || req.Password != nil || req.Tags != nil {
+		_, err = redisAPI.UpdateCluster(req, scw.WithContext(ctx))
+		if err != nil {
+			return diag.FromErr(err)
+		}
 	}
 
 	migrateClusterRequests := []redis.MigrateClusterRequest(nil)
@@ -156,7 +156,7 @@ func resourceScalewaySecretUpdate(ctx context.Context, d *schema.ResourceData, m
 		hasChanged = true
 	}
 
-	if d.HasChange("tags") {
+	if d.HasChanges("tags", "tags_all") {
 		updateRequest.Tags = expandUpdatedStringsPtr(d.Get("tags"))
 		hasChanged = true
 	}
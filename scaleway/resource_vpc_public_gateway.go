@@ -198,7 +198,7 @@ func resourceScalewayVPCPublicGatewayUpdate(ctx context.Context, d *schema.Resou
 		updateRequest.Name = scw.StringPtr(d.Get("name").(string))
 	}
 
-	if d.HasChange("tags") {
+	if d.HasChanges("tags", "tags_all") {
 		updateRequest.Tags = expandUpdatedStringsPtr(d.Get("tags"))
 	}
 
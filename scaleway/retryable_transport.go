@@ -3,18 +3,174 @@ package scaleway
 import (
 	"bytes"
 	"context"
+	"encoding/json"
 	"io"
 	"io/ioutil"
 	"net/http"
+	"strconv"
 	"time"
 
 	"github.com/hashicorp/go-retryablehttp"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
+// retrySchema returns the provider schema of the retry block
+func retrySchema() *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeList,
+		Optional:    true,
+		MaxItems:    1,
+		Description: "The retry policy of the HTTP requests made to the Scaleway API",
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"max_retries": {
+					Type:         schema.TypeInt,
+					Optional:     true,
+					Default:      3,
+					Description:  "Maximum number of retries of a request",
+					ValidateFunc: validation.IntAtLeast(0),
+				},
+				"min_wait": {
+					Type:         schema.TypeString,
+					Optional:     true,
+					Description:  "Minimum time to wait between two retries (2s if none specified)",
+					ValidateFunc: validateDuration(),
+				},
+				"max_wait": {
+					Type:         schema.TypeString,
+					Optional:     true,
+					Description:  "Maximum time to wait between two retries, Retry-After headers are bounded by this value (2m if none specified)",
+					ValidateFunc: validateDuration(),
+				},
+				"retryable_status_codes": {
+					Type:        schema.TypeList,
+					Optional:    true,
+					Description: "List of HTTP status codes that are retried, 429 responses and network errors are always retried. (5XX status codes if none specified)",
+					Elem: &schema.Schema{
+						Type:         schema.TypeInt,
+						ValidateFunc: validation.IntBetween(100, 599),
+					},
+				},
+				"retry_transient_state": {
+					Type:        schema.TypeBool,
+					Optional:    true,
+					Default:     false,
+					Description: "Retry the requests failing with a 409 error because the resource is in a transient state",
+				},
+			},
+		},
+	}
+}
+
+// expandProviderRetry returns the retryable transport options of the provider retry block
+func expandProviderRetry(d *schema.ResourceData) (retryableTransportOptions, error) {
+	options := retryableTransportOptions{}
+	if d == nil {
+		return options, nil
+	}
+	if _, ok := d.GetOk("retry.0"); !ok {
+		return options, nil
+	}
+
+	retryMax := d.Get("retry.0.max_retries").(int)
+	options.RetryMax = &retryMax
+
+	minWait, err := expandDuration(d.Get("retry.0.min_wait"))
+	if err != nil {
+		return options, err
+	}
+	options.RetryWaitMin = minWait
+
+	maxWait, err := expandDuration(d.Get("retry.0.max_wait"))
+	if err != nil {
+		return options, err
+	}
+	options.RetryWaitMax = maxWait
+
+	for _, statusCode := range d.Get("retry.0.retryable_status_codes").([]interface{}) {
+		options.RetryStatusCodes = append(options.RetryStatusCodes, statusCode.(int))
+	}
+	options.RetryTransientState = d.Get("retry.0.retry_transient_state").(bool)
+
+	return options, nil
+}
+
 type retryableTransportOptions struct {
 	RetryMax     *int
 	RetryWaitMax *time.Duration
 	RetryWaitMin *time.Duration
+	// RetryStatusCodes overrides the list of HTTP status codes that are retried
+	RetryStatusCodes []int
+	// RetryTransientState enables the retry of 409 errors caused by a resource in a transient state
+	RetryTransientState bool
+}
+
+// isTransientStateResponse returns true if the response is a 409 caused by a resource in a transient state.
+// The response body is restored so it can be read again.
+func isTransientStateResponse(resp *http.Response) bool {
+	if resp == nil || resp.StatusCode != http.StatusConflict || resp.Body == nil {
+		return false
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	if err != nil {
+		return false
+	}
+
+	responseError := struct {
+		Type string `json:"type"`
+	}{}
+	if err := json.Unmarshal(body, &responseError); err != nil {
+		return false
+	}
+
+	return responseError.Type == "transient_state"
+}
+
+// retryAfter returns the delay requested by the Retry-After header of the response, if any.
+// Both delay-seconds and HTTP-date formats are supported.
+func retryAfter(resp *http.Response) (time.Duration, bool) {
+	if resp == nil {
+		return 0, false
+	}
+	header := resp.Header.Get("Retry-After")
+	if header == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.ParseInt(header, 10, 64); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	if date, err := http.ParseTime(header); err == nil {
+		delay := time.Until(date)
+		if delay < 0 {
+			delay = 0
+		}
+		return delay, true
+	}
+
+	return 0, false
+}
+
+// retryAfterBackoff is an exponential backoff that honours Retry-After headers.
+// The wait duration is always bounded by max.
+func retryAfterBackoff(min, max time.Duration, attemptNum int, resp *http.Response) time.Duration {
+	if delay, ok := retryAfter(resp); ok {
+		if delay > max {
+			return max
+		}
+		return delay
+	}
+
+	return retryablehttp.DefaultBackoff(min, max, attemptNum, nil)
 }
 
 func newRetryableTransportWithOptions(defaultTransport http.RoundTripper, options retryableTransportOptions) http.RoundTripper {
@@ -24,12 +180,38 @@ func newRetryableTransportWithOptions(defaultTransport http.RoundTripper, option
 	// Defaults
 	c.RetryMax = 3
 	c.RetryWaitMax = 2 * time.Minute
-	c.Logger = l
+	// Logs are written by the hooks to keep the fields of the request context
+	c.Logger = nil
+	c.RequestLogHook = func(_ retryablehttp.Logger, req *http.Request, retryNumber int) {
+		if retryNumber > 0 {
+			tflog.Debug(req.Context(), "retrying Scaleway API request", map[string]interface{}{
+				"http_method":  req.Method,
+				"http_host":    req.URL.Host,
+				"http_path":    req.URL.Path,
+				"retry_number": retryNumber,
+			})
+		}
+	}
 	c.RetryWaitMin = time.Second * 2
+	c.Backoff = retryAfterBackoff
 	c.CheckRetry = func(ctx context.Context, resp *http.Response, err error) (bool, error) {
 		if resp == nil || resp.StatusCode == http.StatusTooManyRequests {
 			return true, err
 		}
+		if options.RetryTransientState && isTransientStateResponse(resp) {
+			return true, nil
+		}
+		if len(options.RetryStatusCodes) > 0 {
+			if ctx.Err() != nil {
+				return false, ctx.Err()
+			}
+			for _, statusCode := range options.RetryStatusCodes {
+				if resp.StatusCode == statusCode {
+					return true, nil
+				}
+			}
+			return false, nil
+		}
 		return retryablehttp.DefaultRetryPolicy(ctx, resp, err)
 	}
 
@@ -78,7 +260,7 @@ func (c *retryableTransport) RoundTrip(r *http.Request) (*http.Response, error)
 		}
 		body = bytes.NewReader(bs)
 	}
-	req, err := retryablehttp.NewRequest(r.Method, r.URL.String(), body)
+	req, err := retryablehttp.NewRequestWithContext(r.Context(), r.Method, r.URL.String(), body)
 	if err != nil {
 		return nil, err
 	}
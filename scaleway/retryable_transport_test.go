@@ -0,0 +1,160 @@
+package scaleway
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/scaleway/scaleway-sdk-go/scw"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRetryAfter(t *testing.T) {
+	testCases := []struct {
+		name     string
+		header   string
+		expected time.Duration
+		found    bool
+	}{
+		{
+			name:  "no header",
+			found: false,
+		},
+		{
+			name:     "seconds",
+			header:   "5",
+			expected: 5 * time.Second,
+			found:    true,
+		},
+		{
+			name:     "past date",
+			header:   "Wed, 21 Oct 2015 07:28:00 GMT",
+			expected: 0,
+			found:    true,
+		},
+		{
+			name:   "invalid",
+			header: "soon",
+			found:  false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			resp := &http.Response{Header: http.Header{}}
+			if tc.header != "" {
+				resp.Header.Set("Retry-After", tc.header)
+			}
+			delay, found := retryAfter(resp)
+			assert.Equal(t, tc.found, found)
+			assert.Equal(t, tc.expected, delay)
+		})
+	}
+
+	resp := &http.Response{Header: http.Header{}}
+	resp.Header.Set("Retry-After", "3600")
+	assert.Equal(t, time.Minute, retryAfterBackoff(time.Second, time.Minute, 0, resp))
+}
+
+func TestRetryableTransportOptions(t *testing.T) {
+	testCases := []struct {
+		name            string
+		options         retryableTransportOptions
+		statusCode      int
+		body            string
+		expectedCalls   int32
+		expectedStatus  int
+		expectedPayload string
+	}{
+		{
+			name:           "default policy retries 5XX",
+			statusCode:     http.StatusBadGateway,
+			expectedCalls:  3,
+			expectedStatus: http.StatusBadGateway,
+		},
+		{
+			name:           "default policy does not retry 409",
+			statusCode:     http.StatusConflict,
+			body:           `{"type":"transient_state"}`,
+			expectedCalls:  1,
+			expectedStatus: http.StatusConflict,
+		},
+		{
+			name: "transient state retried",
+			options: retryableTransportOptions{
+				RetryTransientState: true,
+			},
+			statusCode:      http.StatusConflict,
+			body:            `{"type":"transient_state"}`,
+			expectedCalls:   3,
+			expectedStatus:  http.StatusConflict,
+			expectedPayload: `{"type":"transient_state"}`,
+		},
+		{
+			name: "other conflicts not retried",
+			options: retryableTransportOptions{
+				RetryTransientState: true,
+			},
+			statusCode:      http.StatusConflict,
+			body:            `{"type":"conflict"}`,
+			expectedCalls:   1,
+			expectedStatus:  http.StatusConflict,
+			expectedPayload: `{"type":"conflict"}`,
+		},
+		{
+			name: "custom status codes",
+			options: retryableTransportOptions{
+				RetryStatusCodes: []int{http.StatusServiceUnavailable},
+			},
+			statusCode:     http.StatusBadGateway,
+			expectedCalls:  1,
+			expectedStatus: http.StatusBadGateway,
+		},
+		{
+			name: "too many requests always retried",
+			options: retryableTransportOptions{
+				RetryStatusCodes: []int{http.StatusServiceUnavailable},
+			},
+			statusCode:     http.StatusTooManyRequests,
+			expectedCalls:  3,
+			expectedStatus: http.StatusTooManyRequests,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			calls := int32(0)
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				atomic.AddInt32(&calls, 1)
+				w.Header().Set("Retry-After", "0")
+				w.WriteHeader(tc.statusCode)
+				_, _ = w.Write([]byte(tc.body))
+			}))
+			defer server.Close()
+
+			retryMax := 2
+			tc.options.RetryMax = &retryMax
+			tc.options.RetryWaitMax = scw.TimeDurationPtr(0)
+			client := &http.Client{Transport: newRetryableTransportWithOptions(http.DefaultTransport, tc.options)}
+
+			req, err := http.NewRequest(http.MethodPost, server.URL, strings.NewReader("{}"))
+			require.NoError(t, err)
+			resp, err := client.Do(req)
+			require.NoError(t, err)
+			defer resp.Body.Close()
+
+			payload, err := io.ReadAll(resp.Body)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedStatus, resp.StatusCode)
+			assert.Equal(t, tc.expectedCalls, atomic.LoadInt32(&calls))
+			if tc.expectedPayload != "" {
+				assert.Equal(t, tc.expectedPayload, string(payload))
+			}
+		})
+	}
+}